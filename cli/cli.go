@@ -70,6 +70,13 @@ func NewDefraCommand(cfg *config.Config) *cobra.Command {
 		MakeIndexListCommand(),
 	)
 
+	webhook := MakeWebhookCommand()
+	webhook.AddCommand(
+		MakeWebhookAddCommand(),
+		MakeWebhookDeleteCommand(),
+		MakeWebhookListCommand(),
+	)
+
 	backup := MakeBackupCommand()
 	backup.AddCommand(
 		MakeBackupExportCommand(),
@@ -89,8 +96,10 @@ func NewDefraCommand(cfg *config.Config) *cobra.Command {
 		MakeCollectionKeysCommand(),
 		MakeCollectionDeleteCommand(),
 		MakeCollectionUpdateCommand(),
+		MakeCollectionUpsertCommand(),
 		MakeCollectionCreateCommand(),
 		MakeCollectionDescribeCommand(),
+		MakeCollectionChangesCommand(),
 	)
 
 	client := MakeClientCommand(cfg)
@@ -99,6 +108,7 @@ func NewDefraCommand(cfg *config.Config) *cobra.Command {
 		MakeRequestCommand(),
 		schema,
 		index,
+		webhook,
 		p2p,
 		backup,
 		tx,
@@ -0,0 +1,50 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func MakeCollectionChangesCommand() *cobra.Command {
+	var after uint64
+	var limit uint64
+	var cmd = &cobra.Command{
+		Use:   "changes [--after <sequence>] [--limit <limit>]",
+		Short: "List the changes recorded in the change log of a collection.",
+		Long: `List the changes recorded in the change log of a collection.
+
+Changes are ordered by their sequence, which increases monotonically.
+The changes after a given sequence can be listed by using the '--after' flag.
+
+Example: list all changes
+  defradb client collection changes --name User
+
+Example: list the next 100 changes after the change with sequence 200
+  defradb client collection changes --name User --after 200 --limit 100
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			col, ok := tryGetCollectionContext(cmd)
+			if !ok {
+				return cmd.Usage()
+			}
+
+			changes, err := col.GetChanges(cmd.Context(), after, limit)
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, changes)
+		},
+	}
+	cmd.Flags().Uint64Var(&after, "after", 0, "Sequence of the change to list changes after")
+	cmd.Flags().Uint64Var(&limit, "limit", 0, "Maximum number of changes to list")
+	return cmd
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func MakeCollectionUpsertCommand() *cobra.Command {
+	var filter string
+	var updater string
+	var cmd = &cobra.Command{
+		Use:   "upsert --filter <filter> --updater <updater> <document>",
+		Short: "Update documents matching a filter or create a new document.",
+		Long: `Update documents matching a filter or create a new document.
+
+The update and the create happen atomically within a single transaction.
+If no documents match the filter the given document is created.
+
+Example: upsert by filter
+  defradb client collection upsert --name User \
+  --filter '{ "name": { "_eq": "Bob" } }' --updater '{ "points": 100 }' '{ "name": "Bob", "points": 100 }'
+		`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			col, ok := tryGetCollectionContext(cmd)
+			if !ok {
+				return cmd.Usage()
+			}
+
+			if filter == "" || updater == "" {
+				return ErrNoFilterOrUpdater
+			}
+			doc, err := client.NewDocFromJSON([]byte(args[0]))
+			if err != nil {
+				return err
+			}
+			res, err := col.UpsertWithFilter(cmd.Context(), filter, doc, updater)
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, res)
+		},
+	}
+	cmd.Flags().StringVar(&filter, "filter", "", "Document filter")
+	cmd.Flags().StringVar(&updater, "updater", "", "Document updater")
+	return cmd
+}
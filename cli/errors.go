@@ -17,16 +17,21 @@ import (
 const (
 	errInvalidLensConfig        string = "invalid lens configuration"
 	errSchemaVersionNotOfSchema string = "the given schema version is from a different schema"
+	errInvalidIndexDirection    string = "invalid index field direction"
+	errInvalidWasmModuleID      string = "invalid wasm module ID, expected the format <path>=<id>"
 )
 
 var (
 	ErrNoDocOrFile              = errors.New("document or file must be defined")
 	ErrInvalidDocument          = errors.New("invalid document")
 	ErrNoDocKeyOrFilter         = errors.New("document key or filter must be defined")
+	ErrNoFilterOrUpdater        = errors.New("filter and updater must be defined")
 	ErrInvalidExportFormat      = errors.New("invalid export format")
 	ErrNoLensConfig             = errors.New("lens config cannot be empty")
 	ErrInvalidLensConfig        = errors.New("invalid lens configuration")
 	ErrSchemaVersionNotOfSchema = errors.New(errSchemaVersionNotOfSchema)
+	ErrInvalidIndexDirection    = errors.New(errInvalidIndexDirection)
+	ErrInvalidWasmModuleID      = errors.New(errInvalidWasmModuleID)
 )
 
 func NewErrInvalidLensConfig(inner error) error {
@@ -40,3 +45,18 @@ func NewErrSchemaVersionNotOfSchema(schemaRoot string, schemaVersionID string) e
 		errors.NewKV("SchemaVersionID", schemaVersionID),
 	)
 }
+
+func NewErrInvalidIndexDirection(field string, direction string) error {
+	return errors.New(
+		errInvalidIndexDirection,
+		errors.NewKV("Field", field),
+		errors.NewKV("Direction", direction),
+	)
+}
+
+func NewErrInvalidWasmModuleID(value string) error {
+	return errors.New(
+		errInvalidWasmModuleID,
+		errors.NewKV("Value", value),
+	)
+}
@@ -11,6 +11,8 @@
 package cli
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -21,29 +23,51 @@ func MakeIndexCreateCommand() *cobra.Command {
 	var collectionArg string
 	var nameArg string
 	var fieldsArg []string
+	var uniqueArg bool
 	var cmd = &cobra.Command{
-		Use:   "create -c --collection <collection> --fields <fields> [-n --name <name>]",
+		Use:   "create -c --collection <collection> --fields <fields> [-n --name <name>] [--unique]",
 		Short: "Creates a secondary index on a collection's field(s)",
 		Long: `Creates a secondary index on a collection's field(s).
 		
 The --name flag is optional. If not provided, a name will be generated automatically.
+The --unique flag is optional. If provided, the index will enforce unique field values.
+Several fields can be given to create a composite index. The direction of each field
+can be set by appending ':ASC' or ':DESC' to its name. The default direction is ascending.
 
 Example: create an index for 'Users' collection on 'name' field:
   defradb client index create --collection Users --fields name
 
 Example: create a named index for 'Users' collection on 'name' field:
-  defradb client index create --collection Users --fields name --name UsersByName`,
-		ValidArgs: []string{"collection", "fields", "name"},
+  defradb client index create --collection Users --fields name --name UsersByName
+
+Example: create a unique index for 'Users' collection on 'email' field:
+  defradb client index create --collection Users --fields email --unique
+
+Example: create a composite index for 'Users' collection on 'name' and 'age' fields:
+  defradb client index create --collection Users --fields name,age:DESC`,
+		ValidArgs: []string{"collection", "fields", "name", "unique"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			store := mustGetStoreContext(cmd)
 
 			var fields []client.IndexedFieldDescription
-			for _, name := range fieldsArg {
-				fields = append(fields, client.IndexedFieldDescription{Name: name})
+			for _, field := range fieldsArg {
+				name, direction, _ := strings.Cut(field, ":")
+				fieldDesc := client.IndexedFieldDescription{Name: name}
+				switch strings.ToUpper(direction) {
+				case "":
+				case string(client.Ascending):
+					fieldDesc.Direction = client.Ascending
+				case string(client.Descending):
+					fieldDesc.Direction = client.Descending
+				default:
+					return NewErrInvalidIndexDirection(name, direction)
+				}
+				fields = append(fields, fieldDesc)
 			}
 			desc := client.IndexDescription{
 				Name:   nameArg,
 				Fields: fields,
+				Unique: uniqueArg,
 			}
 			col, err := store.GetCollectionByName(cmd.Context(), collectionArg)
 			if err != nil {
@@ -62,6 +86,7 @@ Example: create a named index for 'Users' collection on 'name' field:
 	cmd.Flags().StringVarP(&collectionArg, "collection", "c", "", "Collection name")
 	cmd.Flags().StringVarP(&nameArg, "name", "n", "", "Index name")
 	cmd.Flags().StringSliceVar(&fieldsArg, "fields", []string{}, "Fields to index")
+	cmd.Flags().BoolVarP(&uniqueArg, "unique", "u", false, "Make the index unique")
 
 	return cmd
 }
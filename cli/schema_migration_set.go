@@ -24,6 +24,7 @@ import (
 
 func MakeSchemaMigrationSetCommand() *cobra.Command {
 	var lensFile string
+	var wasmModuleIDs []string
 	var cmd = &cobra.Command{
 		Use:   "set [src] [dst] [cfg]",
 		Short: "Set a schema migration within DefraDB",
@@ -38,6 +39,12 @@ Example: set from file:
 Example: add from stdin:
   cat schema_migration.lens | defradb client schema migration set bae123 bae456 -
 
+Example: set using a wasm module previously stored within the database:
+  defradb client schema migration set bae123 bae456 -f schema_migration.lens --wasm-module-id module.wasm=bafk123
+
+The content of the wasm modules is stored within the database, the wasm files do not need to remain
+at the given paths once the migration has been set.
+
 Learn more about the DefraDB GraphQL Schema Language on https://docs.source.network.`,
 		Args: cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -80,9 +87,22 @@ Learn more about the DefraDB GraphQL Schema Language on https://docs.source.netw
 				Lens:                       lensCfg,
 			}
 
+			for _, value := range wasmModuleIDs {
+				path, id, found := strings.Cut(value, "=")
+				if !found || path == "" || id == "" {
+					return NewErrInvalidWasmModuleID(value)
+				}
+				if migrationCfg.WasmModuleIDs == nil {
+					migrationCfg.WasmModuleIDs = map[string]string{}
+				}
+				migrationCfg.WasmModuleIDs[path] = id
+			}
+
 			return store.LensRegistry().SetMigration(cmd.Context(), migrationCfg)
 		},
 	}
 	cmd.Flags().StringVarP(&lensFile, "file", "f", "", "Lens configuration file")
+	cmd.Flags().StringArrayVar(&wasmModuleIDs, "wasm-module-id", nil,
+		"Content ID of a stored wasm module used by the configuration, in the format <path>=<id>")
 	return cmd
 }
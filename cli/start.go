@@ -116,6 +116,24 @@ func MakeStartCommand(cfg *config.Config) *cobra.Command {
 		log.FeedbackFatalE(context.Background(), "Could not bind net.p2pdisabled", err)
 	}
 
+	cmd.Flags().Bool(
+		"replicate-migrations", cfg.Net.MigrationReplicationEnabled,
+		"Replicate schema migrations to and from peers on the pubsub network",
+	)
+	err = cfg.BindFlag("net.migrationreplication", cmd.Flags().Lookup("replicate-migrations"))
+	if err != nil {
+		log.FeedbackFatalE(context.Background(), "Could not bind net.migrationreplication", err)
+	}
+
+	cmd.Flags().String(
+		"migration-trusted-peers", cfg.Net.MigrationTrustedPeers,
+		"List of peer IDs, in addition to the replicators, from which replicated schema migrations are accepted",
+	)
+	err = cfg.BindFlag("net.migrationtrustedpeers", cmd.Flags().Lookup("migration-trusted-peers"))
+	if err != nil {
+		log.FeedbackFatalE(context.Background(), "Could not bind net.migrationtrustedpeers", err)
+	}
+
 	cmd.Flags().Bool(
 		"tls", cfg.API.TLS,
 		"Enable serving the API over https",
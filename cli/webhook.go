@@ -0,0 +1,25 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func MakeWebhookCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "webhook",
+		Short: "Manage the webhooks of a running DefraDB instance",
+		Long: `Manage (add, delete, or list) webhooks on a DefraDB node.
+A webhook delivers the document changes of a collection to an HTTP endpoint.`,
+	}
+	return cmd
+}
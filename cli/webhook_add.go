@@ -0,0 +1,55 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func MakeWebhookAddCommand() *cobra.Command {
+	var collectionArg string
+	var filterArg string
+	var cmd = &cobra.Command{
+		Use:   "add -c --collection <collection> [-f --filter <filter>] <url>",
+		Short: "Add a webhook delivering the document changes of a collection",
+		Long: `Add a webhook delivering the document changes of a collection.
+
+The changes recorded after the webhook is added, whose documents match the filter, are
+POSTed to the URL as JSON in the order they were recorded. Failed deliveries are retried.
+
+Example: add a webhook for the 'Users' collection:
+  defradb client webhook add --collection Users http://localhost:8080/users
+
+Example: add a webhook with a filter:
+  defradb client webhook add --collection Users --filter '{age: {_gt: 21}}' http://localhost:8080/users
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := mustGetStoreContext(cmd)
+
+			webhook, err := store.AddWebhook(cmd.Context(), client.Webhook{
+				Collection: collectionArg,
+				URL:        args[0],
+				Filter:     filterArg,
+			})
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, webhook)
+		},
+	}
+	cmd.Flags().StringVarP(&collectionArg, "collection", "c", "", "Collection name")
+	cmd.Flags().StringVarP(&filterArg, "filter", "f", "", "Filter the documents of the changes to deliver")
+
+	return cmd
+}
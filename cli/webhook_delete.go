@@ -0,0 +1,40 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func MakeWebhookDeleteCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a webhook",
+		Long: `Delete a webhook and stop delivering changes to it.
+
+Example:
+  defradb client webhook delete 1
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := mustGetStoreContext(cmd)
+
+			id, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return err
+			}
+			return store.DeleteWebhook(cmd.Context(), uint32(id))
+		},
+	}
+	return cmd
+}
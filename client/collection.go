@@ -106,6 +106,22 @@ type Collection interface {
 	// Returns an ErrDocumentNotFound if a document is not found for any given DocKey.
 	UpdateWithKeys(context.Context, []DocKey, string) (*UpdateResult, error)
 
+	// UpsertWithFilter updates the documents matching the given filter, or creates the given
+	// document if no documents match.
+	//
+	// The provided updater must be a string Patch, string Merge Patch, a parsed Patch, or parsed Merge Patch
+	// else an ErrInvalidUpdater will be returned.
+	//
+	// If no documents match the filter and the given document already exists an error will be returned.
+	//
+	// The update and the create happen within a single transaction. Concurrent upserts that create
+	// the same document conflict, if the collection is not bound to a transaction the upsert is then
+	// retried so that it updates the created document if it matches the filter, otherwise the caller
+	// must retry the transaction.
+	// Concurrent upserts that create different documents, for example because they were given different
+	// documents to create, do not conflict and may both create their document.
+	UpsertWithFilter(ctx context.Context, filter any, doc *Document, updater string) (*UpsertResult, error)
+
 	// DeleteWith deletes a target document.
 	//
 	// Target can be a Filter statement, a single docKey, a single document, an array of docKeys,
@@ -160,6 +176,15 @@ type Collection interface {
 
 	// GetIndexes returns all the indexes that exist on the collection.
 	GetIndexes(ctx context.Context) ([]IndexDescription, error)
+
+	// GetChanges returns the changes recorded in the change log of the collection after
+	// the given sequence, ordered by sequence.
+	//
+	// At most `limit` changes are returned, unless `limit` is zero.
+	//
+	// Changes are recorded within the transaction that made them, regardless of whether
+	// update events are enabled.
+	GetChanges(ctx context.Context, after uint64, limit uint64) ([]Change, error)
 }
 
 // DocKeysResult wraps the result of an attempt at a DocKey retrieval operation.
@@ -178,6 +203,16 @@ type UpdateResult struct {
 	DocKeys []string
 }
 
+// UpsertResult wraps the result of an upsert call.
+type UpsertResult struct {
+	// Created is true if no documents matched the filter and a new document was created.
+	Created bool
+	// Count contains the number of documents updated or created by the upsert call.
+	Count int64
+	// DocKeys contains the DocKeys of all the documents updated or created by the upsert call.
+	DocKeys []string
+}
+
 // DeleteResult wraps the result of an delete call.
 type DeleteResult struct {
 	// Count contains the number of documents deleted by the delete call.
@@ -186,6 +221,22 @@ type DeleteResult struct {
 	DocKeys []string
 }
 
+// Change is a change to a document, as recorded in the change log of its collection.
+type Change struct {
+	// Sequence is the position of the change within the change log of the collection.
+	//
+	// Sequences are assigned locally, in the order that the changes were committed.
+	Sequence uint64 `json:"sequence"`
+	// Type is the type of the change, either CREATE, UPDATE or DELETE.
+	Type string `json:"type"`
+	// DocKey is the key of the document that was changed.
+	DocKey string `json:"docKey"`
+	// Cid is the CID of the composite block that contains the change.
+	Cid string `json:"cid"`
+	// Height is the height of the composite block within the DAG of the document.
+	Height uint64 `json:"height"`
+}
+
 // P2PCollection is the gRPC response representation of a P2P collection topic
 type P2PCollection struct {
 	// The collection ID
@@ -22,4 +22,7 @@ const (
 	LWW_REGISTER
 	OBJECT
 	COMPOSITE
+	PN_COUNTER
+	OR_SET
+	RGA
 )
@@ -93,8 +93,12 @@ type Store interface {
 	// AddSchema takes the provided GQL schema in SDL format, and applies it to the [Store],
 	// creating the necessary collections, request types, etc.
 	//
-	// All schema types provided must not exist prior to calling this, and they may not reference existing
-	// types previously defined.
+	// All schema types provided must not exist prior to calling this.  They may relate to existing types
+	// previously defined, in which case the other side of the relation is added to the existing type.  It
+	// is named after the new type, in the plural for the many side of a one-to-many relation (following the
+	// regular English rules, `categories` for `Category`), unless it is declared by extending the existing
+	// type (`extend type`).  The fields added to existing types will be
+	// applied as per [PatchSchema], creating new default schema versions.
 	AddSchema(context.Context, string) ([]CollectionDescription, error)
 
 	// PatchSchema takes the given JSON patch string and applies it to the set of SchemaDescriptions
@@ -112,6 +116,12 @@ type Store interface {
 	//
 	// Field [FieldKind] values may be provided in either their raw integer form, or as string as per
 	// [FieldKindStringToEnumMapping].
+	//
+	// Fields may be renamed or removed, excluding the key field, relation fields and indexed fields. Renamed
+	// fields keep their existing values, the values of removed fields are no longer accessible, and their
+	// field IDs will not be reused. The kind of existing LWW register fields may be widened as per
+	// [FieldKind.CanWidenTo], existing values will be coerced to the new kind. A Lens migration renaming,
+	// coercing and removing the fields will be registered between the previous and new schema versions.
 	PatchSchema(context.Context, string, bool) error
 
 	// SetDefaultSchemaVersion sets the default schema version to the ID provided.  It will be applied to all
@@ -135,6 +145,9 @@ type Store interface {
 	//
 	// Migrations will only run if there is a complete path from the document schema version to the latest local
 	// schema version.
+	//
+	// The content of the wasm modules used by the migration will be stored within the database, and is loaded from
+	// there when the migration is reloaded.
 	SetMigration(context.Context, LensConfig) error
 
 	// LensRegistry returns the LensRegistry in use by this database instance.
@@ -180,6 +193,22 @@ type Store interface {
 	// GetAllIndexes returns all the indexes that currently exist within this [Store].
 	GetAllIndexes(context.Context) (map[CollectionName][]IndexDescription, error)
 
+	// AddWebhook adds the given webhook, returning it with its ID set.
+	//
+	// The document changes of its collection that are recorded after it is added, and that match
+	// its filter, will be POSTed to its URL as [WebhookEvent]s in the order they were recorded.
+	// Failed deliveries are retried with an exponential backoff, and the delivery progress is
+	// persisted so that it resumes where it left off should the database be restarted.
+	//
+	// It will return an error if update events are not enabled on the database.
+	AddWebhook(context.Context, Webhook) (Webhook, error)
+
+	// DeleteWebhook deletes the webhook with the given ID, stopping any deliveries to it.
+	DeleteWebhook(context.Context, uint32) error
+
+	// GetAllWebhooks returns all the webhooks that currently exist within this [Store].
+	GetAllWebhooks(context.Context) ([]Webhook, error)
+
 	// ExecRequest executes the given GQL request against the [Store].
 	ExecRequest(context.Context, string) *RequestResult
 }
@@ -198,6 +227,12 @@ type GQLResult struct {
 	//
 	// It will be nil if any errors were raised during execution.
 	Data any `json:"data"`
+
+	// ResumeToken is the token of the event that produced this result, if it was
+	// yielded by a subscription.
+	//
+	// It may be given to a new subscription in order to resume from after this event.
+	ResumeToken string `json:"-"`
 }
 
 // RequestResult represents the results of a GQL request.
@@ -146,11 +146,115 @@ func (f FieldKind) String() string {
 		return "[String]"
 	case FieldKind_STRING_ARRAY:
 		return "[String!]"
+	case FieldKind_JSON:
+		return "JSON"
+	case FieldKind_BLOB:
+		return "Blob"
 	default:
 		return fmt.Sprint(uint8(f))
 	}
 }
 
+// SupportsCRDT returns true if values of this kind can be replicated with the given CRDT type.
+//
+// Counters can only hold numbers, sets can only hold arrays of scalars and
+// sequences can only hold strings.
+func (f FieldKind) SupportsCRDT(crdtType CType) bool {
+	switch crdtType {
+	case PN_COUNTER:
+		return f == FieldKind_INT || f == FieldKind_FLOAT
+	case OR_SET:
+		return f.IsScalarArray()
+	case RGA:
+		return f == FieldKind_STRING
+	default:
+		return true
+	}
+}
+
+// IsScalarArray returns true if this kind is an array of scalar values.
+func (f FieldKind) IsScalarArray() bool {
+	switch f {
+	case FieldKind_BOOL_ARRAY,
+		FieldKind_INT_ARRAY,
+		FieldKind_FLOAT_ARRAY,
+		FieldKind_STRING_ARRAY,
+		FieldKind_NILLABLE_BOOL_ARRAY,
+		FieldKind_NILLABLE_INT_ARRAY,
+		FieldKind_NILLABLE_FLOAT_ARRAY,
+		FieldKind_NILLABLE_STRING_ARRAY:
+		return true
+	default:
+		return false
+	}
+}
+
+// ElementKind returns the kind of the elements of this kind if it is an array of scalar values,
+// and whether those elements are nillable.
+//
+// Returns [FieldKind_None] if this kind is not an array of scalar values.
+func (f FieldKind) ElementKind() (FieldKind, bool) {
+	switch f {
+	case FieldKind_BOOL_ARRAY:
+		return FieldKind_BOOL, false
+	case FieldKind_NILLABLE_BOOL_ARRAY:
+		return FieldKind_BOOL, true
+	case FieldKind_INT_ARRAY:
+		return FieldKind_INT, false
+	case FieldKind_NILLABLE_INT_ARRAY:
+		return FieldKind_INT, true
+	case FieldKind_FLOAT_ARRAY:
+		return FieldKind_FLOAT, false
+	case FieldKind_NILLABLE_FLOAT_ARRAY:
+		return FieldKind_FLOAT, true
+	case FieldKind_STRING_ARRAY:
+		return FieldKind_STRING, false
+	case FieldKind_NILLABLE_STRING_ARRAY:
+		return FieldKind_STRING, true
+	default:
+		return FieldKind_None, false
+	}
+}
+
+// CanWidenTo returns true if the kind of an existing field may be changed from this kind to the
+// given kind, with any existing values being coerced to the new kind.
+//
+// Integers may be widened to floats, and booleans, integers, floats and datetimes to strings.
+// Strings may be widened to datetimes, string values that are not valid RFC3339 datetimes will
+// become nil.  Arrays may be widened to arrays of nillable elements, and arrays of integers to
+// arrays of floats.
+func (f FieldKind) CanWidenTo(other FieldKind) bool {
+	if f == other {
+		return true
+	}
+
+	if f.IsScalarArray() || other.IsScalarArray() {
+		if !f.IsScalarArray() || !other.IsScalarArray() {
+			return false
+		}
+
+		elementKind, isNillable := f.ElementKind()
+		otherElementKind, otherIsNillable := other.ElementKind()
+		if isNillable && !otherIsNillable {
+			return false
+		}
+
+		return elementKind == otherElementKind ||
+			(elementKind == FieldKind_INT && otherElementKind == FieldKind_FLOAT)
+	}
+
+	switch f {
+	case FieldKind_INT:
+		return other == FieldKind_FLOAT || other == FieldKind_STRING
+	case FieldKind_BOOL, FieldKind_FLOAT, FieldKind_DATETIME:
+		return other == FieldKind_STRING
+	case FieldKind_STRING:
+		return other == FieldKind_DATETIME
+	default:
+		return false
+	}
+}
+
 // Note: These values are serialized and persisted in the database, avoid modifying existing values.
 const (
 	FieldKind_None         FieldKind = 0
@@ -167,8 +271,8 @@ const (
 	FieldKind_STRING       FieldKind = 11
 	FieldKind_STRING_ARRAY FieldKind = 12
 	_                      FieldKind = 13 // safe to repurpose (was never used)
-	_                      FieldKind = 14 // safe to repurpose (was never used)
-	_                      FieldKind = 15 // safe to repurpose (was never used)
+	FieldKind_JSON         FieldKind = 14 // schemaless value of any JSON type, including nested objects
+	FieldKind_BLOB         FieldKind = 15 // binary data, base64 encoded when represented as text
 
 	// Embedded object, but accessed via foreign keys
 	FieldKind_FOREIGN_OBJECT FieldKind = 16
@@ -204,6 +308,8 @@ var FieldKindStringToEnumMapping = map[string]FieldKind{
 	"String":     FieldKind_STRING,
 	"[String]":   FieldKind_NILLABLE_STRING_ARRAY,
 	"[String!]":  FieldKind_STRING_ARRAY,
+	"JSON":       FieldKind_JSON,
+	"Blob":       FieldKind_BLOB,
 }
 
 // RelationType describes the type of relation between two types.
@@ -302,7 +302,7 @@ func (doc *Document) setAndParseType(field string, value any) error {
 		}
 
 	// string, bool, and more
-	case string, bool, int64, []any, []bool, []*bool, []int64, []*int64, []float64, []*float64, []string, []*string:
+	case string, bool, int64, []byte, []any, []bool, []*bool, []int64, []*int64, []float64, []*float64, []string, []*string:
 		err := doc.setCBOR(LWW_REGISTER, field, val)
 		if err != nil {
 			return err
@@ -450,6 +450,7 @@ func (doc *Document) toMap() (map[string]any, error) {
 				return nil, err
 			}
 			docMap[k] = subDocMap
+			continue
 		}
 
 		docMap[k] = value.Value()
@@ -470,11 +471,12 @@ func (doc *Document) toMapWithKey() (map[string]any, error) {
 
 		if value.IsDocument() {
 			subDoc := value.Value().(*Document)
-			subDocMap, err := subDoc.toMapWithKey()
+			subDocMap, err := subDoc.toMap()
 			if err != nil {
 				return nil, err
 			}
 			docMap[k] = subDocMap
+			continue
 		}
 
 		docMap[k] = value.Value()
@@ -24,6 +24,7 @@ const (
 	errMaxTxnRetries        string = "reached maximum transaction reties"
 	errRelationOneSided     string = "relation must be defined on both schemas"
 	errCollectionNotFound   string = "collection not found"
+	errCRDTKindMismatch     string = "CRDT type is not supported for fields of the given kind"
 )
 
 // Errors returnable from this package.
@@ -47,6 +48,7 @@ var (
 	ErrMaxTxnRetries        = errors.New(errMaxTxnRetries)
 	ErrRelationOneSided     = errors.New(errRelationOneSided)
 	ErrCollectionNotFound   = errors.New(errCollectionNotFound)
+	ErrCRDTKindMismatch     = errors.New(errCRDTKindMismatch)
 )
 
 // NewErrFieldNotExist returns an error indicating that the given field does not exist.
@@ -123,3 +125,14 @@ func NewErrCollectionNotFoundForSchema(schemaRoot string) error {
 		errors.NewKV("SchemaRoot", schemaRoot),
 	)
 }
+
+// NewErrCRDTKindMismatch returns an error indicating that the given CRDT type can not
+// be used by the field with the given name and kind.
+func NewErrCRDTKindMismatch(name string, crdtType CType, kind FieldKind) error {
+	return errors.New(
+		errCRDTKindMismatch,
+		errors.NewKV("Name", name),
+		errors.NewKV("CRDTType", crdtType),
+		errors.NewKV("Kind", kind),
+	)
+}
@@ -35,9 +35,21 @@ type LensConfig struct {
 
 	// The configuration of the Lens module.
 	//
-	// For now, the wasm module must remain at the location specified as long as the
-	// migration is active.
+	// The content of the wasm modules is stored within the database when the migration is
+	// set, the wasm files do not need to remain at the location specified afterwards.
+	//
+	// Modules may also be the native modules declared in the [lens] package, such as the
+	// rename field, set default, copy field, map values and drop field modules.  These are
+	// executed in-process, do not require a wasm file, and may be freely mixed with wasm modules.
 	model.Lens
+
+	// WasmModuleIDs contains the content identifiers (CIDs) of the wasm modules used by this
+	// migration, by module path.
+	//
+	// It is populated when the migration is set, at which point the content of the wasm modules
+	// is stored within the database.  If an ID is provided for a module, the content stored under
+	// that ID will be used and the wasm file does not need to exist at the given path.
+	WasmModuleIDs map[string]string
 }
 
 // LensRegistry exposes several useful thread-safe migration related functions which may
@@ -61,6 +73,9 @@ type LensRegistry interface {
 	//
 	// Migrations will only run if there is a complete path from the document schema version to the latest local
 	// schema version.
+	//
+	// The content of the wasm modules used by the migration will be stored within the database, and is loaded from
+	// there when the migration is reloaded.
 	SetMigration(context.Context, LensConfig) error
 
 	// ReloadLenses clears any cached migrations, loads their configurations from the database and re-initializes
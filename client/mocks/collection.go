@@ -725,6 +725,62 @@ func (_c *Collection_GetAllDocKeys_Call) RunAndReturn(run func(context.Context)
 	return _c
 }
 
+// GetChanges provides a mock function with given fields: ctx, after, limit
+func (_m *Collection) GetChanges(ctx context.Context, after uint64, limit uint64) ([]client.Change, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	var r0 []client.Change
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]client.Change, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []client.Change); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Change)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collection_GetChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChanges'
+type Collection_GetChanges_Call struct {
+	*mock.Call
+}
+
+// GetChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after uint64
+//   - limit uint64
+func (_e *Collection_Expecter) GetChanges(ctx interface{}, after interface{}, limit interface{}) *Collection_GetChanges_Call {
+	return &Collection_GetChanges_Call{Call: _e.mock.On("GetChanges", ctx, after, limit)}
+}
+
+func (_c *Collection_GetChanges_Call) Run(run func(ctx context.Context, after uint64, limit uint64)) *Collection_GetChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *Collection_GetChanges_Call) Return(_a0 []client.Change, _a1 error) *Collection_GetChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collection_GetChanges_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]client.Change, error)) *Collection_GetChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetIndexes provides a mock function with given fields: ctx
 func (_m *Collection) GetIndexes(ctx context.Context) ([]client.IndexDescription, error) {
 	ret := _m.Called(ctx)
@@ -1253,6 +1309,63 @@ func (_c *Collection_UpdateWithKeys_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpsertWithFilter provides a mock function with given fields: ctx, filter, doc, updater
+func (_m *Collection) UpsertWithFilter(ctx context.Context, filter interface{}, doc *client.Document, updater string) (*client.UpsertResult, error) {
+	ret := _m.Called(ctx, filter, doc, updater)
+
+	var r0 *client.UpsertResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, *client.Document, string) (*client.UpsertResult, error)); ok {
+		return rf(ctx, filter, doc, updater)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, *client.Document, string) *client.UpsertResult); ok {
+		r0 = rf(ctx, filter, doc, updater)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.UpsertResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, *client.Document, string) error); ok {
+		r1 = rf(ctx, filter, doc, updater)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collection_UpsertWithFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertWithFilter'
+type Collection_UpsertWithFilter_Call struct {
+	*mock.Call
+}
+
+// UpsertWithFilter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter interface{}
+//   - doc *client.Document
+//   - updater string
+func (_e *Collection_Expecter) UpsertWithFilter(ctx interface{}, filter interface{}, doc interface{}, updater interface{}) *Collection_UpsertWithFilter_Call {
+	return &Collection_UpsertWithFilter_Call{Call: _e.mock.On("UpsertWithFilter", ctx, filter, doc, updater)}
+}
+
+func (_c *Collection_UpsertWithFilter_Call) Run(run func(ctx context.Context, filter interface{}, doc *client.Document, updater string)) *Collection_UpsertWithFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(*client.Document), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Collection_UpsertWithFilter_Call) Return(_a0 *client.UpsertResult, _a1 error) *Collection_UpsertWithFilter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collection_UpsertWithFilter_Call) RunAndReturn(run func(context.Context, interface{}, *client.Document, string) (*client.UpsertResult, error)) *Collection_UpsertWithFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithTxn provides a mock function with given fields: _a0
 func (_m *Collection) WithTxn(_a0 datastore.Txn) client.Collection {
 	ret := _m.Called(_a0)
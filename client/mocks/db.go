@@ -83,6 +83,59 @@ func (_c *DB_AddSchema_Call) RunAndReturn(run func(context.Context, string) ([]c
 	return _c
 }
 
+// AddWebhook provides a mock function with given fields: _a0, _a1
+func (_m *DB) AddWebhook(_a0 context.Context, _a1 client.Webhook) (client.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 client.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.Webhook) (client.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.Webhook) client.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(client.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.Webhook) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_AddWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWebhook'
+type DB_AddWebhook_Call struct {
+	*mock.Call
+}
+
+// AddWebhook is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 client.Webhook
+func (_e *DB_Expecter) AddWebhook(_a0 interface{}, _a1 interface{}) *DB_AddWebhook_Call {
+	return &DB_AddWebhook_Call{Call: _e.mock.On("AddWebhook", _a0, _a1)}
+}
+
+func (_c *DB_AddWebhook_Call) Run(run func(_a0 context.Context, _a1 client.Webhook)) *DB_AddWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(client.Webhook))
+	})
+	return _c
+}
+
+func (_c *DB_AddWebhook_Call) Return(_a0 client.Webhook, _a1 error) *DB_AddWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_AddWebhook_Call) RunAndReturn(run func(context.Context, client.Webhook) (client.Webhook, error)) *DB_AddWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BasicExport provides a mock function with given fields: ctx, config
 func (_m *DB) BasicExport(ctx context.Context, config *client.BackupConfig) error {
 	ret := _m.Called(ctx, config)
@@ -244,6 +297,49 @@ func (_c *DB_Close_Call) RunAndReturn(run func()) *DB_Close_Call {
 	return _c
 }
 
+// DeleteWebhook provides a mock function with given fields: _a0, _a1
+func (_m *DB) DeleteWebhook(_a0 context.Context, _a1 uint32) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type DB_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uint32
+func (_e *DB_Expecter) DeleteWebhook(_a0 interface{}, _a1 interface{}) *DB_DeleteWebhook_Call {
+	return &DB_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", _a0, _a1)}
+}
+
+func (_c *DB_DeleteWebhook_Call) Run(run func(_a0 context.Context, _a1 uint32)) *DB_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint32))
+	})
+	return _c
+}
+
+func (_c *DB_DeleteWebhook_Call) Return(_a0 error) *DB_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_DeleteWebhook_Call) RunAndReturn(run func(context.Context, uint32) error) *DB_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Events provides a mock function with given fields:
 func (_m *DB) Events() events.Events {
 	ret := _m.Called()
@@ -492,6 +588,60 @@ func (_c *DB_GetAllSchema_Call) RunAndReturn(run func(context.Context) ([]client
 	return _c
 }
 
+// GetAllWebhooks provides a mock function with given fields: _a0
+func (_m *DB) GetAllWebhooks(_a0 context.Context) ([]client.Webhook, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []client.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]client.Webhook, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []client.Webhook); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetAllWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllWebhooks'
+type DB_GetAllWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetAllWebhooks is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *DB_Expecter) GetAllWebhooks(_a0 interface{}) *DB_GetAllWebhooks_Call {
+	return &DB_GetAllWebhooks_Call{Call: _e.mock.On("GetAllWebhooks", _a0)}
+}
+
+func (_c *DB_GetAllWebhooks_Call) Run(run func(_a0 context.Context)) *DB_GetAllWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_GetAllWebhooks_Call) Return(_a0 []client.Webhook, _a1 error) *DB_GetAllWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetAllWebhooks_Call) RunAndReturn(run func(context.Context) ([]client.Webhook, error)) *DB_GetAllWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCollectionByName provides a mock function with given fields: _a0, _a1
 func (_m *DB) GetCollectionByName(_a0 context.Context, _a1 string) (client.Collection, error) {
 	ret := _m.Called(_a0, _a1)
@@ -16,6 +16,11 @@ type Aggregate struct {
 	Field
 
 	Targets []*AggregateTarget
+
+	// Distinct is true if only distinct values should be aggregated.
+	//
+	// It is currently only supported by count.
+	Distinct bool
 }
 
 type AggregateTarget struct {
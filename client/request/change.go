@@ -0,0 +1,44 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+import "github.com/sourcenetwork/immutable"
+
+var (
+	_ Selection = (*ChangeSelect)(nil)
+)
+
+// ChangeSelect is a request for the changes recorded in the change log of a collection.
+type ChangeSelect struct {
+	Field
+
+	// Collection is the name of the collection to return the changes of.
+	Collection string
+
+	// After is the sequence of the change to return the changes after.
+	After immutable.Option[uint64]
+
+	// Limit is the maximum number of changes to return.
+	Limit immutable.Option[uint64]
+
+	Fields []Selection
+}
+
+func (c ChangeSelect) ToSelect() *Select {
+	return &Select{
+		Field: Field{
+			Name:  c.Name,
+			Alias: c.Alias,
+		},
+		Fields: c.Fields,
+		Root:   ChangeSelection,
+	}
+}
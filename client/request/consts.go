@@ -20,34 +20,49 @@ const (
 	RelatedObjectID = "_id"
 
 	Cid         = "cid"
+	Collection  = "collection"
 	Data        = "data"
 	DocKey      = "dockey"
 	DocKeys     = "dockeys"
+	Distinct    = "distinct"
 	FieldName   = "field"
 	FieldIDName = "fieldId"
 	Id          = "id"
 	Ids         = "ids"
+	Input       = "input"
 	ShowDeleted = "showDeleted"
 
+	UpsertCreate = "create"
+	UpsertUpdate = "update"
+
 	FilterClause  = "filter"
 	GroupByClause = "groupBy"
 	LimitClause   = "limit"
 	OffsetClause  = "offset"
 	OrderClause   = "order"
 	DepthClause   = "depth"
+	EventsClause  = "events"
+	AfterClause   = "after"
 
 	AverageFieldName = "_avg"
 	CountFieldName   = "_count"
 	KeyFieldName     = "_key"
+	MaxFieldName     = "_max"
+	MinFieldName     = "_min"
 	GroupFieldName   = "_group"
 	DeletedFieldName = "_deleted"
+	EventFieldName   = "_event"
 	SumFieldName     = "_sum"
 	VersionFieldName = "_version"
 
+	EventTypeFieldName     = "type"
+	EventPreviousFieldName = "previous"
+
 	ExplainLabel = "explain"
 
 	LatestCommitsName = "latestCommits"
 	CommitsName       = "commits"
+	ChangesName       = "changes"
 
 	CommitTypeName           = "Commit"
 	LinksFieldName           = "links"
@@ -63,6 +78,18 @@ const (
 	LinksNameFieldName = "name"
 	LinksCidFieldName  = "cid"
 
+	ChangeTypeName      = "Change"
+	SequenceFieldName   = "sequence"
+	ChangeTypeFieldName = "type"
+
+	SetAddOperation    = "_add"
+	SetRemoveOperation = "_remove"
+
+	TextSpliceOperation = "_splice"
+	TextSpliceIndex     = "index"
+	TextSpliceDelete    = "delete"
+	TextSpliceInsert    = "insert"
+
 	ASC  = OrderDirection("ASC")
 	DESC = OrderDirection("DESC")
 )
@@ -80,6 +107,8 @@ var (
 		CountFieldName:    true,
 		SumFieldName:      true,
 		AverageFieldName:  true,
+		MinFieldName:      true,
+		MaxFieldName:      true,
 		KeyFieldName:      true,
 		DeletedFieldName:  true,
 	}
@@ -88,6 +117,8 @@ var (
 		CountFieldName:   {},
 		SumFieldName:     {},
 		AverageFieldName: {},
+		MinFieldName:     {},
+		MaxFieldName:     {},
 	}
 
 	CommitQueries = map[string]struct{}{
@@ -110,4 +141,12 @@ var (
 		LinksNameFieldName,
 		LinksCidFieldName,
 	}
+
+	ChangeFields = []string{
+		SequenceFieldName,
+		ChangeTypeFieldName,
+		DockeyFieldName,
+		CidFieldName,
+		HeightFieldName,
+	}
 )
@@ -14,6 +14,8 @@ const (
 	FilterOpOr  = "_or"
 	FilterOpAnd = "_and"
 	FilterOpNot = "_not"
+
+	FilterOpRegex = "_regex"
 )
 
 // Filter contains the parsed condition map to be
@@ -19,6 +19,7 @@ const (
 	CreateObjects
 	UpdateObjects
 	DeleteObjects
+	UpsertObjects
 )
 
 // ObjectMutation is a field on the `mutation` operation of a graphql request. It includes
@@ -35,6 +36,14 @@ type ObjectMutation struct {
 	Filter immutable.Option[Filter]
 	Data   string
 
+	// Input holds the json representations of the documents to create
+	// if this is a batch create mutation.
+	Input []string
+
+	// CreateData is the json representation of the document to create
+	// if an upsert does not match any existing documents.
+	CreateData string
+
 	Fields []Selection
 }
 
@@ -20,6 +20,7 @@ type SelectionType int
 const (
 	ObjectSelection SelectionType = iota
 	CommitSelection
+	ChangeSelection
 )
 
 // Select is a complex Field with strong typing.
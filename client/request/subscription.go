@@ -14,6 +14,15 @@ import (
 	"github.com/sourcenetwork/immutable"
 )
 
+// SubscriptionEventType is the type of document event that a subscription may yield.
+type SubscriptionEventType string
+
+const (
+	CreateEvent SubscriptionEventType = "CREATE"
+	UpdateEvent SubscriptionEventType = "UPDATE"
+	DeleteEvent SubscriptionEventType = "DELETE"
+)
+
 // ObjectSubscription is a field on the SubscriptionType
 // of a graphql request. It includes all the possible
 // arguments
@@ -25,9 +34,49 @@ type ObjectSubscription struct {
 
 	Filter immutable.Option[Filter]
 
+	// Events are the types of document event this subscription yields.
+	//
+	// If empty, all event types are yielded.
+	Events []SubscriptionEventType
+
+	// ResumeToken is the token of the last event received by a previous subscription.
+	//
+	// If set, the events that occurred after it are replayed before any new events are
+	// yielded.
+	ResumeToken immutable.Option[string]
+
+	// EventField is the `_event` metadata field, if it was requested.
+	EventField immutable.Option[SubscriptionEventField]
+
 	Fields []Selection
 }
 
+// SubscriptionEventField is the `_event` metadata field of a subscription. It
+// describes the event that caused the subscription to yield a document.
+type SubscriptionEventField struct {
+	Field
+
+	// Type is the `type` field of the event, if it was requested.
+	Type immutable.Option[Field]
+
+	// Previous is the `previous` field of the event, if it was requested. It holds
+	// the selected fields of the document as they were before the event.
+	Previous immutable.Option[Select]
+}
+
+// HasEvent returns true if this subscription yields events of the given type.
+func (m ObjectSubscription) HasEvent(eventType SubscriptionEventType) bool {
+	if len(m.Events) == 0 {
+		return true
+	}
+	for _, e := range m.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
 // ToSelect returns a basic Select object, with the same Name, Alias, and Fields as
 // the Subscription object. Used to create a Select planNode for the event stream return objects.
 func (m ObjectSubscription) ToSelect(docKey, cid string) *Select {
@@ -42,3 +91,16 @@ func (m ObjectSubscription) ToSelect(docKey, cid string) *Select {
 		Filter:  m.Filter,
 	}
 }
+
+// ToPreviousSelect returns a Select object for the previous values of the document,
+// as requested by the `previous` field of the `_event` metadata field.
+func (m ObjectSubscription) ToPreviousSelect(docKey, cid string) *Select {
+	return &Select{
+		Field: Field{
+			Name: m.Collection,
+		},
+		DocKeys: immutable.Some([]string{docKey}),
+		CID:     immutable.Some(cid),
+		Fields:  m.EventField.Value().Previous.Value().Fields,
+	}
+}
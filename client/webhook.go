@@ -0,0 +1,39 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// Webhook is an HTTP endpoint that the document changes of a collection are delivered to.
+type Webhook struct {
+	// ID is the local identifier of this webhook, it is assigned when the webhook is added.
+	ID uint32
+	// Collection is the name of the collection whose document changes are delivered.
+	Collection string
+	// URL is the address that document changes are POSTed to.
+	URL string
+	// Filter is an optional GraphQL filter that documents must match for their changes
+	// to be delivered, for example `{age: {_gt: 21}}`.
+	Filter string
+}
+
+// WebhookEvent is the body POSTed to a webhook for each document change delivered to it.
+type WebhookEvent struct {
+	// Webhook is the ID of the webhook the change is delivered to.
+	Webhook uint32 `json:"webhook"`
+	// Collection is the name of the collection the document belongs to.
+	Collection string `json:"collection"`
+
+	Change
+
+	// Data contains the fields of the document as of this change.
+	//
+	// The fields of a deleted document are those it had before it was deleted.
+	Data map[string]any `json:"data"`
+}
@@ -52,6 +52,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/mitchellh/mapstructure"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/spf13/pflag"
@@ -349,20 +350,24 @@ func (apicfg *APIConfig) AddressToURL() string {
 
 // NetConfig configures aspects of network and peer-to-peer.
 type NetConfig struct {
-	P2PAddress    string
-	P2PDisabled   bool
-	Peers         string
-	PubSubEnabled bool `mapstructure:"pubsub"`
-	RelayEnabled  bool `mapstructure:"relay"`
+	P2PAddress                  string
+	P2PDisabled                 bool
+	Peers                       string
+	PubSubEnabled               bool   `mapstructure:"pubsub"`
+	RelayEnabled                bool   `mapstructure:"relay"`
+	MigrationReplicationEnabled bool   `mapstructure:"migrationreplication"`
+	MigrationTrustedPeers       string `mapstructure:"migrationtrustedpeers"`
 }
 
 func defaultNetConfig() *NetConfig {
 	return &NetConfig{
-		P2PAddress:    "/ip4/0.0.0.0/tcp/9171",
-		P2PDisabled:   false,
-		Peers:         "",
-		PubSubEnabled: true,
-		RelayEnabled:  false,
+		P2PAddress:                  "/ip4/0.0.0.0/tcp/9171",
+		P2PDisabled:                 false,
+		Peers:                       "",
+		PubSubEnabled:               true,
+		RelayEnabled:                false,
+		MigrationReplicationEnabled: false,
+		MigrationTrustedPeers:       "",
 	}
 }
 
@@ -382,6 +387,14 @@ func (netcfg *NetConfig) validate() error {
 			maddrs[i] = addr
 		}
 	}
+	if len(netcfg.MigrationTrustedPeers) > 0 {
+		for _, id := range strings.Split(netcfg.MigrationTrustedPeers, ",") {
+			_, err := peer.Decode(id)
+			if err != nil {
+				return NewErrInvalidTrustedPeers(err, netcfg.MigrationTrustedPeers)
+			}
+		}
+	}
 	return nil
 }
 
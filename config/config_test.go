@@ -383,6 +383,20 @@ func TestValidationInvalidNetConfigPeers(t *testing.T) {
 	assert.ErrorIs(t, err, ErrFailedToValidateConfig)
 }
 
+func TestValidationNetConfigMigrationTrustedPeers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Net.MigrationTrustedPeers = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"
+	err := cfg.validate()
+	assert.NoError(t, err)
+}
+
+func TestValidationInvalidNetConfigMigrationTrustedPeers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Net.MigrationTrustedPeers = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N,notapeer"
+	err := cfg.validate()
+	assert.ErrorIs(t, err, ErrFailedToValidateConfig)
+}
+
 func TestValidationInvalidLoggingConfig(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.Log.Level = "546578"
@@ -30,6 +30,7 @@ const (
 	errInvalidP2PAddress           string = "invalid P2P address"
 	errInvalidRPCAddress           string = "invalid RPC address"
 	errInvalidBootstrapPeers       string = "invalid bootstrap peers"
+	errInvalidTrustedPeers         string = "invalid migration trusted peers"
 	errInvalidLogLevel             string = "invalid log level"
 	errInvalidDatastoreType        string = "invalid store type"
 	errInvalidLogFormat            string = "invalid log format"
@@ -66,6 +67,7 @@ var (
 	ErrInvalidP2PAddress           = errors.New(errInvalidP2PAddress)
 	ErrInvalidRPCAddress           = errors.New(errInvalidRPCAddress)
 	ErrInvalidBootstrapPeers       = errors.New(errInvalidBootstrapPeers)
+	ErrInvalidTrustedPeers         = errors.New(errInvalidTrustedPeers)
 	ErrInvalidLogLevel             = errors.New(errInvalidLogLevel)
 	ErrInvalidDatastoreType        = errors.New(errInvalidDatastoreType)
 	ErrOverrideConfigConvertFailed = errors.New(errOverrideConfigConvertFailed)
@@ -142,6 +144,10 @@ func NewErrInvalidBootstrapPeers(inner error, peers string) error {
 	return errors.Wrap(errInvalidBootstrapPeers, inner, errors.NewKV("peers", peers))
 }
 
+func NewErrInvalidTrustedPeers(inner error, peers string) error {
+	return errors.Wrap(errInvalidTrustedPeers, inner, errors.NewKV("peers", peers))
+}
+
 func NewErrInvalidLogLevel(level string) error {
 	return errors.New(errInvalidLogLevel, errors.NewKV("level", level))
 }
@@ -0,0 +1,21 @@
+package connor
+
+// all will determine whether all items of the data
+// array match the conditions.
+//
+// It passes for empty arrays.
+func all(conditions, data any) (bool, error) {
+	items, ok := getArrayItems(data)
+	if !ok {
+		return false, nil
+	}
+	for _, item := range items {
+		if m, err := eq(conditions, item); err != nil {
+			return false, err
+		} else if !m {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,35 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	// all items match
+	result, err := all(map[FilterKey]any{&operator{"_gt"}: int64(0)}, []int64{1, 5, 10})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// not all items match
+	result, err = all(map[FilterKey]any{&operator{"_gt"}: int64(1)}, []int64{1, 5, 10})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// all items equal the value
+	result, err = all("web3", []string{"web3", "web3"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// not an array
+	result, err = all(int64(5), int64(5))
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestAll_WithEmptyArray_ShouldMatch(t *testing.T) {
+	result, err := all(map[FilterKey]any{&operator{"_gt"}: int64(0)}, []int64{})
+	require.NoError(t, err)
+	require.True(t, result)
+}
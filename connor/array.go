@@ -0,0 +1,22 @@
+package connor
+
+import "reflect"
+
+// getArrayItems returns the items of the given array, which may be of
+// any slice type.
+//
+// It returns false if the data is not an array.
+func getArrayItems(data any) ([]any, bool) {
+	if data == nil {
+		return nil, false
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+	items := make([]any, value.Len())
+	for i := range items {
+		items[i] = value.Index(i).Interface()
+	}
+	return items, true
+}
@@ -18,6 +18,10 @@ func matchWith(op string, conditions, data any) (bool, error) {
 	switch op {
 	case "_and":
 		return and(conditions, data)
+	case "_all":
+		return all(conditions, data)
+	case "_contains", "_any":
+		return contains(conditions, data)
 	case "_eq":
 		return eq(conditions, data)
 	case "_ge":
@@ -40,6 +44,12 @@ func matchWith(op string, conditions, data any) (bool, error) {
 		return like(conditions, data)
 	case "_nlike":
 		return nlike(conditions, data)
+	case "_ilike":
+		return ilike(conditions, data)
+	case "_nilike":
+		return nilike(conditions, data)
+	case "_regex":
+		return regex(conditions, data)
 	case "_not":
 		return not(conditions, data)
 	default:
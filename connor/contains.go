@@ -0,0 +1,20 @@
+package connor
+
+// contains will determine whether at least one item of the data
+// array matches the condition, which is either a value or a set of
+// conditions.
+func contains(condition, data any) (bool, error) {
+	items, ok := getArrayItems(data)
+	if !ok {
+		return false, nil
+	}
+	for _, item := range items {
+		if m, err := eq(condition, item); err != nil {
+			return false, err
+		} else if m {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
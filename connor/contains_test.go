@@ -0,0 +1,55 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContains(t *testing.T) {
+	data := []int64{1, 5, 10}
+
+	// contains value
+	result, err := contains(int64(5), data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// does not contain value
+	result, err = contains(int64(7), data)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// empty array
+	result, err = contains(int64(5), []int64{})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// not an array
+	result, err = contains(int64(5), int64(5))
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestContains_WithNestedOperators(t *testing.T) {
+	data := []string{"Source", "is the glue", "of web3"}
+
+	// any item matches all nested conditions
+	result, err := matchWith("_any", map[FilterKey]any{
+		&operator{"_regex"}: "^is",
+		&operator{"_ne"}:    "is",
+	}, data)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// no single item matches all nested conditions
+	result, err = matchWith("_any", map[FilterKey]any{
+		&operator{"_like"}:  "Source%",
+		&operator{"_regex"}: "web3$",
+	}, data)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// nested operator errors
+	_, err = matchWith("_contains", map[FilterKey]any{&operator{"_some"}: "test"}, data)
+	require.ErrorIs(t, err, ErrUnknownOperator)
+}
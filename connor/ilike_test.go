@@ -0,0 +1,31 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestILike(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// exact match
+	result, err := ilike("source IS the glue of WEB3", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match prefix
+	result, err = ilike("SOURCE%", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match contains
+	result, err = ilike("%GLUE%", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match contains error
+	result, err = ilike("%GLUES%", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+}
@@ -0,0 +1,43 @@
+package connor
+
+import (
+	"regexp"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// regex is an operator which tests whether a string matches
+// a regular expression.
+//
+// The condition is either the pattern of the regular expression, or the
+// regular expression itself if it was compiled in advance.
+func regex(condition, data any) (bool, error) {
+	switch arr := data.(type) {
+	case immutable.Option[string]:
+		if !arr.HasValue() {
+			return false, nil
+		}
+		data = arr.Value()
+	}
+
+	var re *regexp.Regexp
+	switch cn := condition.(type) {
+	case *regexp.Regexp:
+		re = cn
+	case string:
+		var err error
+		re, err = regexp.Compile(cn)
+		if err != nil {
+			return false, NewErrInvalidRegex(cn, err)
+		}
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+
+	if d, ok := data.(string); ok {
+		return re.MatchString(d), nil
+	}
+	return false, nil
+}
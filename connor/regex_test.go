@@ -0,0 +1,50 @@
+package connor
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegex(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// match
+	result, err := regex("^Source.*web[0-9]$", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match optional value
+	result, err = regex("glue", immutable.Some(testString))
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// no match
+	result, err = regex("^glue", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// no match on missing value
+	result, err = regex("glue", immutable.None[string]())
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestRegex_WithInvalidPattern_ReturnError(t *testing.T) {
+	_, err := regex("[a-", "Source is the glue of web3")
+	require.ErrorIs(t, err, ErrInvalidRegex)
+}
+
+func TestRegex_WithCompiledRegex(t *testing.T) {
+	re := regexp.MustCompile("^glue of web[0-9]+$")
+
+	result, err := regex(re, "glue of web3")
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = regex(re, "glue of web")
+	require.NoError(t, err)
+	require.False(t, result)
+}
@@ -17,6 +17,9 @@ import (
 const (
 	errFailedToGetPriority string = "failed to get priority"
 	errFailedToStoreValue  string = "failed to store value"
+	errInvalidCounterValue string = "invalid counter value, expected a number"
+	errInvalidSetValue     string = "invalid set value, expected an array"
+	errInvalidTextValue    string = "invalid text value, expected a string"
 )
 
 // Errors returnable from this package.
@@ -26,6 +29,9 @@ const (
 var (
 	ErrFailedToGetPriority = errors.New(errFailedToGetPriority)
 	ErrFailedToStoreValue  = errors.New(errFailedToStoreValue)
+	ErrInvalidCounterValue = errors.New(errInvalidCounterValue)
+	ErrInvalidSetValue     = errors.New(errInvalidSetValue)
+	ErrInvalidTextValue    = errors.New(errInvalidTextValue)
 	ErrEncodingPriority    = errors.New("error encoding priority")
 	ErrDecodingPriority    = errors.New("error decoding priority")
 	// ErrMismatchedMergeType - Tying to merge two ReplicatedData of different types
@@ -41,3 +47,18 @@ func NewErrFailedToGetPriority(inner error) error {
 func NewErrFailedToStoreValue(inner error) error {
 	return errors.Wrap(errFailedToStoreValue, inner)
 }
+
+// NewErrInvalidCounterValue returns an error indicating that the value of a counter is not a number.
+func NewErrInvalidCounterValue(value any) error {
+	return errors.New(errInvalidCounterValue, errors.NewKV("Value", value))
+}
+
+// NewErrInvalidSetValue returns an error indicating that the value of a set is not an array.
+func NewErrInvalidSetValue(value any) error {
+	return errors.New(errInvalidSetValue, errors.NewKV("Value", value))
+}
+
+// NewErrInvalidTextValue returns an error indicating that the value of a text is not a string.
+func NewErrInvalidTextValue(value any) error {
+	return errors.New(errInvalidTextValue, errors.NewKV("Value", value))
+}
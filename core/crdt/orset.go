@@ -0,0 +1,407 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"sort"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ds "github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// orSetNonceLength is the number of random bytes the tags of the elements added
+// to an existing set start with.
+const orSetNonceLength = 8
+
+var (
+	// ensure types implements core interfaces
+	_ core.ReplicatedData = (*ORSet)(nil)
+	_ core.Delta          = (*ORSetDelta)(nil)
+)
+
+// ORSetElement is an element added to an ORSet.
+type ORSetElement struct {
+	// Tag uniquely identifies the addition of the element.
+	Tag []byte
+	// Value is the CBOR encoded value of the element.
+	Value []byte
+}
+
+// ORSetDelta is a single delta operation for an ORSet.
+type ORSetDelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	// Adds are the elements added to the set.
+	Adds []ORSetElement
+	// Removes are the tags of the elements removed from the set.
+	Removes   [][]byte
+	DocKey    []byte
+	FieldName string
+}
+
+// GetPriority gets the current priority for this delta.
+func (delta *ORSetDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *ORSetDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *ORSetDelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Adds            []ORSetElement
+		Removes         [][]byte
+		DocKey          []byte
+		FieldName       string
+	}{delta.SchemaVersionID, delta.Priority, delta.Adds, delta.Removes, delta.DocKey, delta.FieldName})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *ORSetDelta) Value() any {
+	return delta.Adds
+}
+
+// orSetState is the persisted state of an ORSet.
+type orSetState struct {
+	// Elements are the added elements that have not been removed.
+	Elements []orSetStateElement
+	// Removed are the tags of all the removed elements.
+	//
+	// They are kept so that additions merged after their removal are ignored.
+	// These tombstones are kept forever, as an addition may be received at any
+	// time, so the state grows with the number of values ever removed from the set.
+	Removed [][]byte
+}
+
+type orSetStateElement struct {
+	Tag   []byte
+	Value []byte
+	// Priority is the priority of the delta that added the element.
+	Priority uint64
+}
+
+// ORSet, Observed-Remove Set, is a CRDT type holding a set of values that can be
+// added and removed concurrently.
+//
+// Every addition of a value is identified by a unique tag, and removing a value only
+// removes the additions that have been observed by the node removing it. If a value
+// is added and removed concurrently, the addition wins.
+//
+// The tags of removed values are never pruned, see orSetState.
+type ORSet struct {
+	baseCRDT
+
+	// schemaVersionKey is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at time of commit.
+	schemaVersionKey core.CollectionSchemaVersionKey
+
+	fieldName string
+}
+
+// NewORSet returns a new instance of the ORSet with the given ID.
+func NewORSet(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) ORSet {
+	return ORSet{
+		baseCRDT:         newBaseCRDT(store, key),
+		schemaVersionKey: schemaVersionKey,
+		fieldName:        fieldName,
+	}
+}
+
+// Value gets the current elements of the set as a CBOR encoded array.
+func (set ORSet) Value(ctx context.Context) ([]byte, error) {
+	valueK := set.key.WithValueFlag()
+	buf, err := set.store.Get(ctx, valueK.ToDS())
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Set generates a new delta changing the set from its current elements to the
+// elements of the given CBOR encoded array.
+//
+// Values missing from the current set are added and current values missing from the
+// given array are removed.
+func (set ORSet) Set(ctx context.Context, value []byte) (*ORSetDelta, error) {
+	state, hasState, err := set.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values, err := decodeSetValues(value)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		targets[string(value)] = struct{}{}
+	}
+	current := make(map[string]struct{}, len(state.Elements))
+	delta := &ORSetDelta{
+		DocKey:          []byte(set.key.DocKey),
+		FieldName:       set.fieldName,
+		SchemaVersionID: set.schemaVersionKey.SchemaVersionId,
+	}
+	for _, element := range state.Elements {
+		current[string(element.Value)] = struct{}{}
+		if _, ok := targets[string(element.Value)]; !ok {
+			delta.Removes = append(delta.Removes, element.Tag)
+		}
+	}
+
+	// The elements of a new set are tagged without a nonce, so that creating
+	// the same document on several nodes results in the same block.
+	var nonce []byte
+	if hasState {
+		nonce = make([]byte, orSetNonceLength)
+		_, err = rand.Read(nonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, value := range values {
+		if _, ok := current[string(value)]; ok {
+			continue
+		}
+		tag := binary.BigEndian.AppendUint32(nonce, uint32(len(delta.Adds)))
+		if !hasState {
+			tag = append(tag, value...)
+		}
+		delta.Adds = append(delta.Adds, ORSetElement{Tag: tag, Value: value})
+	}
+	return delta, nil
+}
+
+func (set ORSet) ID() string {
+	return set.key.ToString()
+}
+
+// Merge implements ReplicatedData interface.
+// Merge applies the additions and removals of the given delta to the set, the
+// order of the deltas does not matter.
+func (set ORSet) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*ORSetDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	state, _, err := set.getState(ctx)
+	if err != nil {
+		return err
+	}
+
+	removed := make(map[string]struct{}, len(state.Removed)+len(d.Removes))
+	for _, tag := range state.Removed {
+		removed[string(tag)] = struct{}{}
+	}
+	for _, tag := range d.Removes {
+		if _, ok := removed[string(tag)]; !ok {
+			removed[string(tag)] = struct{}{}
+			state.Removed = append(state.Removed, tag)
+		}
+	}
+
+	elements := make([]orSetStateElement, 0, len(state.Elements)+len(d.Adds))
+	existing := make(map[string]struct{}, len(state.Elements))
+	for _, element := range state.Elements {
+		existing[string(element.Tag)] = struct{}{}
+		if _, ok := removed[string(element.Tag)]; !ok {
+			elements = append(elements, element)
+		}
+	}
+	for _, add := range d.Adds {
+		_, isExisting := existing[string(add.Tag)]
+		_, isRemoved := removed[string(add.Tag)]
+		if isExisting || isRemoved {
+			continue
+		}
+		elements = append(elements, orSetStateElement{
+			Tag:      add.Tag,
+			Value:    add.Value,
+			Priority: d.Priority,
+		})
+	}
+	// Elements are ordered by the priority of their addition, and then by their value, so
+	// that the order is the same on all nodes.
+	sort.SliceStable(elements, func(i, j int) bool {
+		if elements[i].Priority != elements[j].Priority {
+			return elements[i].Priority < elements[j].Priority
+		}
+		if c := bytes.Compare(elements[i].Value, elements[j].Value); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(elements[i].Tag, elements[j].Tag) < 0
+	})
+	state.Elements = elements
+
+	err = set.setState(ctx, state)
+	if err != nil {
+		return err
+	}
+	err = set.setValue(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	curPrio, err := set.getPriority(ctx, set.key)
+	if err != nil {
+		return NewErrFailedToGetPriority(err)
+	}
+	if d.GetPriority() <= curPrio {
+		return nil
+	}
+	return set.setPriority(ctx, set.key, d.GetPriority())
+}
+
+// setValue stores the distinct values of the given state as a CBOR encoded array,
+// which is flagged as deleted if the document is deleted.
+func (set ORSet) setValue(ctx context.Context, state orSetState) error {
+	values := make([]cbor.RawMessage, 0, len(state.Elements))
+	seen := make(map[string]struct{}, len(state.Elements))
+	for _, element := range state.Elements {
+		if _, ok := seen[string(element.Value)]; ok {
+			continue
+		}
+		seen[string(element.Value)] = struct{}{}
+		values = append(values, element.Value)
+	}
+	buf, err := cbor.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	key := set.key.WithValueFlag()
+	marker, err := set.store.Get(ctx, set.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+
+	err = set.store.Put(ctx, key.ToDS(), buf)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// getState returns the persisted state of the set, and whether it exists.
+func (set ORSet) getState(ctx context.Context) (orSetState, bool, error) {
+	buf, err := set.store.Get(ctx, set.key.WithElementFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return orSetState{}, false, nil
+		}
+		return orSetState{}, false, err
+	}
+	var state orSetState
+	err = cbor.Unmarshal(buf, &state)
+	if err != nil {
+		return orSetState{}, false, err
+	}
+	return state, true, nil
+}
+
+func (set ORSet) setState(ctx context.Context, state orSetState) error {
+	buf, err := cbor.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = set.store.Put(ctx, set.key.WithElementFlag().ToDS(), buf)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// DeltaDecode is a typed helper to extract
+// a ORSetDelta from a ipld.Node
+func (set ORSet) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &ORSetDelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// decodeSetValues decodes the given CBOR encoded array and returns the CBOR encoding
+// of each of its distinct values. Empty and nil values are decoded as an empty set.
+func decodeSetValues(data []byte) ([][]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var value any
+	err := cbor.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+	array, ok := value.([]any)
+	if !ok {
+		return nil, NewErrInvalidSetValue(value)
+	}
+
+	values := make([][]byte, 0, len(array))
+	seen := make(map[string]struct{}, len(array))
+	for _, item := range array {
+		buf, err := cbor.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[string(buf)]; ok {
+			continue
+		}
+		seen[string(buf)] = struct{}{}
+		values = append(values, buf)
+	}
+	return values, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupORSet() ORSet {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewORSet(store, core.CollectionSchemaVersionKey{}, key, "")
+}
+
+func setORSetValues(ctx context.Context, t *testing.T, set ORSet, values []string, priority uint64) *ORSetDelta {
+	buf, err := cbor.Marshal(values)
+	require.NoError(t, err)
+	delta, err := set.Set(ctx, buf)
+	require.NoError(t, err)
+	delta.SetPriority(priority)
+	require.NoError(t, set.Merge(ctx, delta))
+	return delta
+}
+
+func requireORSetValues(ctx context.Context, t *testing.T, set ORSet, expected []string) {
+	buf, err := set.Value(ctx)
+	require.NoError(t, err)
+	var values []string
+	require.NoError(t, cbor.Unmarshal(buf, &values))
+	require.ElementsMatch(t, expected, values)
+}
+
+func TestORSetSet_ShouldOnlyAddAndRemoveChangedValues(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+	setORSetValues(ctx, t, set, []string{"a", "b"}, 1)
+
+	delta := setORSetValues(ctx, t, set, []string{"b", "c"}, 2)
+
+	require.Len(t, delta.Adds, 1)
+	require.Len(t, delta.Removes, 1)
+	requireORSetValues(ctx, t, set, []string{"b", "c"})
+}
+
+func TestORSetMerge_ConcurrentAddAndRemove_ShouldKeepAddition(t *testing.T) {
+	ctx := context.Background()
+	source := setupORSet()
+	initial := setORSetValues(ctx, t, source, []string{"a"}, 1)
+
+	first := setupORSet()
+	require.NoError(t, first.Merge(ctx, initial))
+	remove := setORSetValues(ctx, t, first, []string{}, 2)
+
+	second := setupORSet()
+	require.NoError(t, second.Merge(ctx, initial))
+	add := setORSetValues(ctx, t, second, []string{"a", "b"}, 2)
+
+	require.NoError(t, first.Merge(ctx, add))
+	require.NoError(t, second.Merge(ctx, remove))
+
+	requireORSetValues(ctx, t, first, []string{"b"})
+	requireORSetValues(ctx, t, second, []string{"b"})
+}
+
+func TestORSetMerge_ConcurrentRemoveAndReAddOfSameValue_ShouldKeepValue(t *testing.T) {
+	ctx := context.Background()
+	source := setupORSet()
+	initial := setORSetValues(ctx, t, source, []string{"a"}, 1)
+
+	first := setupORSet()
+	require.NoError(t, first.Merge(ctx, initial))
+	remove := setORSetValues(ctx, t, first, []string{}, 2)
+
+	second := setupORSet()
+	require.NoError(t, second.Merge(ctx, initial))
+	removeAgain := setORSetValues(ctx, t, second, []string{}, 2)
+	reAdd := setORSetValues(ctx, t, second, []string{"a"}, 3)
+
+	require.NoError(t, first.Merge(ctx, removeAgain))
+	require.NoError(t, first.Merge(ctx, reAdd))
+	require.NoError(t, second.Merge(ctx, remove))
+
+	requireORSetValues(ctx, t, first, []string{"a"})
+	requireORSetValues(ctx, t, second, []string{"a"})
+}
+
+func TestORSetMerge_ReAddAfterRemove_ShouldAddValue(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+	setORSetValues(ctx, t, set, []string{"a"}, 1)
+	setORSetValues(ctx, t, set, []string{}, 2)
+	requireORSetValues(ctx, t, set, []string{})
+
+	setORSetValues(ctx, t, set, []string{"a"}, 3)
+	requireORSetValues(ctx, t, set, []string{"a"})
+}
+
+func TestORSetMerge_AddAfterItsRemoval_ShouldBeIgnored(t *testing.T) {
+	ctx := context.Background()
+	source := setupORSet()
+	initial := setORSetValues(ctx, t, source, []string{"a"}, 1)
+	add := setORSetValues(ctx, t, source, []string{"a", "b"}, 2)
+	remove := setORSetValues(ctx, t, source, []string{"a"}, 3)
+
+	set := setupORSet()
+	require.NoError(t, set.Merge(ctx, initial))
+	require.NoError(t, set.Merge(ctx, remove))
+	require.NoError(t, set.Merge(ctx, add))
+
+	requireORSetValues(ctx, t, set, []string{"a"})
+}
+
+func TestORSetMerge_DeltasInAnyOrder_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	source := setupORSet()
+	initial := setORSetValues(ctx, t, source, []string{"a", "b"}, 1)
+
+	first := setupORSet()
+	require.NoError(t, first.Merge(ctx, initial))
+	firstDelta := setORSetValues(ctx, t, first, []string{"b", "c"}, 2)
+
+	second := setupORSet()
+	require.NoError(t, second.Merge(ctx, initial))
+	secondDelta := setORSetValues(ctx, t, second, []string{"a", "d"}, 2)
+
+	orders := [][]*ORSetDelta{
+		{initial, firstDelta, secondDelta},
+		{initial, secondDelta, firstDelta},
+		{firstDelta, secondDelta, initial},
+		{secondDelta, initial, firstDelta},
+	}
+	var expected []byte
+	for _, order := range orders {
+		set := setupORSet()
+		for _, delta := range order {
+			require.NoError(t, set.Merge(ctx, delta))
+		}
+		requireORSetValues(ctx, t, set, []string{"c", "d"})
+
+		value, err := set.Value(ctx)
+		require.NoError(t, err)
+		if expected == nil {
+			expected = value
+		}
+		require.Equal(t, expected, value)
+	}
+}
+
+func TestORSetMerge_SameDeltaTwice_ShouldOnlyApplyOnce(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+	setORSetValues(ctx, t, set, []string{"a"}, 1)
+	delta := setORSetValues(ctx, t, set, []string{"a", "b"}, 2)
+
+	require.NoError(t, set.Merge(ctx, delta))
+	requireORSetValues(ctx, t, set, []string{"a", "b"})
+}
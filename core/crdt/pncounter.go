@@ -0,0 +1,332 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ds "github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// pnCounterNonceLength is the number of random bytes added to the deltas
+// changing an existing counter value.
+const pnCounterNonceLength = 8
+
+var (
+	// ensure types implements core interfaces
+	_ core.ReplicatedData = (*PNCounter)(nil)
+	_ core.Delta          = (*PNCounterDelta)(nil)
+)
+
+// PNCounterDelta is a single delta operation for a PNCounter.
+type PNCounterDelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	// Data is the CBOR encoded amount the counter is changed by.
+	//
+	// It is negative if the counter is decremented.
+	Data      []byte
+	DocKey    []byte
+	FieldName string
+	// Nonce is a random value that keeps the blocks of equal concurrent
+	// changes distinct, so that none of them is lost.
+	//
+	// It is empty for the delta setting the initial value of the counter.
+	Nonce []byte
+}
+
+// GetPriority gets the current priority for this delta.
+func (delta *PNCounterDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *PNCounterDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *PNCounterDelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Data            []byte
+		DocKey          []byte
+		FieldName       string
+		Nonce           []byte
+	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.FieldName, delta.Nonce})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *PNCounterDelta) Value() any {
+	return delta.Data
+}
+
+// PNCounter, Positive-Negative Counter, is a CRDT type holding a number that can be
+// incremented and decremented concurrently without losing any of the changes.
+//
+// The state of the counter is the sum of the changes of all its deltas.
+type PNCounter struct {
+	baseCRDT
+
+	// schemaVersionKey is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at time of commit.
+	schemaVersionKey core.CollectionSchemaVersionKey
+
+	fieldName string
+}
+
+// NewPNCounter returns a new instance of the PNCounter with the given ID.
+func NewPNCounter(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) PNCounter {
+	return PNCounter{
+		baseCRDT:         newBaseCRDT(store, key),
+		schemaVersionKey: schemaVersionKey,
+		fieldName:        fieldName,
+	}
+}
+
+// Value gets the current counter value.
+func (counter PNCounter) Value(ctx context.Context) ([]byte, error) {
+	valueK := counter.key.WithValueFlag()
+	buf, err := counter.store.Get(ctx, valueK.ToDS())
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Set generates a new delta changing the counter from its current value to the given
+// CBOR encoded value.
+func (counter PNCounter) Set(ctx context.Context, value []byte) (*PNCounterDelta, error) {
+	key, err := counter.valueKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, hasValue, err := counter.getNumber(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	target, err := decodeCounterNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	change, err := cbor.Marshal(addCounterNumbers(target, negateCounterNumber(current)))
+	if err != nil {
+		return nil, err
+	}
+
+	delta := &PNCounterDelta{
+		Data:            change,
+		DocKey:          []byte(counter.key.DocKey),
+		FieldName:       counter.fieldName,
+		SchemaVersionID: counter.schemaVersionKey.SchemaVersionId,
+	}
+	// The initial value of the counter is set without a nonce, so that creating
+	// the same document on several nodes results in the same block.
+	if hasValue {
+		delta.Nonce = make([]byte, pnCounterNonceLength)
+		_, err = rand.Read(delta.Nonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return delta, nil
+}
+
+func (counter PNCounter) ID() string {
+	return counter.key.ToString()
+}
+
+// Merge implements ReplicatedData interface.
+// Merge adds the change of the given delta to the counter, the order of
+// the deltas does not matter and merging the same delta again has no effect.
+func (counter PNCounter) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*PNCounterDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	data, err := d.Marshal()
+	if err != nil {
+		return err
+	}
+	mergedKey := counter.mergedKey(data)
+	merged, err := counter.store.Has(ctx, mergedKey)
+	if err != nil {
+		return err
+	}
+	if merged {
+		return nil
+	}
+	err = counter.store.Put(ctx, mergedKey, []byte{})
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+
+	change, err := decodeCounterNumber(d.Data)
+	if err != nil {
+		return err
+	}
+	key, err := counter.valueKey(ctx)
+	if err != nil {
+		return err
+	}
+	current, _, err := counter.getNumber(ctx, key)
+	if err != nil {
+		return err
+	}
+	val, err := cbor.Marshal(addCounterNumbers(current, change))
+	if err != nil {
+		return err
+	}
+	err = counter.store.Put(ctx, key.ToDS(), val)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+
+	curPrio, err := counter.getPriority(ctx, counter.key)
+	if err != nil {
+		return NewErrFailedToGetPriority(err)
+	}
+	if d.GetPriority() <= curPrio {
+		return nil
+	}
+	return counter.setPriority(ctx, counter.key, d.GetPriority())
+}
+
+// valueKey returns the key the value of the counter is stored at, which
+// is flagged as deleted if the document is deleted.
+func (counter PNCounter) valueKey(ctx context.Context) (core.DataStoreKey, error) {
+	key := counter.key.WithValueFlag()
+	marker, err := counter.store.Get(ctx, counter.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return core.DataStoreKey{}, err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+	return key, nil
+}
+
+// getNumber returns the number stored at the given key, or zero if there is none.
+func (counter PNCounter) getNumber(ctx context.Context, key core.DataStoreKey) (any, bool, error) {
+	buf, err := counter.store.Get(ctx, key.ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return int64(0), false, nil
+		}
+		return nil, false, err
+	}
+	number, err := decodeCounterNumber(buf)
+	return number, true, err
+}
+
+// mergedKey returns the key marking the delta with the given encoding as merged.
+//
+// A key is kept for every merged delta, so that a delta merged more than once, for
+// example when a block is received again from a peer, only changes the counter once.
+// These keys are never pruned.
+func (counter PNCounter) mergedKey(delta []byte) ds.Key {
+	hash := sha256.Sum256(delta)
+	return counter.key.WithElementFlag().ToDS().ChildString(hex.EncodeToString(hash[:]))
+}
+
+// DeltaDecode is a typed helper to extract
+// a PNCounterDelta from a ipld.Node
+func (counter PNCounter) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &PNCounterDelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// decodeCounterNumber decodes the given CBOR encoded number as either an int64
+// or a float64. Empty and nil values are decoded as zero.
+func decodeCounterNumber(data []byte) (any, error) {
+	if len(data) == 0 {
+		return int64(0), nil
+	}
+	var value any
+	err := cbor.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case nil:
+		return int64(0), nil
+	case uint64:
+		return int64(value), nil
+	case int64, float64:
+		return value, nil
+	default:
+		return nil, NewErrInvalidCounterValue(value)
+	}
+}
+
+// addCounterNumbers returns the sum of the given numbers, which is a float64
+// unless both of them are an int64.
+func addCounterNumbers(a, b any) any {
+	aInt, aIsInt := a.(int64)
+	bInt, bIsInt := b.(int64)
+	if aIsInt && bIsInt {
+		return aInt + bInt
+	}
+	return toCounterFloat(a) + toCounterFloat(b)
+}
+
+func negateCounterNumber(value any) any {
+	if intValue, ok := value.(int64); ok {
+		return -intValue
+	}
+	return -toCounterFloat(value)
+}
+
+func toCounterFloat(value any) float64 {
+	if intValue, ok := value.(int64); ok {
+		return float64(intValue)
+	}
+	return value.(float64)
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupPNCounter() PNCounter {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewPNCounter(store, core.CollectionSchemaVersionKey{}, key, "")
+}
+
+func setPNCounterValue(
+	ctx context.Context,
+	t *testing.T,
+	counter PNCounter,
+	value any,
+	priority uint64,
+) *PNCounterDelta {
+	buf, err := cbor.Marshal(value)
+	require.NoError(t, err)
+	delta, err := counter.Set(ctx, buf)
+	require.NoError(t, err)
+	delta.SetPriority(priority)
+	require.NoError(t, counter.Merge(ctx, delta))
+	return delta
+}
+
+func requirePNCounterValue(ctx context.Context, t *testing.T, counter PNCounter, expected any) {
+	buf, err := counter.Value(ctx)
+	require.NoError(t, err)
+	value, err := decodeCounterNumber(buf)
+	require.NoError(t, err)
+	require.Equal(t, expected, value)
+}
+
+func TestPNCounterSet_ShouldChangeByDifference(t *testing.T) {
+	ctx := context.Background()
+	counter := setupPNCounter()
+	setPNCounterValue(ctx, t, counter, int64(10), 1)
+
+	delta := setPNCounterValue(ctx, t, counter, int64(7), 2)
+
+	change, err := decodeCounterNumber(delta.Data)
+	require.NoError(t, err)
+	require.Equal(t, int64(-3), change)
+	requirePNCounterValue(ctx, t, counter, int64(7))
+}
+
+func TestPNCounterMerge_ConcurrentIncrementsAndDecrementsInAnyOrder_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	source := setupPNCounter()
+	initial := setPNCounterValue(ctx, t, source, int64(10), 1)
+
+	first := setupPNCounter()
+	require.NoError(t, first.Merge(ctx, initial))
+	increment := setPNCounterValue(ctx, t, first, int64(15), 2)
+
+	second := setupPNCounter()
+	require.NoError(t, second.Merge(ctx, initial))
+	decrement := setPNCounterValue(ctx, t, second, int64(8), 2)
+
+	require.NoError(t, first.Merge(ctx, decrement))
+	require.NoError(t, second.Merge(ctx, increment))
+
+	requirePNCounterValue(ctx, t, first, int64(13))
+	requirePNCounterValue(ctx, t, second, int64(13))
+}
+
+func TestPNCounterMerge_ConcurrentEqualIncrements_ShouldCountBoth(t *testing.T) {
+	ctx := context.Background()
+	source := setupPNCounter()
+	initial := setPNCounterValue(ctx, t, source, int64(1), 1)
+
+	first := setupPNCounter()
+	require.NoError(t, first.Merge(ctx, initial))
+	firstIncrement := setPNCounterValue(ctx, t, first, int64(2), 2)
+
+	second := setupPNCounter()
+	require.NoError(t, second.Merge(ctx, initial))
+	secondIncrement := setPNCounterValue(ctx, t, second, int64(2), 2)
+
+	require.NoError(t, first.Merge(ctx, secondIncrement))
+	require.NoError(t, second.Merge(ctx, firstIncrement))
+
+	requirePNCounterValue(ctx, t, first, int64(3))
+	requirePNCounterValue(ctx, t, second, int64(3))
+}
+
+func TestPNCounterMerge_WithFloatChanges_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	source := setupPNCounter()
+	initial := setPNCounterValue(ctx, t, source, float64(1.5), 1)
+
+	counter := setupPNCounter()
+	require.NoError(t, counter.Merge(ctx, initial))
+	setPNCounterValue(ctx, t, counter, float64(2.25), 2)
+
+	requirePNCounterValue(ctx, t, counter, float64(2.25))
+}
+
+func TestPNCounterMerge_SameDeltaTwice_ShouldOnlyApplyOnce(t *testing.T) {
+	ctx := context.Background()
+	counter := setupPNCounter()
+	setPNCounterValue(ctx, t, counter, int64(10), 1)
+	delta := setPNCounterValue(ctx, t, counter, int64(12), 2)
+
+	require.NoError(t, counter.Merge(ctx, delta))
+	requirePNCounterValue(ctx, t, counter, int64(12))
+}
+
+func TestPNCounterMerge_SameInitialDeltaFromSeveralNodes_ShouldOnlyApplyOnce(t *testing.T) {
+	ctx := context.Background()
+	first := setupPNCounter()
+	firstInitial := setPNCounterValue(ctx, t, first, int64(10), 1)
+
+	second := setupPNCounter()
+	secondInitial := setPNCounterValue(ctx, t, second, int64(10), 1)
+
+	require.NoError(t, first.Merge(ctx, secondInitial))
+	require.NoError(t, second.Merge(ctx, firstInitial))
+
+	requirePNCounterValue(ctx, t, first, int64(10))
+	requirePNCounterValue(ctx, t, second, int64(10))
+}
+
+func TestPNCounterMerge_ShouldKeepOneKeyPerMergedDelta(t *testing.T) {
+	ctx := context.Background()
+	counter := setupPNCounter()
+	first := setPNCounterValue(ctx, t, counter, int64(10), 1)
+	setPNCounterValue(ctx, t, counter, int64(12), 2)
+	require.NoError(t, counter.Merge(ctx, first))
+
+	q, err := counter.store.Query(ctx, query.Query{
+		Prefix:   counter.key.WithElementFlag().ToString(),
+		KeysOnly: true,
+	})
+	require.NoError(t, err)
+	entries, err := q.Rest()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
@@ -0,0 +1,605 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ds "github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	// rgaTagLength is the number of bytes the IDs of inserted characters start with.
+	rgaTagLength = 8
+	// rgaMaxDiffEdits is the maximum number of inserted and deleted characters looked for
+	// when comparing two texts, more changed texts are replaced as a whole.
+	rgaMaxDiffEdits = 1000
+)
+
+// rgaEdit is an edit of a single character of a text.
+type rgaEdit byte
+
+const (
+	rgaKeep rgaEdit = iota
+	rgaDelete
+	rgaInsert
+)
+
+var (
+	// ensure types implements core interfaces
+	_ core.ReplicatedData = (*RGA)(nil)
+	_ core.Delta          = (*RGADelta)(nil)
+)
+
+// RGAInsert is a run of characters inserted into an RGA.
+type RGAInsert struct {
+	// ID identifies the run, the ID of each of its characters is the ID of
+	// the run followed by the index of the character within it.
+	ID []byte
+	// After is the ID of the character the run is inserted after, it is empty
+	// if the run is inserted at the start of the text.
+	After []byte
+	// Value is the text of the run.
+	Value string
+}
+
+// RGADelta is a single delta operation for an RGA.
+type RGADelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	// Inserts are the runs of characters inserted into the text.
+	Inserts []RGAInsert
+	// Deletes are the IDs of the characters deleted from the text.
+	Deletes   [][]byte
+	DocKey    []byte
+	FieldName string
+}
+
+// GetPriority gets the current priority for this delta.
+func (delta *RGADelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *RGADelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *RGADelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Inserts         []RGAInsert
+		Deletes         [][]byte
+		DocKey          []byte
+		FieldName       string
+	}{delta.SchemaVersionID, delta.Priority, delta.Inserts, delta.Deletes, delta.DocKey, delta.FieldName})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *RGADelta) Value() any {
+	return delta.Inserts
+}
+
+// rgaState is the persisted state of an RGA.
+type rgaState struct {
+	// Elements are all the characters of the text in order, including the deleted ones.
+	Elements []rgaElement
+	// PendingInserts are the merged runs inserted after a character that has not been
+	// merged yet.
+	PendingInserts []rgaPendingInsert
+	// PendingDeletes are the IDs of the merged deleted characters that have not been
+	// merged yet.
+	PendingDeletes [][]byte
+}
+
+type rgaElement struct {
+	ID []byte
+	// Priority is the priority of the delta that inserted the character.
+	Priority uint64
+	Value    string
+	Deleted  bool
+}
+
+type rgaPendingInsert struct {
+	Insert   RGAInsert
+	Priority uint64
+}
+
+// RGA, Replicated Growable Array, is a sequence CRDT type holding a text that can be
+// edited concurrently.
+//
+// Every inserted character is identified by a unique ID and is inserted after an existing
+// character, so that concurrent edits at different positions of the text are all kept.
+// Deleted characters are kept as tombstones so that characters inserted after them can
+// still be placed.
+type RGA struct {
+	baseCRDT
+
+	// schemaVersionKey is the schema version datastore key at the time of commit.
+	//
+	// It can be used to identify the collection datastructure state at time of commit.
+	schemaVersionKey core.CollectionSchemaVersionKey
+
+	fieldName string
+}
+
+// NewRGA returns a new instance of the RGA with the given ID.
+func NewRGA(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) RGA {
+	return RGA{
+		baseCRDT:         newBaseCRDT(store, key),
+		schemaVersionKey: schemaVersionKey,
+		fieldName:        fieldName,
+	}
+}
+
+// Value reconstructs the current text from its characters, and returns it CBOR encoded.
+func (rga RGA) Value(ctx context.Context) ([]byte, error) {
+	state, _, err := rga.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(state.text())
+}
+
+// Set generates a new delta changing the text from its current value to the given
+// CBOR encoded string.
+//
+// Only the changed parts of the text are deleted and inserted, so that edits made concurrently
+// by other nodes to other parts of the text are kept.
+func (rga RGA) Set(ctx context.Context, value []byte) (*RGADelta, error) {
+	state, hasState, err := rga.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	text, err := decodeRGAText(value)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := state.visibleElements()
+	current := make([]string, len(visible))
+	for i, element := range visible {
+		current[i] = element.Value
+	}
+	target := make([]string, 0, len(text))
+	for _, character := range text {
+		target = append(target, string(character))
+	}
+
+	// The runs of a new text are identified by its hash instead of a random tag, so that
+	// creating the same document on several nodes results in the same block.
+	var tag []byte
+	if hasState {
+		tag = make([]byte, rgaTagLength)
+		_, err = rand.Read(tag)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		hash := sha256.Sum256([]byte(text))
+		tag = hash[:rgaTagLength]
+	}
+
+	delta := &RGADelta{
+		DocKey:          []byte(rga.key.DocKey),
+		FieldName:       rga.fieldName,
+		SchemaVersionID: rga.schemaVersionKey.SchemaVersionId,
+	}
+	var after []byte
+	var run *RGAInsert
+	currentIndex, targetIndex := 0, 0
+	for _, edit := range rgaDiff(current, target) {
+		if edit == rgaInsert {
+			if run == nil {
+				runID := binary.BigEndian.AppendUint32(append([]byte{}, tag...), uint32(len(delta.Inserts)))
+				delta.Inserts = append(delta.Inserts, RGAInsert{ID: runID, After: after})
+				run = &delta.Inserts[len(delta.Inserts)-1]
+			}
+			run.Value += target[targetIndex]
+			targetIndex++
+			continue
+		}
+
+		run = nil
+		if edit == rgaDelete {
+			delta.Deletes = append(delta.Deletes, visible[currentIndex].ID)
+		} else {
+			targetIndex++
+		}
+		after = visible[currentIndex].ID
+		currentIndex++
+	}
+	return delta, nil
+}
+
+func (rga RGA) ID() string {
+	return rga.key.ToString()
+}
+
+// Merge implements ReplicatedData interface.
+// Merge integrates the inserted and deleted characters of the given delta into the text,
+// the order of the deltas does not matter.
+func (rga RGA) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*RGADelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	state, _, err := rga.getState(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, insert := range d.Inserts {
+		state.PendingInserts = append(state.PendingInserts, rgaPendingInsert{Insert: insert, Priority: d.Priority})
+	}
+	state.PendingDeletes = append(state.PendingDeletes, d.Deletes...)
+	state.integratePending()
+
+	err = rga.setState(ctx, state)
+	if err != nil {
+		return err
+	}
+	err = rga.setValue(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	curPrio, err := rga.getPriority(ctx, rga.key)
+	if err != nil {
+		return NewErrFailedToGetPriority(err)
+	}
+	if d.GetPriority() <= curPrio {
+		return nil
+	}
+	return rga.setPriority(ctx, rga.key, d.GetPriority())
+}
+
+// integratePending inserts and deletes all the pending characters whose position is known.
+func (state *rgaState) integratePending() {
+	for {
+		integrated := false
+		pending := state.PendingInserts[:0]
+		for _, insert := range state.PendingInserts {
+			if state.integrate(insert.Insert, insert.Priority) {
+				integrated = true
+			} else {
+				pending = append(pending, insert)
+			}
+		}
+		state.PendingInserts = pending
+		if !integrated {
+			break
+		}
+	}
+
+	positions := state.positions()
+	pendingDeletes := state.PendingDeletes[:0]
+	for _, id := range state.PendingDeletes {
+		if position, ok := positions[string(id)]; ok {
+			state.Elements[position].Deleted = true
+		} else {
+			pendingDeletes = append(pendingDeletes, id)
+		}
+	}
+	state.PendingDeletes = pendingDeletes
+}
+
+// integrate inserts the characters of the given run into the text and returns true, or
+// returns false if the character it is inserted after is unknown.
+func (state *rgaState) integrate(insert RGAInsert, priority uint64) bool {
+	positions := state.positions()
+	if _, ok := positions[string(rgaCharacterID(insert.ID, 0))]; ok {
+		// the run has already been merged
+		return true
+	}
+
+	position := 0
+	if len(insert.After) > 0 {
+		after, ok := positions[string(insert.After)]
+		if !ok {
+			return false
+		}
+		position = after + 1
+	}
+
+	// Characters inserted concurrently after the same character are ordered by descending
+	// priority and ID, which all the characters inserted after them exceed too.
+	first := rgaCharacterID(insert.ID, 0)
+	for position < len(state.Elements) && state.Elements[position].isAfter(priority, first) {
+		position++
+	}
+
+	characters := []rune(insert.Value)
+	elements := make([]rgaElement, 0, len(state.Elements)+len(characters))
+	elements = append(elements, state.Elements[:position]...)
+	for i, character := range characters {
+		elements = append(elements, rgaElement{
+			ID:       rgaCharacterID(insert.ID, i),
+			Priority: priority,
+			Value:    string(character),
+		})
+	}
+	state.Elements = append(elements, state.Elements[position:]...)
+	return true
+}
+
+// isAfter returns true if the element has been inserted with a greater priority and ID
+// than the given ones.
+func (element rgaElement) isAfter(priority uint64, id []byte) bool {
+	if element.Priority != priority {
+		return element.Priority > priority
+	}
+	return bytes.Compare(element.ID, id) > 0
+}
+
+// positions returns the position of each character of the text by ID.
+func (state *rgaState) positions() map[string]int {
+	positions := make(map[string]int, len(state.Elements))
+	for i, element := range state.Elements {
+		positions[string(element.ID)] = i
+	}
+	return positions
+}
+
+// visibleElements returns the characters of the text that have not been deleted.
+func (state *rgaState) visibleElements() []rgaElement {
+	visible := make([]rgaElement, 0, len(state.Elements))
+	for _, element := range state.Elements {
+		if !element.Deleted {
+			visible = append(visible, element)
+		}
+	}
+	return visible
+}
+
+// text returns the current text.
+func (state *rgaState) text() string {
+	var builder strings.Builder
+	for _, element := range state.Elements {
+		if !element.Deleted {
+			builder.WriteString(element.Value)
+		}
+	}
+	return builder.String()
+}
+
+// setValue stores the current text, which is flagged as deleted if the document is deleted.
+func (rga RGA) setValue(ctx context.Context, state rgaState) error {
+	buf, err := cbor.Marshal(state.text())
+	if err != nil {
+		return err
+	}
+
+	key := rga.key.WithValueFlag()
+	marker, err := rga.store.Get(ctx, rga.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+
+	err = rga.store.Put(ctx, key.ToDS(), buf)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// getState returns the persisted state of the text, and whether it exists.
+func (rga RGA) getState(ctx context.Context) (rgaState, bool, error) {
+	buf, err := rga.store.Get(ctx, rga.key.WithElementFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return rgaState{}, false, nil
+		}
+		return rgaState{}, false, err
+	}
+	var state rgaState
+	err = cbor.Unmarshal(buf, &state)
+	if err != nil {
+		return rgaState{}, false, err
+	}
+	return state, true, nil
+}
+
+func (rga RGA) setState(ctx context.Context, state rgaState) error {
+	buf, err := cbor.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = rga.store.Put(ctx, rga.key.WithElementFlag().ToDS(), buf)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// DeltaDecode is a typed helper to extract
+// a RGADelta from a ipld.Node
+func (rga RGA) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &RGADelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// rgaDiff returns the edits changing the current characters into the target characters,
+// with as few insertions and deletions as possible.
+func rgaDiff(current []string, target []string) []rgaEdit {
+	prefix := 0
+	for prefix < len(current) && prefix < len(target) && current[prefix] == target[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(current)-prefix && suffix < len(target)-prefix &&
+		current[len(current)-1-suffix] == target[len(target)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]rgaEdit, 0, len(current)+len(target))
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, rgaKeep)
+	}
+	middle, ok := myersDiff(current[prefix:len(current)-suffix], target[prefix:len(target)-suffix])
+	if ok {
+		edits = append(edits, middle...)
+	} else {
+		for i := prefix; i < len(current)-suffix; i++ {
+			edits = append(edits, rgaDelete)
+		}
+		for i := prefix; i < len(target)-suffix; i++ {
+			edits = append(edits, rgaInsert)
+		}
+	}
+	for i := 0; i < suffix; i++ {
+		edits = append(edits, rgaKeep)
+	}
+	return edits
+}
+
+// myersDiff returns the shortest edits changing a into b using the Myers diff algorithm,
+// or false if more than rgaMaxDiffEdits insertions and deletions are needed.
+func myersDiff(a []string, b []string) ([]rgaEdit, bool) {
+	n, m := len(a), len(b)
+	// trace[d][k+d] is the furthest position in a reached on diagonal k with d edits.
+	trace := make([][]int, 0)
+	for d := 0; d <= n+m && d <= rgaMaxDiffEdits; d++ {
+		v := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			switch {
+			case d == 0:
+				x = 0
+			case k == -d || (k != d && trace[d-1][k-1+d-1] < trace[d-1][k+1+d-1]):
+				x = trace[d-1][k+1+d-1]
+			default:
+				x = trace[d-1][k-1+d-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				trace = append(trace, v)
+				return myersBacktrack(trace, n, m), true
+			}
+		}
+		trace = append(trace, v)
+	}
+	return nil, false
+}
+
+// myersBacktrack returns the edits of the path found by myersDiff.
+func myersBacktrack(trace [][]int, n int, m int) []rgaEdit {
+	edits := make([]rgaEdit, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		previous := trace[d-1]
+		k := x - y
+		previousK := k - 1
+		if k == -d || (k != d && previous[k-1+d-1] < previous[k+1+d-1]) {
+			previousK = k + 1
+		}
+		previousX := previous[previousK+d-1]
+		previousY := previousX - previousK
+		for x > previousX && y > previousY {
+			edits = append(edits, rgaKeep)
+			x--
+			y--
+		}
+		if x == previousX {
+			edits = append(edits, rgaInsert)
+			y--
+		} else {
+			edits = append(edits, rgaDelete)
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		edits = append(edits, rgaKeep)
+		x--
+		y--
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// rgaCharacterID returns the ID of the character at the given index of the run with the given ID.
+func rgaCharacterID(runID []byte, index int) []byte {
+	id := make([]byte, 0, len(runID)+4)
+	id = append(id, runID...)
+	return binary.BigEndian.AppendUint32(id, uint32(index))
+}
+
+// decodeRGAText decodes the given CBOR encoded string. Empty and nil values are decoded
+// as an empty text.
+func decodeRGAText(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	var value any
+	err := cbor.Unmarshal(data, &value)
+	if err != nil {
+		return "", err
+	}
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	default:
+		return "", NewErrInvalidTextValue(value)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupRGA() RGA {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewRGA(store, core.CollectionSchemaVersionKey{}, key, "")
+}
+
+func setRGAText(ctx context.Context, t *testing.T, rga RGA, text string, priority uint64) *RGADelta {
+	value, err := cbor.Marshal(text)
+	require.NoError(t, err)
+	delta, err := rga.Set(ctx, value)
+	require.NoError(t, err)
+	delta.SetPriority(priority)
+	require.NoError(t, rga.Merge(ctx, delta))
+	return delta
+}
+
+func requireRGAText(ctx context.Context, t *testing.T, rga RGA, expected string) {
+	value, err := rga.Value(ctx)
+	require.NoError(t, err)
+	var text string
+	require.NoError(t, cbor.Unmarshal(value, &text))
+	require.Equal(t, expected, text)
+}
+
+func TestRGASet_ShouldOnlyInsertAndDeleteChangedText(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+	setRGAText(ctx, t, rga, "Hello world", 1)
+
+	delta := setRGAText(ctx, t, rga, "Hello brave world", 2)
+
+	require.Len(t, delta.Inserts, 1)
+	require.Equal(t, "brave ", delta.Inserts[0].Value)
+	require.Empty(t, delta.Deletes)
+	requireRGAText(ctx, t, rga, "Hello brave world")
+}
+
+func TestRGAMerge_ConcurrentEditsInAnyOrder_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	rga1 := setupRGA()
+	rga2 := setupRGA()
+	initial := setRGAText(ctx, t, rga1, "Hello world", 1)
+	require.NoError(t, rga2.Merge(ctx, initial))
+
+	delta1 := setRGAText(ctx, t, rga1, "Hello brave world", 2)
+	delta2 := setRGAText(ctx, t, rga2, "Hi world!", 2)
+
+	require.NoError(t, rga1.Merge(ctx, delta2))
+	require.NoError(t, rga2.Merge(ctx, delta1))
+
+	requireRGAText(ctx, t, rga1, "Hi brave world!")
+	requireRGAText(ctx, t, rga2, "Hi brave world!")
+}
+
+func TestRGAMerge_ConcurrentInsertsAtSamePosition_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	rga1 := setupRGA()
+	rga2 := setupRGA()
+	initial := setRGAText(ctx, t, rga1, "ac", 1)
+	require.NoError(t, rga2.Merge(ctx, initial))
+
+	delta1 := setRGAText(ctx, t, rga1, "abc", 2)
+	delta2 := setRGAText(ctx, t, rga2, "aBc", 2)
+
+	require.NoError(t, rga1.Merge(ctx, delta2))
+	require.NoError(t, rga2.Merge(ctx, delta1))
+
+	value1, err := rga1.Value(ctx)
+	require.NoError(t, err)
+	value2, err := rga2.Value(ctx)
+	require.NoError(t, err)
+	require.Equal(t, value1, value2)
+}
+
+func TestRGAMerge_InsertBeforeItsPosition_ShouldBePending(t *testing.T) {
+	ctx := context.Background()
+	source := setupRGA()
+	initial := setRGAText(ctx, t, source, "Hello", 1)
+	appended := setRGAText(ctx, t, source, "Hello world", 2)
+
+	rga := setupRGA()
+	require.NoError(t, rga.Merge(ctx, appended))
+	requireRGAText(ctx, t, rga, "")
+
+	require.NoError(t, rga.Merge(ctx, initial))
+	requireRGAText(ctx, t, rga, "Hello world")
+}
+
+func TestRGAMerge_SameDeltaTwice_ShouldOnlyApplyOnce(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+	delta := setRGAText(ctx, t, rga, "Hello", 1)
+
+	require.NoError(t, rga.Merge(ctx, delta))
+	requireRGAText(ctx, t, rga, "Hello")
+}
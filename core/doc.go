@@ -14,6 +14,8 @@ Package core provides commonly shared interfaces and building blocks.
 package core
 
 import (
+	"encoding/base64"
+
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -218,6 +220,9 @@ func (mapping *DocumentMapping) ToMap(doc Doc) map[string]any {
 		case Doc:
 			innerMapping := mapping.ChildMappings[renderKey.Index]
 			renderValue = innerMapping.ToMap(innerV)
+		case []byte:
+			// binary data is rendered the same way it is represented in JSON
+			renderValue = base64.StdEncoding.EncodeToString(innerV)
 		default:
 			if mapping.typeInfo.HasValue() && renderKey.Index == mapping.typeInfo.Value().Index {
 				renderValue = mapping.typeInfo.Value().Name
@@ -11,6 +11,7 @@
 package core
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/sourcenetwork/immutable"
@@ -25,6 +26,10 @@ func DecodeFieldValue(fieldDesc client.FieldDescription, val any) (any, error) {
 		return nil, nil
 	}
 
+	if fieldDesc.Kind == client.FieldKind_JSON {
+		return decodeJSONFieldValue(fieldDesc.Name, val)
+	}
+
 	var err error
 	if array, isArray := val.([]any); isArray {
 		var ok bool
@@ -64,15 +69,15 @@ func DecodeFieldValue(fieldDesc client.FieldDescription, val any) (any, error) {
 		case client.FieldKind_FLOAT_ARRAY:
 			floatArray := make([]float64, len(array))
 			for i, untypedValue := range array {
-				floatArray[i], ok = untypedValue.(float64)
-				if !ok {
-					return nil, client.NewErrUnexpectedType[float64](fieldDesc.Name, untypedValue)
+				floatArray[i], err = convertToFloat(fmt.Sprintf("%s[%v]", fieldDesc.Name, i), untypedValue)
+				if err != nil {
+					return nil, err
 				}
 			}
 			val = floatArray
 
 		case client.FieldKind_NILLABLE_FLOAT_ARRAY:
-			val, err = convertNillableArray[float64](fieldDesc.Name, array)
+			val, err = convertNillableArrayWithConverter(fieldDesc.Name, array, convertToFloat)
 			if err != nil {
 				return nil, err
 			}
@@ -173,3 +178,32 @@ func convertToInt(propertyName string, untypedValue any) (int64, error) {
 		return 0, client.NewErrUnexpectedType[string](propertyName, untypedValue)
 	}
 }
+
+// convertToFloat converts the given value to a float, integers are accepted as the field may
+// have been widened from an integer array.
+func convertToFloat(propertyName string, untypedValue any) (float64, error) {
+	switch value := untypedValue.(type) {
+	case float64:
+		return value, nil
+	case uint64:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	default:
+		return 0, client.NewErrUnexpectedType[float64](propertyName, untypedValue)
+	}
+}
+
+// decodeJSONFieldValue decodes the JSON text a JSON field value is stored as.
+func decodeJSONFieldValue(fieldName string, val any) (any, error) {
+	data, ok := val.(string)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[string](fieldName, val)
+	}
+	var result any
+	err := json.Unmarshal([]byte(data), &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
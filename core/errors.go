@@ -11,15 +11,20 @@
 package core
 
 import (
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
 )
 
 const (
 	errFailedToGetFieldIdOfKey string = "failed to get FieldID of Key"
+	errCanNotEncodeIndexValue  string = "can not encode index field value"
+	errCanNotDecodeIndexValue  string = "can not decode index field value"
 )
 
 var (
 	ErrFailedToGetFieldIdOfKey = errors.New(errFailedToGetFieldIdOfKey)
+	ErrCanNotEncodeIndexValue  = errors.New(errCanNotEncodeIndexValue)
+	ErrCanNotDecodeIndexValue  = errors.New(errCanNotDecodeIndexValue)
 	ErrEmptyKey                = errors.New("received empty key string")
 	ErrInvalidKey              = errors.New("invalid key string")
 )
@@ -28,3 +33,19 @@ var (
 func NewErrFailedToGetFieldIdOfKey(inner error) error {
 	return errors.Wrap(errFailedToGetFieldIdOfKey, inner)
 }
+
+// NewErrCanNotEncodeIndexValue returns an error indicating that the value can not be
+// encoded as an index field value of the given kind.
+func NewErrCanNotEncodeIndexValue(kind client.FieldKind, value any) error {
+	return errors.New(
+		errCanNotEncodeIndexValue,
+		errors.NewKV("Kind", kind),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrCanNotDecodeIndexValue returns an error indicating that the given bytes are
+// not a valid encoded index field value.
+func NewErrCanNotDecodeIndexValue(data []byte) error {
+	return errors.New(errCanNotDecodeIndexValue, errors.NewKV("Data", string(data)))
+}
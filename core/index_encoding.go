@@ -0,0 +1,264 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// Every encoded index field value starts with a type tag so that values of
+// different types never compare as equal and nil always sorts first.
+const (
+	indexValueTagNil byte = iota
+	indexValueTagBool
+	indexValueTagInt
+	indexValueTagFloat
+	indexValueTagString
+	indexValueTagTime
+)
+
+const (
+	// indexStringEscape escapes 0 bytes within encoded strings.
+	indexStringEscape byte = 0x00
+	// indexStringEscapedZero follows indexStringEscape if the original byte was 0.
+	indexStringEscapedZero byte = 0xFF
+	// indexStringTerminator follows indexStringEscape at the end of the string.
+	indexStringTerminator byte = 0x01
+)
+
+// EncodeIndexFieldValue encodes the given field value so that it can be used as
+// a segment of an index key.
+//
+// The encoding preserves order: for any two values of the same kind the byte-wise
+// comparison of their encoded forms matches the comparison of the values themselves.
+// If descending is true the order is reversed.
+// The result is hex encoded, so it never contains a key separator.
+//
+// The value is converted to the given field kind first, so that filter values
+// (e.g. time.Time for datetime fields) produce the same bytes as stored values.
+func EncodeIndexFieldValue(kind client.FieldKind, val any, descending bool) ([]byte, error) {
+	b, err := encodeIndexFieldValue(kind, val)
+	if err != nil {
+		return nil, err
+	}
+	if descending {
+		invertBytes(b)
+	}
+	res := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(res, b)
+	return res, nil
+}
+
+// EncodeIndexStringPrefix encodes the given string the same way EncodeIndexFieldValue
+// does, but without the terminator.
+//
+// The result is a prefix of the encoded form of every string that starts with
+// the given one, so it can be used to seek over all of them.
+func EncodeIndexStringPrefix(prefix string, descending bool) []byte {
+	b := encodeIndexString(prefix)
+	b = b[:len(b)-2]
+	if descending {
+		invertBytes(b)
+	}
+	res := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(res, b)
+	return res
+}
+
+func encodeIndexFieldValue(kind client.FieldKind, val any) ([]byte, error) {
+	if val == nil {
+		return []byte{indexValueTagNil}, nil
+	}
+
+	switch kind {
+	case client.FieldKind_INT:
+		switch v := val.(type) {
+		case int64:
+			return encodeIndexInt(v), nil
+		case int:
+			return encodeIndexInt(int64(v)), nil
+		case int32:
+			return encodeIndexInt(int64(v)), nil
+		case uint64:
+			return encodeIndexInt(int64(v)), nil
+		case float64:
+			return encodeIndexInt(int64(v)), nil
+		}
+	case client.FieldKind_FLOAT:
+		switch v := val.(type) {
+		case float64:
+			return encodeIndexFloat(v), nil
+		case float32:
+			return encodeIndexFloat(float64(v)), nil
+		case int64:
+			return encodeIndexFloat(float64(v)), nil
+		case int:
+			return encodeIndexFloat(float64(v)), nil
+		case uint64:
+			return encodeIndexFloat(float64(v)), nil
+		}
+	case client.FieldKind_BOOL:
+		if v, ok := val.(bool); ok {
+			if v {
+				return []byte{indexValueTagBool, 1}, nil
+			}
+			return []byte{indexValueTagBool, 0}, nil
+		}
+	case client.FieldKind_STRING, client.FieldKind_FOREIGN_OBJECT, client.FieldKind_DocKey:
+		if v, ok := val.(string); ok {
+			return encodeIndexString(v), nil
+		}
+	case client.FieldKind_DATETIME:
+		switch v := val.(type) {
+		case time.Time:
+			return encodeIndexTime(v), nil
+		case string:
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, NewErrCanNotEncodeIndexValue(kind, val)
+			}
+			return encodeIndexTime(t), nil
+		}
+	}
+	return nil, NewErrCanNotEncodeIndexValue(kind, val)
+}
+
+func encodeIndexInt(v int64) []byte {
+	b := make([]byte, 9)
+	b[0] = indexValueTagInt
+	// flipping the sign bit makes negative numbers sort before positive ones
+	binary.BigEndian.PutUint64(b[1:], uint64(v)^(1<<63))
+	return b
+}
+
+func encodeIndexFloat(v float64) []byte {
+	if v == 0 {
+		// -0 and 0 are equal, so they must have the same encoding
+		v = 0
+	}
+	bits := math.Float64bits(v)
+	if v < 0 {
+		bits = ^bits
+	} else {
+		bits ^= 1 << 63
+	}
+	b := make([]byte, 9)
+	b[0] = indexValueTagFloat
+	binary.BigEndian.PutUint64(b[1:], bits)
+	return b
+}
+
+func encodeIndexString(v string) []byte {
+	b := make([]byte, 0, len(v)+3)
+	b = append(b, indexValueTagString)
+	for i := 0; i < len(v); i++ {
+		if v[i] == indexStringEscape {
+			b = append(b, indexStringEscape, indexStringEscapedZero)
+		} else {
+			b = append(b, v[i])
+		}
+	}
+	return append(b, indexStringEscape, indexStringTerminator)
+}
+
+func encodeIndexTime(v time.Time) []byte {
+	b := make([]byte, 9)
+	b[0] = indexValueTagTime
+	binary.BigEndian.PutUint64(b[1:], uint64(v.UnixNano())^(1<<63))
+	return b
+}
+
+// DecodeIndexFieldValue decodes a value encoded with EncodeIndexFieldValue.
+//
+// Ints are returned as int64, floats as float64 and datetimes as time.Time in UTC.
+// Note that datetime values do not preserve the original time zone.
+func DecodeIndexFieldValue(data []byte, descending bool) (any, error) {
+	b := make([]byte, hex.DecodedLen(len(data)))
+	_, err := hex.Decode(b, data)
+	if err != nil {
+		return nil, NewErrCanNotDecodeIndexValue(data)
+	}
+	if descending {
+		invertBytes(b)
+	}
+	if len(b) == 0 {
+		return nil, NewErrCanNotDecodeIndexValue(data)
+	}
+
+	switch b[0] {
+	case indexValueTagNil:
+		if len(b) == 1 {
+			return nil, nil
+		}
+	case indexValueTagBool:
+		if len(b) == 2 {
+			return b[1] == 1, nil
+		}
+	case indexValueTagInt:
+		if len(b) == 9 {
+			return int64(binary.BigEndian.Uint64(b[1:]) ^ (1 << 63)), nil
+		}
+	case indexValueTagFloat:
+		if len(b) == 9 {
+			bits := binary.BigEndian.Uint64(b[1:])
+			if bits&(1<<63) != 0 {
+				bits ^= 1 << 63
+			} else {
+				bits = ^bits
+			}
+			return math.Float64frombits(bits), nil
+		}
+	case indexValueTagString:
+		if s, ok := decodeIndexString(b[1:]); ok {
+			return s, nil
+		}
+	case indexValueTagTime:
+		if len(b) == 9 {
+			nanos := int64(binary.BigEndian.Uint64(b[1:]) ^ (1 << 63))
+			return time.Unix(0, nanos).UTC(), nil
+		}
+	}
+	return nil, NewErrCanNotDecodeIndexValue(data)
+}
+
+func decodeIndexString(b []byte) (string, bool) {
+	res := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] != indexStringEscape {
+			res = append(res, b[i])
+			continue
+		}
+		if i+1 >= len(b) {
+			return "", false
+		}
+		i++
+		switch b[i] {
+		case indexStringEscapedZero:
+			res = append(res, 0)
+		case indexStringTerminator:
+			return string(res), i == len(b)-1
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func invertBytes(b []byte) {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+}
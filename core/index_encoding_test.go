@@ -0,0 +1,152 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestEncodeIndexFieldValue_ShouldRoundTrip(t *testing.T) {
+	now := time.Now().UTC()
+	testCases := []struct {
+		kind     client.FieldKind
+		value    any
+		expected any
+	}{
+		{kind: client.FieldKind_STRING, value: nil, expected: nil},
+		{kind: client.FieldKind_INT, value: int64(-42), expected: int64(-42)},
+		{kind: client.FieldKind_INT, value: int64(math.MaxInt64), expected: int64(math.MaxInt64)},
+		{kind: client.FieldKind_INT, value: 7, expected: int64(7)},
+		{kind: client.FieldKind_FLOAT, value: -3.5, expected: -3.5},
+		{kind: client.FieldKind_FLOAT, value: int64(2), expected: float64(2)},
+		{kind: client.FieldKind_BOOL, value: true, expected: true},
+		{kind: client.FieldKind_BOOL, value: false, expected: false},
+		{kind: client.FieldKind_STRING, value: "", expected: ""},
+		{kind: client.FieldKind_STRING, value: "a/b\x00c", expected: "a/b\x00c"},
+		{kind: client.FieldKind_DATETIME, value: now, expected: now},
+		{kind: client.FieldKind_DATETIME, value: "2021-07-23T03:46:56-05:00",
+			expected: time.Date(2021, 7, 23, 8, 46, 56, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		for _, desc := range []bool{false, true} {
+			encoded, err := EncodeIndexFieldValue(tc.kind, tc.value, desc)
+			require.NoError(t, err)
+			assert.NotContains(t, string(encoded), "/")
+
+			decoded, err := DecodeIndexFieldValue(encoded, desc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, decoded, "value: %v, descending: %v", tc.value, desc)
+		}
+	}
+}
+
+func TestEncodeIndexFieldValue_ShouldPreserveOrder(t *testing.T) {
+	testCases := []struct {
+		kind   client.FieldKind
+		values []any
+	}{
+		{
+			kind:   client.FieldKind_INT,
+			values: []any{nil, int64(math.MinInt64), int64(-300), int64(-1), int64(0), int64(1), int64(256)},
+		},
+		{
+			kind:   client.FieldKind_FLOAT,
+			values: []any{nil, math.Inf(-1), -10.5, -0.1, 0.0, 0.1, 3.0, 10.5, math.Inf(1)},
+		},
+		{
+			kind:   client.FieldKind_BOOL,
+			values: []any{nil, false, true},
+		},
+		{
+			kind:   client.FieldKind_STRING,
+			values: []any{nil, "", "\x00", "\x00a", "a", "a\x00", "aa", "ab", "b", strings.Repeat("b", 30)},
+		},
+		{
+			kind: client.FieldKind_DATETIME,
+			values: []any{
+				nil,
+				"1969-12-31T23:59:59Z",
+				"2021-07-23T03:46:56-05:00",
+				"2021-07-23T08:46:57Z",
+				"2022-01-01T00:00:00+03:00",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, desc := range []bool{false, true} {
+			var prev []byte
+			for i, val := range tc.values {
+				encoded, err := EncodeIndexFieldValue(tc.kind, val, desc)
+				require.NoError(t, err)
+				if i > 0 {
+					cmp := bytes.Compare(prev, encoded)
+					if desc {
+						assert.Equal(t, 1, cmp, "%v should be sorted after %v", tc.values[i-1], val)
+					} else {
+						assert.Equal(t, -1, cmp, "%v should be sorted before %v", tc.values[i-1], val)
+					}
+				}
+				prev = encoded
+			}
+		}
+	}
+}
+
+func TestEncodeIndexStringPrefix_ShouldBePrefixOfEncodedValues(t *testing.T) {
+	for _, desc := range []bool{false, true} {
+		prefix := EncodeIndexStringPrefix("ab\x00", desc)
+		for _, val := range []string{"ab\x00", "ab\x00c", "ab\x00\x00"} {
+			encoded, err := EncodeIndexFieldValue(client.FieldKind_STRING, val, desc)
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(encoded, prefix), "value: %q, descending: %v", val, desc)
+		}
+		for _, val := range []string{"ab", "abc", "b"} {
+			encoded, err := EncodeIndexFieldValue(client.FieldKind_STRING, val, desc)
+			require.NoError(t, err)
+			assert.False(t, bytes.HasPrefix(encoded, prefix), "value: %q, descending: %v", val, desc)
+		}
+	}
+}
+
+func TestEncodeIndexFieldValue_IfNegativeZero_ShouldEqualZero(t *testing.T) {
+	zero, err := EncodeIndexFieldValue(client.FieldKind_FLOAT, 0.0, false)
+	require.NoError(t, err)
+	negZero, err := EncodeIndexFieldValue(client.FieldKind_FLOAT, math.Copysign(0, -1), false)
+	require.NoError(t, err)
+	assert.Equal(t, zero, negZero)
+}
+
+func TestEncodeIndexFieldValue_IfValueDoesNotMatchKind_ReturnError(t *testing.T) {
+	_, err := EncodeIndexFieldValue(client.FieldKind_INT, "1", false)
+	assert.ErrorIs(t, err, ErrCanNotEncodeIndexValue)
+
+	_, err = EncodeIndexFieldValue(client.FieldKind_DATETIME, "not a date", false)
+	assert.ErrorIs(t, err, ErrCanNotEncodeIndexValue)
+}
+
+func TestDecodeIndexFieldValue_IfInvalidData_ReturnError(t *testing.T) {
+	invalidInputs := []string{"", "zz", "02ff", "04616263", "0461620002"}
+	for _, input := range invalidInputs {
+		_, err := DecodeIndexFieldValue([]byte(input), false)
+		assert.ErrorIs(t, err, ErrCanNotDecodeIndexValue, "input: %s", input)
+	}
+}
@@ -39,6 +39,8 @@ const (
 	PriorityKey = InstanceType("p")
 	// DeletedKey is a type that represents a deleted document.
 	DeletedKey = InstanceType("d")
+	// ElementKey is a type that represents the elements of a set instance.
+	ElementKey = InstanceType("e")
 )
 
 const (
@@ -50,10 +52,15 @@ const (
 	SCHEMA_VERSION                 = "/schema/version/v"
 	SCHEMA_VERSION_HISTORY         = "/schema/version/h"
 	SEQ                            = "/seq"
+	CHANGE_LOG                     = "/changelog"
+	PENDING_CHANGE                 = "/changelog-pending"
 	PRIMARY_KEY                    = "/pk"
 	DATASTORE_DOC_VERSION_FIELD_ID = "v"
 	REPLICATOR                     = "/replicator/id"
+	WEBHOOK                        = "/webhook/id"
+	WEBHOOK_CURSOR                 = "/webhook/cursor"
 	P2P_COLLECTION                 = "/p2p/collection"
+	P2P_MIGRATION_CLOCK            = "/p2p/migration/clock"
 )
 
 // Key is an interface that represents a key in the database.
@@ -182,10 +189,51 @@ type SequenceKey struct {
 
 var _ Key = (*SequenceKey)(nil)
 
+// ChangeLogKey points to the change recorded at the given sequence of the change
+// log of the collection with the given schema root.
+type ChangeLogKey struct {
+	SchemaRoot string
+	Sequence   uint64
+}
+
+var _ Key = (*ChangeLogKey)(nil)
+
+// PendingChangeKey points to a change that has been committed but has not yet been
+// given a sequence in the change log of its collection.
+type PendingChangeKey struct {
+	SchemaRoot string
+	Cid        string
+}
+
+var _ Key = (*PendingChangeKey)(nil)
+
+// P2PMigrationClockKey points to the logical clock of the replicated migration from the
+// given schema version.
+type P2PMigrationClockKey struct {
+	SourceSchemaVersionID string
+}
+
+var _ Key = (*P2PMigrationClockKey)(nil)
+
 type ReplicatorKey struct {
 	ReplicatorID string
 }
 
+// WebhookKey points to the jsonified description of the webhook with the given ID.
+type WebhookKey struct {
+	WebhookID uint32
+}
+
+var _ Key = (*WebhookKey)(nil)
+
+// WebhookCursorKey points to the sequence of the last change delivered to the
+// webhook with the given ID.
+type WebhookCursorKey struct {
+	WebhookID uint32
+}
+
+var _ Key = (*WebhookCursorKey)(nil)
+
 var _ Key = (*ReplicatorKey)(nil)
 
 // Creates a new DataStoreKey from a string as best as it can,
@@ -372,6 +420,45 @@ func NewSequenceKey(name string) SequenceKey {
 	return SequenceKey{SequenceName: name}
 }
 
+func NewChangeLogKey(schemaRoot string, sequence uint64) ChangeLogKey {
+	return ChangeLogKey{
+		SchemaRoot: schemaRoot,
+		Sequence:   sequence,
+	}
+}
+
+func NewChangeLogKeyFromString(key string) (ChangeLogKey, error) {
+	key = strings.TrimPrefix(key, CHANGE_LOG+"/")
+	elements := strings.Split(key, "/")
+	if len(elements) != 2 {
+		return ChangeLogKey{}, errors.WithStack(ErrInvalidKey, errors.NewKV("Key", key))
+	}
+
+	sequence, err := strconv.ParseUint(elements[1], 10, 64)
+	if err != nil {
+		return ChangeLogKey{}, errors.WithStack(ErrInvalidKey, errors.NewKV("Key", key))
+	}
+
+	return NewChangeLogKey(elements[0], sequence), nil
+}
+
+func NewPendingChangeKey(schemaRoot string, cid string) PendingChangeKey {
+	return PendingChangeKey{
+		SchemaRoot: schemaRoot,
+		Cid:        cid,
+	}
+}
+
+func NewPendingChangeKeyFromString(key string) (PendingChangeKey, error) {
+	key = strings.TrimPrefix(key, PENDING_CHANGE+"/")
+	elements := strings.Split(key, "/")
+	if len(elements) != 2 {
+		return PendingChangeKey{}, errors.WithStack(ErrInvalidKey, errors.NewKV("Key", key))
+	}
+
+	return NewPendingChangeKey(elements[0], elements[1]), nil
+}
+
 func (k DataStoreKey) WithValueFlag() DataStoreKey {
 	newKey := k
 	newKey.InstanceType = ValueKey
@@ -390,6 +477,12 @@ func (k DataStoreKey) WithDeletedFlag() DataStoreKey {
 	return newKey
 }
 
+func (k DataStoreKey) WithElementFlag() DataStoreKey {
+	newKey := k
+	newKey.InstanceType = ElementKey
+	return newKey
+}
+
 func (k DataStoreKey) WithDocKey(docKey string) DataStoreKey {
 	newKey := k
 	newKey.DocKey = docKey
@@ -730,6 +823,117 @@ func (k SequenceKey) ToDS() ds.Key {
 	return ds.NewKey(k.ToString())
 }
 
+func (k ChangeLogKey) ToString() string {
+	result := CHANGE_LOG
+
+	if k.SchemaRoot != "" {
+		result = result + "/" + k.SchemaRoot
+	}
+
+	// The sequence is zero padded so that the changes are ordered by sequence
+	// when iterated over.
+	if k.Sequence != 0 {
+		result = result + "/" + fmt.Sprintf("%020d", k.Sequence)
+	}
+
+	return result
+}
+
+func (k ChangeLogKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k ChangeLogKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func (k PendingChangeKey) ToString() string {
+	result := PENDING_CHANGE
+
+	if k.SchemaRoot != "" {
+		result = result + "/" + k.SchemaRoot
+	}
+	if k.Cid != "" {
+		result = result + "/" + k.Cid
+	}
+
+	return result
+}
+
+func (k PendingChangeKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k PendingChangeKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func NewP2PMigrationClockKey(sourceSchemaVersionID string) P2PMigrationClockKey {
+	return P2PMigrationClockKey{SourceSchemaVersionID: sourceSchemaVersionID}
+}
+
+func (k P2PMigrationClockKey) ToString() string {
+	result := P2P_MIGRATION_CLOCK
+
+	if k.SourceSchemaVersionID != "" {
+		result = result + "/" + k.SourceSchemaVersionID
+	}
+
+	return result
+}
+
+func (k P2PMigrationClockKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k P2PMigrationClockKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func NewWebhookKey(id uint32) WebhookKey {
+	return WebhookKey{WebhookID: id}
+}
+
+func (k WebhookKey) ToString() string {
+	result := WEBHOOK
+
+	if k.WebhookID != 0 {
+		result = result + "/" + strconv.FormatUint(uint64(k.WebhookID), 10)
+	}
+
+	return result
+}
+
+func (k WebhookKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k WebhookKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func NewWebhookCursorKey(id uint32) WebhookCursorKey {
+	return WebhookCursorKey{WebhookID: id}
+}
+
+func (k WebhookCursorKey) ToString() string {
+	result := WEBHOOK_CURSOR
+
+	if k.WebhookID != 0 {
+		result = result + "/" + strconv.FormatUint(uint64(k.WebhookID), 10)
+	}
+
+	return result
+}
+
+func (k WebhookCursorKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k WebhookCursorKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
 // New
 func NewP2PCollectionKey(collectionID string) P2PCollectionKey {
 	return P2PCollectionKey{CollectionID: collectionID}
@@ -421,3 +421,45 @@ func TestNewIndexDataStoreKey_InvalidKey(t *testing.T) {
 		assert.Error(t, err, "case %d: %s", i, key)
 	}
 }
+
+func TestNewChangeLogKey_IfNoSequence_ReturnPrefix(t *testing.T) {
+	key := NewChangeLogKey("bafkreiabc", 0)
+	assert.Equal(t, "/changelog/bafkreiabc", key.ToString())
+}
+
+func TestNewChangeLogKeyFromString_IfFullKey_ReturnKey(t *testing.T) {
+	key := NewChangeLogKey("bafkreiabc", 12)
+	assert.Equal(t, "/changelog/bafkreiabc/00000000000000000012", key.ToString())
+
+	result, err := NewChangeLogKeyFromString(key.ToString())
+	assert.NoError(t, err)
+	assert.Equal(t, key, result)
+}
+
+func TestNewChangeLogKeyFromString_IfInvalidSequence_ReturnError(t *testing.T) {
+	_, err := NewChangeLogKeyFromString("/changelog/bafkreiabc/abc")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestNewPendingChangeKeyFromString_IfFullKey_ReturnKey(t *testing.T) {
+	key := NewPendingChangeKey("bafkreiabc", "bafybeiabc")
+	assert.Equal(t, "/changelog-pending/bafkreiabc/bafybeiabc", key.ToString())
+
+	result, err := NewPendingChangeKeyFromString(key.ToString())
+	assert.NoError(t, err)
+	assert.Equal(t, key, result)
+}
+
+func TestNewPendingChangeKeyFromString_IfNoCid_ReturnError(t *testing.T) {
+	_, err := NewPendingChangeKeyFromString("/changelog-pending/bafkreiabc")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestNewWebhookKey_IfNoID_ReturnPrefix(t *testing.T) {
+	assert.Equal(t, "/webhook/id", NewWebhookKey(0).ToString())
+	assert.Equal(t, "/webhook/id/3", NewWebhookKey(3).ToString())
+}
+
+func TestNewWebhookCursorKey_ReturnKey(t *testing.T) {
+	assert.Equal(t, "/webhook/cursor/3", NewWebhookCursorKey(3).ToString())
+}
@@ -51,7 +51,15 @@ type Parser interface {
 	NewFilterFromString(collectionType string, body string) (immutable.Option[request.Filter], error)
 
 	// ParseSDL parses an SDL string into a set of collection descriptions.
-	ParseSDL(ctx context.Context, schemaString string) ([]client.CollectionDefinition, error)
+	//
+	// It also returns a set of schema descriptions containing the fields that any type extensions
+	// (`extend type`) add to the types that they extend, including the inverse fields generated for
+	// relations to the given existing definitions that are not declared by a type extension.
+	ParseSDL(
+		ctx context.Context,
+		schemaString string,
+		existingDefinitions []client.CollectionDefinition,
+	) ([]client.CollectionDefinition, []client.SchemaDescription, error)
 
 	// Adds the given schema to this parser's model.
 	//
@@ -654,7 +654,13 @@ func (t *txn) query(q dsq.Query) (dsq.Results, error) {
 		defer it.Close()
 
 		// All iterators must be started by rewinding.
-		it.Rewind()
+		if opt.Reverse && len(opt.Prefix) > 0 {
+			// Rewinding a reverse iterator seeks to the prefix itself, which sorts
+			// before all keys with the prefix, so it has to start after them instead.
+			it.Seek(append(append([]byte{}, opt.Prefix...), 0xff))
+		} else {
+			it.Rewind()
+		}
 
 		// skip to the offset
 		for skipped := 0; skipped < q.Offset && it.Valid(); it.Next() {
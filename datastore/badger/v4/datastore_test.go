@@ -422,6 +422,33 @@ func TestQueryOperation(t *testing.T) {
 	require.Equal(t, testValue2, result.Entry.Value)
 }
 
+func TestQueryOperationWithPrefixInDescendingOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newLoadedDatastore(ctx, t)
+	defer func() {
+		err := s.Close()
+		require.NoError(t, err)
+	}()
+
+	err := s.Put(ctx, ds.NewKey("prefix/key1"), testValue1)
+	require.NoError(t, err)
+	err = s.Put(ctx, ds.NewKey("prefix/key2"), testValue2)
+	require.NoError(t, err)
+
+	results, err := s.Query(ctx, dsq.Query{
+		Prefix: "prefix",
+		Orders: []dsq.Order{dsq.OrderByKeyDescending{}},
+	})
+	require.NoError(t, err)
+
+	entries, err := results.Rest()
+	require.NoError(t, err)
+
+	require.Len(t, entries, 2)
+	require.Equal(t, "/prefix/key2", entries[0].Key)
+	require.Equal(t, "/prefix/key1", entries[1].Key)
+}
+
 func TestQueryOperationWithStoreClosed(t *testing.T) {
 	ctx := context.Background()
 	s := newLoadedDatastore(ctx, t)
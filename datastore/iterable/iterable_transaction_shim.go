@@ -12,6 +12,7 @@ package iterable
 
 import (
 	"context"
+	"strings"
 
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
@@ -57,6 +58,10 @@ func (shim *iteratorShim) IteratePrefix(
 	}
 
 	query := shim.q
+	// Reverse iteration passes the range from its last key to its first one.
+	if startPrefix.String() > endPrefix.String() {
+		startPrefix, endPrefix = endPrefix, startPrefix
+	}
 	// If the prefix range only covers one prefix then we don't have to do the
 	// horrible work-around in the else clause
 	if prefixEnd(startPrefix) == endPrefix {
@@ -76,7 +81,13 @@ func (shim *iteratorShim) IteratePrefix(
 			}
 			lastSharedIndex += 1
 		}
-		query.Prefix = string(startBytes[:lastSharedIndex])
+		// The query prefix is matched by whole key segments, so the shared part
+		// has to be cut back to the last complete segment.
+		sharedPrefix := string(startBytes[:lastSharedIndex])
+		if i := strings.LastIndex(sharedPrefix, "/"); i >= 0 {
+			sharedPrefix = sharedPrefix[:i]
+		}
+		query.Prefix = sharedPrefix
 		query.Filters = append(query.Filters, betweenFilter{
 			start: startPrefix.String(),
 			end:   endPrefix.String(),
@@ -67,7 +67,10 @@ func prepareDAGStore(t *testing.T) *DAGStore {
 
 func NewTxnWithMultistore(t *testing.T) *MultiStoreTxn {
 	txn := NewTxn(t)
+	txn.EXPECT().ID().Return(0).Maybe()
 	txn.EXPECT().OnSuccess(mock.Anything).Maybe()
+	txn.EXPECT().OnError(mock.Anything).Maybe()
+	txn.EXPECT().OnDiscard(mock.Anything).Maybe()
 
 	result := &MultiStoreTxn{
 		Txn:             txn,
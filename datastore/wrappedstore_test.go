@@ -102,6 +102,32 @@ func TestIteratePrefix(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIteratePrefix_IfRangeEndsWithinSegment_ShouldReturnKeysInRange(t *testing.T) {
+	ctx := context.Background()
+	rootstore := memory.NewDatastore(ctx)
+
+	dsRW := AsDSReaderWriter(rootstore)
+	dsRW = prefix(dsRW, prefixKey)
+
+	for _, key := range []string{"/idx/0401", "/idx/0411", "/idx/0412", "/idx/0421", "/idx2/0411"} {
+		err := dsRW.Put(ctx, ds.NewKey(key), []byte{})
+		require.NoError(t, err)
+	}
+
+	iter, err := dsRW.GetIterator(query.Query{})
+	require.NoError(t, err)
+
+	results, err := iter.IteratePrefix(ctx, ds.NewKey("/idx/041"), ds.NewKey("/idx/0412\xff"))
+	require.NoError(t, err)
+
+	keys := []string{}
+	for res, ok := results.NextSync(); ok; res, ok = results.NextSync() {
+		require.NoError(t, res.Error)
+		keys = append(keys, res.Key)
+	}
+	require.Equal(t, []string{"/idx/0411", "/idx/0412"}, keys)
+}
+
 func TestIteratePrefixWithStoreClosed(t *testing.T) {
 	ctx := context.Background()
 	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
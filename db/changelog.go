@@ -0,0 +1,343 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+// recordUpdate records the given update as a pending change of its collection within
+// the given transaction.
+//
+// Once the transaction has been committed the pending changes are given a sequence in
+// the change log of their collection and published.  Recording the change within the
+// transaction that made it ensures that a committed change is never missing from the
+// change log, whilst deferring the allocation of its sequence ensures that concurrent
+// transactions modifying the same collection do not conflict.
+func (db *db) recordUpdate(ctx context.Context, txn datastore.Txn, evt events.Update) error {
+	eventType, _, err := getSubscriptionEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	change, err := json.Marshal(client.Change{
+		Type:   string(eventType),
+		DocKey: evt.DocKey,
+		Cid:    evt.Cid.String(),
+		Height: evt.Priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	key := core.NewPendingChangeKey(evt.SchemaRoot, evt.Cid.String())
+	err = txn.Systemstore().Put(ctx, key.ToDS(), change)
+	if err != nil {
+		return err
+	}
+
+	db.sequenceChangesOnSuccess(ctx, txn)
+	return nil
+}
+
+// sequenceChangesOnSuccess sequences the pending changes once the given transaction has
+// been committed.
+//
+// The pending changes are sequenced together, so this is only registered once per
+// transaction no matter how many changes it records.
+func (db *db) sequenceChangesOnSuccess(ctx context.Context, txn datastore.Txn) {
+	db.sequencingTxnsLock.Lock()
+	defer db.sequencingTxnsLock.Unlock()
+
+	if _, ok := db.sequencingTxns[txn.ID()]; ok {
+		return
+	}
+	db.sequencingTxns[txn.ID()] = struct{}{}
+
+	txn.OnSuccess(func() {
+		db.forgetSequencingTxn(txn.ID())
+		err := db.sequenceChanges(ctx)
+		if err != nil {
+			// The pending changes remain in the store, and will be sequenced by the next
+			// successful call.
+			log.ErrorE(ctx, "Failed to sequence changes", err, logging.NewKV("TxnID", txn.ID()))
+		}
+	})
+	txn.OnError(func() {
+		db.forgetSequencingTxn(txn.ID())
+	})
+	txn.OnDiscard(func() {
+		db.forgetSequencingTxn(txn.ID())
+	})
+}
+
+func (db *db) forgetSequencingTxn(id uint64) {
+	db.sequencingTxnsLock.Lock()
+	defer db.sequencingTxnsLock.Unlock()
+
+	delete(db.sequencingTxns, id)
+}
+
+// pendingChange is a committed change that has not yet been given a sequence.
+type pendingChange struct {
+	key        core.PendingChangeKey
+	schemaRoot string
+	change     client.Change
+}
+
+// changeLogBatchSize is the maximum number of pending changes sequenced within a single
+// transaction, so that a large backlog does not exceed the transaction size limit.
+const changeLogBatchSize = 1000
+
+// sequenceChanges records all pending changes in the change log of their collection,
+// and then publishes them to the update and changes channels in sequence order.
+//
+// Pending changes of the same collection are sequenced in the order of their height,
+// so that the changes to a document are sequenced in the order that they were made.
+// They are sequenced in batches of at most changeLogBatchSize changes, each batch is
+// committed and published before the next one is sequenced.
+func (db *db) sequenceChanges(ctx context.Context) error {
+	db.changeLogLock.Lock()
+	defer db.changeLogLock.Unlock()
+
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	pending, err := getPendingChanges(ctx, txn)
+	txn.Discard(ctx)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(pending); start += changeLogBatchSize {
+		end := start + changeLogBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		err := db.sequencePendingChanges(ctx, pending[start:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sequencePendingChanges records the given pending changes in the change log of their
+// collection within a single transaction, and then publishes them once it is committed.
+func (db *db) sequencePendingChanges(ctx context.Context, pending []pendingChange) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	sequences := map[string]*sequence{}
+	updates := make([]events.Update, 0, len(pending))
+	for _, p := range pending {
+		seq, ok := sequences[p.schemaRoot]
+		if !ok {
+			seq, err = db.getSequence(ctx, txn, fmt.Sprintf("%s/%s", core.CHANGE_LOG, p.schemaRoot))
+			if err != nil {
+				return err
+			}
+			sequences[p.schemaRoot] = seq
+		}
+		p.change.Sequence, err = seq.next(ctx, txn)
+		if err != nil {
+			return err
+		}
+
+		change, err := json.Marshal(p.change)
+		if err != nil {
+			return err
+		}
+		key := core.NewChangeLogKey(p.schemaRoot, p.change.Sequence)
+		err = txn.Systemstore().Put(ctx, key.ToDS(), change)
+		if err != nil {
+			return err
+		}
+		err = txn.Systemstore().Delete(ctx, p.key.ToDS())
+		if err != nil {
+			return err
+		}
+
+		if db.events.Updates.HasValue() {
+			evt, err := getChangeUpdate(ctx, txn, p.schemaRoot, p.change)
+			if err != nil {
+				return err
+			}
+			updates = append(updates, evt)
+		}
+	}
+
+	err = txn.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, evt := range updates {
+		db.events.Updates.Value().Publish(evt)
+		db.events.Changes.Value().Publish(evt)
+	}
+
+	return nil
+}
+
+// getPendingChanges returns all the pending changes, ordered by schema root and then
+// by height.
+func getPendingChanges(ctx context.Context, txn datastore.Txn) ([]pendingChange, error) {
+	q, err := txn.Systemstore().Query(ctx, query.Query{
+		Prefix: core.PENDING_CHANGE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := q.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close pending change query", err)
+		}
+	}()
+
+	pending := []pendingChange{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		key, err := core.NewPendingChangeKeyFromString(res.Key)
+		if err != nil {
+			return nil, err
+		}
+		var change client.Change
+		err = json.Unmarshal(res.Value, &change)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, pendingChange{
+			key:        key,
+			schemaRoot: key.SchemaRoot,
+			change:     change,
+		})
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].schemaRoot != pending[j].schemaRoot {
+			return pending[i].schemaRoot < pending[j].schemaRoot
+		}
+		return pending[i].change.Height < pending[j].change.Height
+	})
+
+	return pending, nil
+}
+
+// getChanges returns the changes recorded in the change log of the collection with the
+// given schema root after the given sequence, ordered by sequence.
+//
+// At most `limit` changes are returned, unless `limit` is zero.
+func (db *db) getChanges(
+	ctx context.Context,
+	txn datastore.Txn,
+	schemaRoot string,
+	after uint64,
+	limit uint64,
+) ([]client.Change, error) {
+	q, err := txn.Systemstore().Query(ctx, query.Query{
+		Prefix: core.NewChangeLogKey(schemaRoot, 0).ToString(),
+		Filters: []query.Filter{
+			query.FilterKeyCompare{
+				Op:  query.GreaterThan,
+				Key: core.NewChangeLogKey(schemaRoot, after).ToString(),
+			},
+		},
+		Orders: []query.Order{query.OrderByKey{}},
+		Limit:  int(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := q.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close change log query", err)
+		}
+	}()
+
+	changes := []client.Change{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		var change client.Change
+		err = json.Unmarshal(res.Value, &change)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// getChangeUpdate returns the update that produced the given change, as it was
+// published to the changes channel.
+func getChangeUpdate(
+	ctx context.Context,
+	txn datastore.Txn,
+	schemaRoot string,
+	change client.Change,
+) (events.Update, error) {
+	c, err := cid.Decode(change.Cid)
+	if err != nil {
+		return events.Update{}, err
+	}
+	block, err := txn.DAGstore().Get(ctx, c)
+	if err != nil {
+		return events.Update{}, err
+	}
+	node, err := dag.DecodeProtobuf(block.RawData())
+	if err != nil {
+		return events.Update{}, err
+	}
+
+	return events.Update{
+		DocKey:     change.DocKey,
+		Cid:        c,
+		SchemaRoot: schemaRoot,
+		Block:      node,
+		Priority:   change.Height,
+		Sequence:   change.Sequence,
+	}, nil
+}
+
+func (c *collection) GetChanges(ctx context.Context, after uint64, limit uint64) ([]client.Change, error) {
+	txn, err := c.getTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	return c.db.getChanges(ctx, txn, c.Schema().Root, after, limit)
+}
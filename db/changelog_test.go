@@ -0,0 +1,300 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	badger "github.com/sourcenetwork/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v4"
+)
+
+func newMemoryDBWithUpdateEvents(ctx context.Context) (*implicitTxnDB, error) {
+	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+	rootstore, err := badgerds.NewDatastore("", &opts)
+	if err != nil {
+		return nil, err
+	}
+	return newDB(ctx, rootstore, WithUpdateEvents())
+}
+
+func TestSubscription_YieldsResumeTokens(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	result := db.ExecRequest(ctx, `subscription { User { name } }`)
+	require.Empty(t, result.GQL.Errors)
+	defer result.Pub.Unsubscribe()
+
+	for _, name := range []string{"John", "Addo"} {
+		doc, err := client.NewDocFromJSON([]byte(`{"name": "` + name + `"}`))
+		require.NoError(t, err)
+		require.NoError(t, col.Create(ctx, doc))
+	}
+
+	item := (<-result.Pub.Stream()).(client.GQLResult)
+	assert.Equal(t, "1", item.ResumeToken)
+	item = (<-result.Pub.Stream()).(client.GQLResult)
+	assert.Equal(t, "2", item.ResumeToken)
+
+	resumed := db.ExecRequest(ctx, `subscription { User(after: "1") { name } }`)
+	require.Empty(t, resumed.GQL.Errors)
+	defer resumed.Pub.Unsubscribe()
+
+	item = (<-resumed.Pub.Stream()).(client.GQLResult)
+	assert.Equal(t, "2", item.ResumeToken)
+	assert.Equal(t, []map[string]any{{"name": "Addo"}}, item.Data)
+}
+
+func TestCollectionGetChanges_WithAfterAndLimit(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+	require.NoError(t, doc.Set("name", "Addo"))
+	require.NoError(t, col.Update(ctx, doc))
+	_, err = col.Delete(ctx, doc.Key())
+	require.NoError(t, err)
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	for i, eventType := range []string{"CREATE", "UPDATE", "DELETE"} {
+		assert.Equal(t, uint64(i+1), changes[i].Sequence)
+		assert.Equal(t, eventType, changes[i].Type)
+		assert.Equal(t, doc.Key().String(), changes[i].DocKey)
+		assert.Equal(t, uint64(i+1), changes[i].Height)
+	}
+
+	changes, err = col.GetChanges(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint64(2), changes[0].Sequence)
+	assert.Equal(t, "UPDATE", changes[0].Type)
+}
+
+func TestCollectionGetChanges_WithoutUpdateEvents(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint64(1), changes[0].Sequence)
+	assert.Equal(t, "CREATE", changes[0].Type)
+}
+
+func TestCollectionGetChanges_WithDiscardedTxn(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.WithTxn(txn).Create(ctx, doc))
+	txn.Discard(ctx)
+
+	doc, err = client.NewDocFromJSON([]byte(`{"name": "Addo"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint64(1), changes[0].Sequence)
+	assert.Equal(t, doc.Key().String(), changes[0].DocKey)
+}
+
+func TestCollectionGetChanges_WithConcurrentTxns(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	txn1, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	txn2, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"name": "John"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.WithTxn(txn1).Create(ctx, doc1))
+	doc2, err := client.NewDocFromJSON([]byte(`{"name": "Addo"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.WithTxn(txn2).Create(ctx, doc2))
+
+	require.NoError(t, txn2.Commit(ctx))
+	require.NoError(t, txn1.Commit(ctx))
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, uint64(1), changes[0].Sequence)
+	assert.Equal(t, doc2.Key().String(), changes[0].DocKey)
+	assert.Equal(t, uint64(2), changes[1].Sequence)
+	assert.Equal(t, doc1.Key().String(), changes[1].DocKey)
+}
+
+func TestSequenceChanges_WithUnsequencedChange(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	// Simulate a change that was committed but not sequenced before the database was closed.
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	change, err := json.Marshal(client.Change{
+		Type:   "CREATE",
+		DocKey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+		Cid:    "bafybeigtscyfb46emlw6ptsjrrflujsbgfszpqc2o4n6kqggu3jexw4ncy",
+		Height: 1,
+	})
+	require.NoError(t, err)
+	key := core.NewPendingChangeKey(col.Schema().Root, "bafybeigtscyfb46emlw6ptsjrrflujsbgfszpqc2o4n6kqggu3jexw4ncy")
+	require.NoError(t, txn.Systemstore().Put(ctx, key.ToDS(), change))
+	require.NoError(t, txn.Commit(ctx))
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 0)
+
+	require.NoError(t, db.sequenceChanges(ctx))
+
+	changes, err = col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint64(1), changes[0].Sequence)
+	assert.Equal(t, "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7", changes[0].DocKey)
+}
+
+func TestCollectionGetChanges_WithManyDocsInTxn_SequencesOncePerTxn(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	docs := []*client.Document{}
+	for _, name := range []string{"John", "Addo", "Fred"} {
+		doc, err := client.NewDocFromJSON([]byte(fmt.Sprintf(`{"name": "%s"}`, name)))
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+	require.NoError(t, col.WithTxn(txn).CreateMany(ctx, docs))
+	require.Len(t, db.sequencingTxns, 1)
+
+	require.NoError(t, txn.Commit(ctx))
+	require.Len(t, db.sequencingTxns, 0)
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+}
+
+func TestSequenceChanges_WithMoreUnsequencedChangesThanBatchSize(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	// Simulate a backlog of changes that were committed but not sequenced, written in
+	// reverse order of height so that the sequences do not follow the order of the keys.
+	const pendingCount = 2*changeLogBatchSize + 1
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	for i := pendingCount; i > 0; i-- {
+		change, err := json.Marshal(client.Change{
+			Type:   "UPDATE",
+			DocKey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+			Cid:    fmt.Sprintf("cid-%d", i),
+			Height: uint64(i),
+		})
+		require.NoError(t, err)
+		key := core.NewPendingChangeKey(col.Schema().Root, fmt.Sprintf("cid-%d", i))
+		require.NoError(t, txn.Systemstore().Put(ctx, key.ToDS(), change))
+	}
+	require.NoError(t, txn.Commit(ctx))
+
+	require.NoError(t, db.sequenceChanges(ctx))
+
+	changes, err := col.GetChanges(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, pendingCount)
+	for i, change := range changes {
+		assert.Equal(t, uint64(i+1), change.Sequence)
+		assert.Equal(t, uint64(i+1), change.Height)
+	}
+}
@@ -13,6 +13,8 @@ package db
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -22,6 +24,7 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/lens-vm/lens/host-go/config/model"
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -195,6 +198,21 @@ func (db *db) updateSchema(
 		return err
 	}
 
+	// Documents created at the previous version are stored by field ID, and so remain readable
+	// without migration, however documents yielded by, or fed to, Lens (for example those synced
+	// via P2P) are keyed by field name, so a migration is registered for any renamed or deleted fields.
+	// Values stored at the previous version may also need coercing to any widened field kinds.
+	fieldChanges := getSchemaFieldChanges(existingSchemaByName[schema.Name], schema)
+	if len(fieldChanges.renamed) > 0 || len(fieldChanges.deleted) > 0 || len(fieldChanges.kindChanged) > 0 {
+		err = db.lensRegistry.WithTxn(txn).SetMigration(
+			ctx,
+			newSchemaFieldChangesMigration(previousVersionID, schema.VersionID, fieldChanges),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	if setAsDefaultVersion {
 		cols, err := description.GetCollectionsBySchemaVersionID(ctx, txn, previousVersionID)
 		if err != nil {
@@ -263,9 +281,156 @@ func (db *db) validateUpdateSchema(
 		return hasChangedFields, err
 	}
 
+	err = db.validateUpdateSchemaIndexedFields(ctx, txn, getSchemaFieldChanges(existingDesc, proposedDesc))
+	if err != nil {
+		return false, err
+	}
+
 	return hasChangedFields, err
 }
 
+// validateUpdateSchemaIndexedFields validates that none of the fields renamed or deleted by
+// the given changes are indexed, as indexes reference their fields by name.
+func (db *db) validateUpdateSchemaIndexedFields(
+	ctx context.Context,
+	txn datastore.Txn,
+	changes schemaFieldChanges,
+) error {
+	if len(changes.renamed) == 0 && len(changes.deleted) == 0 && len(changes.kindChanged) == 0 {
+		return nil
+	}
+
+	cols, err := description.GetCollectionsBySchemaRoot(ctx, txn, changes.schemaRoot)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range cols {
+		indexes, err := db.fetchCollectionIndexDescriptions(ctx, txn, col.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, index := range indexes {
+			for _, field := range index.Fields {
+				for _, rename := range changes.renamed {
+					if field.Name == rename.existingName {
+						return NewErrCannotRenameIndexedField(field.Name, index.Name)
+					}
+				}
+				for _, deletedName := range changes.deleted {
+					if field.Name == deletedName {
+						return NewErrCannotDeleteIndexedField(field.Name, index.Name)
+					}
+				}
+				for _, kindChange := range changes.kindChanged {
+					if field.Name == kindChange.existingName {
+						return NewErrCannotChangeIndexedFieldKind(field.Name, index.Name)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaFieldChanges describes the existing fields of a schema that have been renamed, deleted,
+// or have had their kind changed by an update.
+type schemaFieldChanges struct {
+	schemaRoot  string
+	renamed     []fieldRename
+	deleted     []string
+	kindChanged []fieldKindChange
+}
+
+type fieldRename struct {
+	existingName string
+	proposedName string
+}
+
+type fieldKindChange struct {
+	existingName string
+	proposedName string
+	existingKind client.FieldKind
+	proposedKind client.FieldKind
+}
+
+// getSchemaFieldChanges returns the existing fields of the given existing schema that have been
+// renamed, deleted, or have had their kind changed in the given proposed schema.
+//
+// The changes are returned in the order that the fields are defined in the existing schema.
+func getSchemaFieldChanges(
+	existingDesc client.SchemaDescription,
+	proposedDesc client.SchemaDescription,
+) schemaFieldChanges {
+	proposedFieldsByID := map[client.FieldID]client.FieldDescription{}
+	for _, field := range proposedDesc.Fields {
+		if field.ID != client.FieldID(0) || field.Name == request.KeyFieldName {
+			proposedFieldsByID[field.ID] = field
+		}
+	}
+
+	changes := schemaFieldChanges{
+		schemaRoot: existingDesc.Root,
+	}
+	for _, field := range existingDesc.Fields {
+		proposedField, stillExists := proposedFieldsByID[field.ID]
+		if !stillExists {
+			changes.deleted = append(changes.deleted, field.Name)
+			continue
+		}
+
+		if proposedField.Name != field.Name {
+			changes.renamed = append(changes.renamed, fieldRename{
+				existingName: field.Name,
+				proposedName: proposedField.Name,
+			})
+		}
+		if proposedField.Kind != field.Kind {
+			changes.kindChanged = append(changes.kindChanged, fieldKindChange{
+				existingName: field.Name,
+				proposedName: proposedField.Name,
+				existingKind: field.Kind,
+				proposedKind: proposedField.Kind,
+			})
+		}
+	}
+
+	return changes
+}
+
+// newSchemaFieldChangesMigration returns the Lens migration between the given schema versions
+// that applies the given field changes to documents.
+func newSchemaFieldChangesMigration(
+	sourceSchemaVersionID string,
+	destinationSchemaVersionID string,
+	changes schemaFieldChanges,
+) client.LensConfig {
+	modules := make([]model.LensModule, 0, len(changes.renamed)+len(changes.kindChanged)+len(changes.deleted))
+	for _, rename := range changes.renamed {
+		modules = append(modules, lens.NewRenameFieldModule(rename.existingName, rename.proposedName))
+	}
+	for _, kindChange := range changes.kindChanged {
+		modules = append(modules, lens.NewCoerceFieldKindModule(
+			kindChange.proposedName,
+			kindChange.existingKind,
+			kindChange.proposedKind,
+		))
+	}
+	for _, deletedName := range changes.deleted {
+		modules = append(modules, lens.NewDropFieldModule(deletedName))
+	}
+
+	return client.LensConfig{
+		SourceSchemaVersionID:      sourceSchemaVersionID,
+		DestinationSchemaVersionID: destinationSchemaVersionID,
+		Lens: model.Lens{
+			Lenses: modules,
+		},
+	}
+}
+
 func validateUpdateSchemaFields(
 	descriptionsByName map[string]client.SchemaDescription,
 	existingDesc client.SchemaDescription,
@@ -273,12 +438,34 @@ func validateUpdateSchemaFields(
 ) (bool, error) {
 	hasChanged := false
 	existingFieldsByID := map[client.FieldID]client.FieldDescription{}
-	existingFieldIndexesByName := map[string]int{}
+	existingFieldsByName := map[string]client.FieldDescription{}
+	existingFieldIndexesByID := map[client.FieldID]int{}
 	for i, field := range existingDesc.Fields {
-		existingFieldIndexesByName[field.Name] = i
+		existingFieldsByName[field.Name] = field
+		existingFieldIndexesByID[field.ID] = i
 		existingFieldsByID[field.ID] = field
 	}
 
+	proposedFieldIDs := map[client.FieldID]struct{}{}
+	for _, proposedField := range proposedDesc.Fields {
+		if proposedField.ID != client.FieldID(0) || proposedField.Name == request.KeyFieldName {
+			proposedFieldIDs[proposedField.ID] = struct{}{}
+		}
+	}
+
+	// Deleting fields shifts the index of the fields that follow them, the number of deleted
+	// fields preceding each existing field is tracked so that the shift may be accounted for.
+	deletedFieldsBefore := make([]int, len(existingDesc.Fields))
+	deletedFieldCount := 0
+	for i, field := range existingDesc.Fields {
+		deletedFieldsBefore[i] = deletedFieldCount
+		if _, stillExists := proposedFieldIDs[field.ID]; !stillExists {
+			deletedFieldCount++
+		}
+	}
+
+	_, proposedHasKeyField := proposedDesc.GetField(request.KeyFieldName)
+
 	newFieldNames := map[string]struct{}{}
 	newFieldIds := map[client.FieldID]struct{}{}
 	for proposedIndex, proposedField := range proposedDesc.Fields {
@@ -293,8 +480,20 @@ func validateUpdateSchemaFields(
 			return false, NewErrCannotSetFieldID(proposedField.Name, proposedField.ID)
 		}
 
-		// If the field is new, then the collection has changed
-		hasChanged = hasChanged || !fieldAlreadyExists
+		// The key field shares its ID with new fields, so a renamed key field can only be
+		// identified by its position and by it otherwise matching the existing key field.
+		if keyField, hasKeyField := existingFieldsByName[request.KeyFieldName]; hasKeyField &&
+			!fieldAlreadyExists && proposedIndex == 0 && !proposedHasKeyField {
+			renamedKeyField := keyField
+			renamedKeyField.Name = proposedField.Name
+			if proposedField == renamedKeyField {
+				return false, NewErrCannotRenameField(keyField.Name, proposedField.Name)
+			}
+		}
+
+		// If the field is new, or has been renamed or had its kind changed, then the collection has changed
+		hasChanged = hasChanged || !fieldAlreadyExists || proposedField.Name != existingField.Name ||
+			proposedField.Kind != existingField.Kind
 
 		if !fieldAlreadyExists && (proposedField.Kind == client.FieldKind_FOREIGN_OBJECT ||
 			proposedField.Kind == client.FieldKind_FOREIGN_OBJECT_ARRAY) {
@@ -428,26 +627,63 @@ func validateUpdateSchemaFields(
 			return false, NewErrDuplicateField(proposedField.Name)
 		}
 
-		if fieldAlreadyExists && proposedField != existingField {
-			return false, NewErrCannotMutateField(proposedField.ID, proposedField.Name)
+		if namesake, nameExists := existingFieldsByName[proposedField.Name]; nameExists &&
+			namesake.ID != proposedField.ID {
+			return false, NewErrFieldNameReused(proposedField.Name)
 		}
 
-		if existingIndex := existingFieldIndexesByName[proposedField.Name]; fieldAlreadyExists &&
-			proposedIndex != existingIndex {
-			return false, NewErrCannotMoveField(proposedField.Name, proposedIndex, existingIndex)
+		if fieldAlreadyExists {
+			// Fields may be renamed and have their kind widened, but no other property of an existing
+			// field may be changed.
+			renamedField := existingField
+			renamedField.Name = proposedField.Name
+			renamedField.Kind = proposedField.Kind
+			if proposedField.Name == "" || proposedField != renamedField {
+				return false, NewErrCannotMutateField(proposedField.ID, proposedField.Name)
+			}
+
+			if proposedField.Kind != existingField.Kind {
+				if !existingField.Kind.CanWidenTo(proposedField.Kind) {
+					return false, NewErrCannotChangeFieldKind(proposedField.Name, existingField.Kind, proposedField.Kind)
+				}
+				if existingField.Typ != client.LWW_REGISTER {
+					return false, NewErrCannotChangeCRDTFieldKind(proposedField.Name, existingField.Typ)
+				}
+			}
+
+			if proposedField.Name != existingField.Name &&
+				(existingField.Name == request.KeyFieldName || existingField.RelationName != "") {
+				return false, NewErrCannotRenameField(existingField.Name, proposedField.Name)
+			}
+
+			existingIndex := existingFieldIndexesByID[proposedField.ID]
+			if proposedIndex != existingIndex-deletedFieldsBefore[existingIndex] {
+				return false, NewErrCannotMoveField(proposedField.Name, proposedIndex, existingIndex)
+			}
+
+			newFieldIds[proposedField.ID] = struct{}{}
 		}
 
-		if proposedField.Typ != client.NONE_CRDT && proposedField.Typ != client.LWW_REGISTER {
+		switch proposedField.Typ {
+		case client.NONE_CRDT, client.LWW_REGISTER, client.PN_COUNTER, client.OR_SET, client.RGA:
+		default:
 			return false, NewErrInvalidCRDTType(proposedField.Name, proposedField.Typ)
 		}
 
+		if !proposedField.Kind.SupportsCRDT(proposedField.Typ) {
+			return false, client.NewErrCRDTKindMismatch(proposedField.Name, proposedField.Typ, proposedField.Kind)
+		}
+
 		newFieldNames[proposedField.Name] = struct{}{}
-		newFieldIds[proposedField.ID] = struct{}{}
 	}
 
 	for _, field := range existingDesc.Fields {
 		if _, stillExists := newFieldIds[field.ID]; !stillExists {
-			return false, NewErrCannotDeleteField(field.Name, field.ID)
+			if field.Name == request.KeyFieldName || field.RelationName != "" {
+				return false, NewErrCannotDeleteField(field.Name, field.ID)
+			}
+			// If a field has been deleted, then the collection has changed
+			hasChanged = true
 		}
 	}
 	return hasChanged, nil
@@ -973,6 +1209,24 @@ func (c *collection) save(
 				return cid.Undef, err
 			}
 
+			if !val.IsDelete() {
+				switch fieldDescription.Kind {
+				case client.FieldKind_JSON:
+					val, err = jsonFieldValue(val)
+				case client.FieldKind_BLOB:
+					val, err = blobFieldValue(val)
+				}
+				if err != nil {
+					return cid.Undef, err
+				}
+			}
+			switch fieldDescription.Typ {
+			case client.PN_COUNTER, client.OR_SET, client.RGA:
+				// counters, sets and texts have no deleted state, a nil value sets them
+				// to zero or removes all their elements
+				val = client.NewCBORValue(fieldDescription.Typ, val.Value())
+			}
+
 			node, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
 			if err != nil {
 				return cid.Undef, err
@@ -1013,20 +1267,19 @@ func (c *collection) save(
 		return cid.Undef, err
 	}
 
-	if c.db.events.Updates.HasValue() {
-		txn.OnSuccess(
-			func() {
-				c.db.events.Updates.Value().Publish(
-					events.Update{
-						DocKey:     doc.Key().String(),
-						Cid:        headNode.Cid(),
-						SchemaRoot: c.Schema().Root,
-						Block:      headNode,
-						Priority:   priority,
-					},
-				)
-			},
-		)
+	err = c.db.recordUpdate(
+		ctx,
+		txn,
+		events.Update{
+			DocKey:     doc.Key().String(),
+			Cid:        headNode.Cid(),
+			SchemaRoot: c.Schema().Root,
+			Block:      headNode,
+			Priority:   priority,
+		},
+	)
+	if err != nil {
+		return cid.Undef, err
 	}
 
 	txn.OnSuccess(func() {
@@ -1179,6 +1432,44 @@ func (c *collection) exists(
 	return true, false, nil
 }
 
+// jsonFieldValue returns the value of a JSON field as its JSON text.
+//
+// Object keys are sorted when marshalled, so the stored value, and the
+// block it is stored in, does not depend on the order the keys were set in.
+func jsonFieldValue(val client.Value) (client.Value, error) {
+	value := val.Value()
+	if subDoc, ok := value.(*client.Document); ok {
+		docJSON, err := subDoc.String()
+		if err != nil {
+			return nil, err
+		}
+		value = json.RawMessage(docJSON)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewCBORValue(client.LWW_REGISTER, string(data)), nil
+}
+
+// blobFieldValue returns the value of a Blob field as the binary data it holds.
+//
+// Documents created from JSON hold the data as a base64 encoded string.
+func blobFieldValue(val client.Value) (client.Value, error) {
+	switch value := val.Value().(type) {
+	case []byte:
+		return val, nil
+	case string:
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, NewErrInvalidFieldValue(client.FieldKind_BLOB, value)
+		}
+		return client.NewCBORValue(client.LWW_REGISTER, data), nil
+	default:
+		return nil, NewErrInvalidFieldValue(client.FieldKind_BLOB, value)
+	}
+}
+
 func (c *collection) saveDocValue(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -1186,7 +1477,7 @@ func (c *collection) saveDocValue(
 	val client.Value,
 ) (ipld.Node, uint64, error) {
 	switch val.Type() {
-	case client.LWW_REGISTER:
+	case client.LWW_REGISTER, client.PN_COUNTER, client.OR_SET, client.RGA:
 		wval, ok := val.(client.WriteableValue)
 		if !ok {
 			return nil, 0, client.ErrValueTypeMismatch
@@ -1201,7 +1492,7 @@ func (c *collection) saveDocValue(
 				return nil, 0, err
 			}
 		}
-		return c.saveValueToMerkleCRDT(ctx, txn, key, client.LWW_REGISTER, bytes)
+		return c.saveValueToMerkleCRDT(ctx, txn, key, val.Type(), bytes)
 	default:
 		return nil, 0, ErrUnknownCRDT
 	}
@@ -1214,7 +1505,7 @@ func (c *collection) saveValueToMerkleCRDT(
 	ctype client.CType,
 	args ...any) (ipld.Node, uint64, error) {
 	switch ctype {
-	case client.LWW_REGISTER:
+	case client.LWW_REGISTER, client.PN_COUNTER, client.OR_SET, client.RGA:
 		fieldID, err := strconv.Atoi(key.FieldId)
 		if err != nil {
 			return nil, 0, err
@@ -1248,8 +1539,18 @@ func (c *collection) saveValueToMerkleCRDT(
 		if !ok {
 			return nil, 0, ErrUnknownCRDTArgument
 		}
-		lwwreg := merkleCRDT.(*crdt.MerkleLWWRegister)
-		return lwwreg.Set(ctx, bytes)
+		switch merkleCRDT := merkleCRDT.(type) {
+		case *crdt.MerkleLWWRegister:
+			return merkleCRDT.Set(ctx, bytes)
+		case *crdt.MerklePNCounter:
+			return merkleCRDT.Set(ctx, bytes)
+		case *crdt.MerkleORSet:
+			return merkleCRDT.Set(ctx, bytes)
+		case *crdt.MerkleRGA:
+			return merkleCRDT.Set(ctx, bytes)
+		default:
+			return nil, 0, ErrUnknownCRDT
+		}
 	case client.COMPOSITE:
 		key = key.WithFieldId(core.COMPOSITE_NAMESPACE)
 		merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
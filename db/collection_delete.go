@@ -242,6 +242,11 @@ func (c *collection) applyDelete(
 		return NewErrDocumentDeleted(key.DocKey)
 	}
 
+	err = c.deleteIndexedDoc(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
 	dsKey := key.ToDataStoreKey()
 
 	headset := clock.NewHeadSet(
@@ -274,20 +279,19 @@ func (c *collection) applyDelete(
 		return err
 	}
 
-	if c.db.events.Updates.HasValue() {
-		txn.OnSuccess(
-			func() {
-				c.db.events.Updates.Value().Publish(
-					events.Update{
-						DocKey:     key.DocKey,
-						Cid:        headNode.Cid(),
-						SchemaRoot: c.Schema().Root,
-						Block:      headNode,
-						Priority:   priority,
-					},
-				)
-			},
-		)
+	err = c.db.recordUpdate(
+		ctx,
+		txn,
+		events.Update{
+			DocKey:     key.DocKey,
+			Cid:        headNode.Cid(),
+			SchemaRoot: c.Schema().Root,
+			Block:      headNode,
+			Priority:   priority,
+		},
+	)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
@@ -140,6 +141,36 @@ func (c *collection) updateIndexedDoc(
 	return nil
 }
 
+func (c *collection) deleteIndexedDoc(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+) error {
+	err := c.loadIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	if len(c.indexes) == 0 {
+		return nil
+	}
+	desc := c.Description()
+	schema := c.Schema()
+	doc, err := c.get(ctx, txn, key, desc.CollectIndexedFields(&schema), false)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil
+	}
+	for _, index := range c.indexes {
+		err = index.Delete(ctx, txn, doc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateIndex creates a new index on the collection.
 //
 // If the index name is empty, a name will be automatically generated.
@@ -185,6 +216,8 @@ func (c *collection) createIndex(
 		return nil, err
 	}
 
+	desc.Fields = c.resolveRelationIndexFields(desc.Fields)
+
 	err = c.checkExistingFields(ctx, desc.Fields)
 	if err != nil {
 		return nil, err
@@ -420,6 +453,27 @@ func (c *collection) checkExistingFields(
 	return nil
 }
 
+// resolveRelationIndexFields replaces the relation object fields that hold the foreign
+// key of the relation with their `_id` fields, as the object fields themselves have no
+// value stored in the document.
+func (c *collection) resolveRelationIndexFields(
+	fields []client.IndexedFieldDescription,
+) []client.IndexedFieldDescription {
+	result := make([]client.IndexedFieldDescription, len(fields))
+	for i, field := range fields {
+		result[i] = field
+		fieldDesc, ok := c.Schema().GetField(field.Name)
+		if !ok || fieldDesc.Kind != client.FieldKind_FOREIGN_OBJECT {
+			continue
+		}
+		if fieldDesc.RelationType.IsSet(client.Relation_Type_Primary) ||
+			fieldDesc.RelationType.IsSet(client.Relation_Type_ONEMANY) {
+			result[i].Name = field.Name + request.RelatedObjectID
+		}
+	}
+	return result
+}
+
 func (c *collection) generateIndexNameIfNeededAndCreateKey(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -476,16 +530,15 @@ func validateIndexDescription(desc client.IndexDescription) error {
 
 func generateIndexName(col client.Collection, fields []client.IndexedFieldDescription, inc int) string {
 	sb := strings.Builder{}
-	// at the moment we support only single field indexes that can be stored only in
-	// ascending order. This will change once we introduce composite indexes.
-	direction := "ASC"
 	sb.WriteString(col.Name())
-	sb.WriteByte('_')
 	// we can safely assume that there is at least one field in the slice
 	// because we validate it before calling this function
-	sb.WriteString(fields[0].Name)
-	sb.WriteByte('_')
-	sb.WriteString(direction)
+	for _, field := range fields {
+		sb.WriteByte('_')
+		sb.WriteString(field.Name)
+		sb.WriteByte('_')
+		sb.WriteString(string(field.Direction))
+	}
 	if inc > 1 {
 		sb.WriteByte('_')
 		sb.WriteString(strconv.Itoa(inc))
@@ -12,8 +12,11 @@ package db
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"strings"
 
+	"github.com/fxamacker/cbor/v2"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/sourcenetwork/immutable"
 	"github.com/valyala/fastjson"
@@ -109,6 +112,58 @@ func (c *collection) UpdateWithKeys(
 	return res, c.commitImplicitTxn(ctx, txn)
 }
 
+// UpsertWithFilter updates the documents matching the given filter, or creates the
+// given document if no documents match. Both steps happen within the same transaction.
+// An updater value is provided, which could be a string Patch, string Merge Patch
+// or a parsed Patch, or parsed Merge Patch.
+func (c *collection) UpsertWithFilter(
+	ctx context.Context,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	if c.txn.HasValue() {
+		return c.upsertWithFilter(ctx, c.txn.Value(), filter, doc, updater)
+	}
+
+	// Concurrent upserts that create the same document will conflict, the upsert is retried
+	// so that it updates the document created by the transaction that was committed first,
+	// if that document matches the filter.
+	var txnErr error
+	for retry := 0; retry < c.db.MaxTxnRetries(); retry++ {
+		res, err := c.upsertWithImplicitTxn(ctx, filter, doc, updater)
+		if err == nil {
+			return res, nil
+		}
+		if !isTxnConflict(err) {
+			return nil, err
+		}
+		txnErr = err
+	}
+
+	return nil, client.NewErrMaxTxnRetries(txnErr)
+}
+
+func (c *collection) upsertWithImplicitTxn(
+	ctx context.Context,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	txn, err := c.db.NewTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	res, err := c.upsertWithFilter(ctx, txn, filter, doc, updater)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, txn.Commit(ctx)
+}
+
 func (c *collection) updateWithKey(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -135,7 +190,7 @@ func (c *collection) updateWithKey(
 	if isPatch {
 		// todo
 	} else {
-		err = c.applyMergeToDoc(doc, parsedUpdater.GetObject())
+		err = c.applyMergeToDoc(ctx, txn, doc, parsedUpdater.GetObject())
 	}
 	if err != nil {
 		return nil, err
@@ -183,7 +238,7 @@ func (c *collection) updateWithKeys(
 		if isPatch {
 			// todo
 		} else {
-			err = c.applyMergeToDoc(doc, parsedUpdater.GetObject())
+			err = c.applyMergeToDoc(ctx, txn, doc, parsedUpdater.GetObject())
 		}
 		if err != nil {
 			return nil, err
@@ -271,7 +326,7 @@ func (c *collection) updateWithFilter(
 		if isPatch {
 			// todo
 		} else if isMerge { // else is fine here
-			err = c.applyMergeToDoc(doc, parsedUpdater.GetObject())
+			err = c.applyMergeToDoc(ctx, txn, doc, parsedUpdater.GetObject())
 		}
 		if err != nil {
 			return nil, err
@@ -290,10 +345,44 @@ func (c *collection) updateWithFilter(
 	return results, nil
 }
 
+func (c *collection) upsertWithFilter(
+	ctx context.Context,
+	txn datastore.Txn,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	updateResult, err := c.updateWithFilter(ctx, txn, filter, updater)
+	if err != nil {
+		return nil, err
+	}
+	if updateResult.Count > 0 {
+		return &client.UpsertResult{
+			Count:   updateResult.Count,
+			DocKeys: updateResult.DocKeys,
+		}, nil
+	}
+
+	// Nothing matched the filter, so the given document is created instead. If the
+	// document already exists but does not match the filter this will return an error.
+	err = c.create(ctx, txn, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.UpsertResult{
+		Created: true,
+		Count:   1,
+		DocKeys: []string{doc.Key().String()},
+	}, nil
+}
+
 // applyMergeToDoc applies the given json merge to the given Defra doc.
 //
 // It does not save the document.
 func (c *collection) applyMergeToDoc(
+	ctx context.Context,
+	txn datastore.Txn,
 	doc *client.Document,
 	merge *fastjson.Object,
 ) error {
@@ -315,7 +404,16 @@ func (c *collection) applyMergeToDoc(
 			}
 		}
 
-		cborVal, err := validateFieldSchema(mval, fd)
+		var cborVal any
+		var err error
+		switch {
+		case fd.Typ == client.OR_SET && mval.Type() == fastjson.TypeObject:
+			cborVal, err = c.applySetOperations(ctx, txn, doc.Key(), fd, mval.GetObject())
+		case fd.Typ == client.RGA && mval.Type() == fastjson.TypeObject:
+			cborVal, err = c.applyTextOperations(ctx, txn, doc.Key(), fd, mval.GetObject())
+		default:
+			cborVal, err = validateFieldSchema(mval, fd)
+		}
 		if err != nil {
 			return err
 		}
@@ -329,6 +427,165 @@ func (c *collection) applyMergeToDoc(
 	return nil
 }
 
+// applySetOperations returns the elements of the given set field after adding the elements
+// of the `_add` operation and removing the elements of the `_remove` operation to its
+// currently stored elements.
+func (c *collection) applySetOperations(
+	ctx context.Context,
+	txn datastore.Txn,
+	docKey client.DocKey,
+	field client.FieldDescription,
+	operations *fastjson.Object,
+) ([]any, error) {
+	var added, removed []any
+	var err error
+	operations.Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+		switch string(k) {
+		case request.SetAddOperation:
+			added, err = getSetElements(v, field)
+		case request.SetRemoveOperation:
+			removed, err = getSetElements(v, field)
+		default:
+			err = NewErrUnknownSetOperation(field.Name, string(k))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var current []any
+	err = c.getStoredFieldValue(ctx, txn, docKey, field, &current)
+	if err != nil {
+		return nil, err
+	}
+
+	removedValues := make(map[string]struct{}, len(removed))
+	for _, element := range removed {
+		key, err := cbor.Marshal(element)
+		if err != nil {
+			return nil, err
+		}
+		removedValues[string(key)] = struct{}{}
+	}
+	result := make([]any, 0, len(current)+len(added))
+	resultValues := make(map[string]struct{}, len(current)+len(added))
+	for _, element := range append(current, added...) {
+		key, err := cbor.Marshal(element)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := removedValues[string(key)]; ok {
+			continue
+		}
+		if _, ok := resultValues[string(key)]; ok {
+			continue
+		}
+		resultValues[string(key)] = struct{}{}
+		result = append(result, element)
+	}
+	return result, nil
+}
+
+// applyTextOperations returns the text of the given text field after applying the splices of
+// the `_splice` operation of the given object to its currently stored text.
+//
+// Each splice deletes the given number of characters at the given index and then inserts
+// the given text there, splices are applied in order.
+func (c *collection) applyTextOperations(
+	ctx context.Context,
+	txn datastore.Txn,
+	docKey client.DocKey,
+	field client.FieldDescription,
+	operations *fastjson.Object,
+) (string, error) {
+	var splices []*fastjson.Value
+	var err error
+	operations.Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+		if string(k) != request.TextSpliceOperation {
+			err = NewErrUnknownTextOperation(field.Name, string(k))
+			return
+		}
+		if v.Type() == fastjson.TypeArray {
+			splices, err = v.Array()
+		} else {
+			splices = []*fastjson.Value{v}
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var current string
+	err = c.getStoredFieldValue(ctx, txn, docKey, field, &current)
+	if err != nil {
+		return "", err
+	}
+
+	text := []rune(current)
+	for _, splice := range splices {
+		index := splice.GetInt(request.TextSpliceIndex)
+		deleted := splice.GetInt(request.TextSpliceDelete)
+		if index < 0 || deleted < 0 || index+deleted > len(text) {
+			return "", NewErrInvalidTextSplice(field.Name, index, deleted)
+		}
+		inserted := []rune(string(splice.GetStringBytes(request.TextSpliceInsert)))
+
+		result := make([]rune, 0, len(text)-deleted+len(inserted))
+		result = append(result, text[:index]...)
+		result = append(result, inserted...)
+		text = append(result, text[index+deleted:]...)
+	}
+	return string(text), nil
+}
+
+// getStoredFieldValue decodes the currently stored value of the given field into the given
+// target, which is left unchanged if the field has no value.
+func (c *collection) getStoredFieldValue(
+	ctx context.Context,
+	txn datastore.Txn,
+	docKey client.DocKey,
+	field client.FieldDescription,
+	target any,
+) error {
+	fieldKey, ok := c.tryGetFieldKey(c.getPrimaryKeyFromDocKey(docKey), field.Name)
+	if !ok {
+		return client.NewErrFieldNotExist(field.Name)
+	}
+	buf, err := txn.Datastore().Get(ctx, fieldKey.WithValueFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	return cbor.Unmarshal(buf, target)
+}
+
+// getSetElements returns the elements of the given array validated against the given
+// set field, with the same types as the elements decoded from the stored set.
+func getSetElements(val *fastjson.Value, field client.FieldDescription) ([]any, error) {
+	array, err := validateFieldSchema(val, field)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := cbor.Marshal(array)
+	if err != nil {
+		return nil, err
+	}
+	var elements []any
+	err = cbor.Unmarshal(buf, &elements)
+	return elements, err
+}
+
 // isSecondaryIDField returns true if the given field description represents a secondary relation field ID.
 func (c *collection) isSecondaryIDField(fieldDesc client.FieldDescription) (client.FieldDescription, bool) {
 	if fieldDesc.RelationType != client.Relation_Type_INTERNAL_ID {
@@ -468,6 +725,12 @@ func validateFieldSchema(val *fastjson.Value, field client.FieldDescription) (an
 	case client.FieldKind_NILLABLE_INT_ARRAY:
 		return getNillableArray(val, getInt64)
 
+	case client.FieldKind_JSON:
+		return getJSON(val)
+
+	case client.FieldKind_BLOB:
+		return getBlob(val)
+
 	case client.FieldKind_FOREIGN_OBJECT, client.FieldKind_FOREIGN_OBJECT_ARRAY:
 		return nil, NewErrFieldOrAliasToFieldNotExist(field.Name)
 	}
@@ -480,6 +743,20 @@ func getString(v *fastjson.Value) (string, error) {
 	return string(b), err
 }
 
+func getBlob(v *fastjson.Value) ([]byte, error) {
+	b, err := v.StringBytes()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(string(b))
+}
+
+func getJSON(v *fastjson.Value) (any, error) {
+	var result any
+	err := json.Unmarshal(v.MarshalTo(nil), &result)
+	return result, err
+}
+
 func getBool(v *fastjson.Value) (bool, error) {
 	return v.Bool()
 }
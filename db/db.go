@@ -74,6 +74,23 @@ type db struct {
 
 	// The ID of the last transaction created.
 	previousTxnID atomic.Uint64
+
+	// changeLogLock ensures that pending changes are sequenced one batch at a time, and
+	// that they are published in the order that they are sequenced.
+	changeLogLock sync.Mutex
+
+	// sequencingTxnsLock guards sequencingTxns.
+	sequencingTxnsLock sync.Mutex
+	// sequencingTxns contains the IDs of the open transactions that will sequence the
+	// pending changes once committed, so that each transaction only does so once.
+	sequencingTxns map[uint64]struct{}
+
+	// webhooksLock guards webhooks.
+	webhooksLock sync.Mutex
+	// webhooks contains the workers delivering changes to each webhook, keyed by webhook ID.
+	//
+	// This will only be set if update events are enabled.
+	webhooks map[uint32]*webhookWorker
 }
 
 // Functional option type.
@@ -85,7 +102,9 @@ const updateEventBufferSize = 100
 func WithUpdateEvents() Option {
 	return func(db *db) {
 		db.events = events.Events{
-			Updates: immutable.Some(events.New[events.Update](0, updateEventBufferSize)),
+			Updates:    immutable.Some(events.New[events.Update](0, updateEventBufferSize)),
+			Changes:    immutable.Some(events.New[events.Update](0, updateEventBufferSize)),
+			Migrations: immutable.Some(events.New[events.Migration](0, updateEventBufferSize)),
 		}
 	}
 }
@@ -129,6 +148,8 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 
 		parser:  parser,
 		options: options,
+
+		sequencingTxns: map[uint64]struct{}{},
 	}
 
 	// apply options
@@ -141,13 +162,27 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 
 	// lensPoolSize may be set by `options`, and because they are funcs on db
 	// we have to mutate `db` here to set the registry.
-	db.lensRegistry = lens.NewRegistry(db.lensPoolSize, db)
+	db.lensRegistry = lens.NewRegistry(db.lensPoolSize, db, db.events.Migrations)
 
 	err = db.initialize(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	// Sequence any changes that were committed but not sequenced before the database
+	// was last closed.
+	err = db.sequenceChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if db.events.Changes.HasValue() {
+		err = db.startWebhooks(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &implicitTxnDB{db}, nil
 }
 
@@ -270,6 +305,9 @@ func (db *db) Close() {
 	log.Info(context.Background(), "Closing DefraDB process...")
 	if db.events.Updates.HasValue() {
 		db.events.Updates.Value().Close()
+		db.events.Changes.Value().Close()
+		db.events.Migrations.Value().Close()
+		db.stopWebhooks()
 	}
 
 	err := db.rootstore.Close()
@@ -17,6 +17,7 @@ import (
 	"github.com/ipfs/go-datastore/query"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/core/cid"
 	"github.com/sourcenetwork/defradb/datastore"
@@ -30,11 +31,15 @@ func CreateSchemaVersion(
 	txn datastore.Txn,
 	desc client.SchemaDescription,
 ) (client.SchemaDescription, error) {
-	for i := range desc.Fields {
-		// This is not wonderful and will probably break when we add the ability
-		// to delete fields, however it is good enough for now and matches the
-		// create behaviour.
-		desc.Fields[i].ID = client.FieldID(i)
+	if desc.Root == "" {
+		for i := range desc.Fields {
+			desc.Fields[i].ID = client.FieldID(i)
+		}
+	} else {
+		err := setNewFieldIDs(ctx, txn, &desc)
+		if err != nil {
+			return client.SchemaDescription{}, err
+		}
 	}
 
 	buf, err := json.Marshal(desc)
@@ -80,6 +85,39 @@ func CreateSchemaVersion(
 	return desc, nil
 }
 
+// setNewFieldIDs sets the IDs of any fields new to the given schema.
+//
+// New fields are given IDs greater than that of any field that has ever existed on the schema,
+// so that the values of deleted fields are never read as the values of new fields.
+func setNewFieldIDs(
+	ctx context.Context,
+	txn datastore.Txn,
+	desc *client.SchemaDescription,
+) error {
+	schemas, err := GetSchemasByRoot(ctx, txn, desc.Root)
+	if err != nil {
+		return err
+	}
+
+	var maxID client.FieldID
+	for _, schema := range schemas {
+		for _, field := range schema.Fields {
+			if field.ID > maxID {
+				maxID = field.ID
+			}
+		}
+	}
+
+	for i, field := range desc.Fields {
+		if field.ID == client.FieldID(0) && field.Name != request.KeyFieldName {
+			maxID++
+			desc.Fields[i].ID = maxID
+		}
+	}
+
+	return nil
+}
+
 // GetSchemaVersion returns the schema description for the schema version of the
 // ID provided.
 //
@@ -42,11 +42,19 @@ const (
 	errDuplicateField                     string = "duplicate field"
 	errCannotMutateField                  string = "mutating an existing field is not supported"
 	errCannotMoveField                    string = "moving fields is not currently supported"
-	errInvalidCRDTType                    string = "only default or LWW (last writer wins) CRDT types are supported"
-	errCannotDeleteField                  string = "deleting an existing field is not supported"
+	errInvalidCRDTType                    string = "only default, LWW (last writer wins), PN counter, OR set or RGA CRDT types are supported"
+	errCannotDeleteField                  string = "deleting the key field or relation fields is not supported"
+	errCannotRenameField                  string = "renaming the key field or relation fields is not supported"
+	errCannotDeleteIndexedField           string = "deleting an indexed field is not supported"
+	errCannotRenameIndexedField           string = "renaming an indexed field is not supported"
+	errFieldNameReused                    string = "the name of an existing field may not be reused by a different field"
+	errCannotChangeFieldKind              string = "the kind of an existing field may only be widened"
+	errCannotChangeCRDTFieldKind          string = "only the kind of LWW register fields may be changed"
+	errCannotChangeIndexedFieldKind       string = "changing the kind of an indexed field is not supported"
 	errFieldKindNotFound                  string = "no type found for given name"
 	errFieldKindDoesNotMatchFieldSchema   string = "field Kind does not match field Schema"
 	errSchemaNotFound                     string = "no schema found for given name"
+	errExtendedTypeNotFound               string = "the type to extend does not exist"
 	errDocumentAlreadyExists              string = "a document with the given dockey already exists"
 	errDocumentDeleted                    string = "a document with the given dockey has been deleted"
 	errIndexMissingFields                 string = "index missing fields"
@@ -86,6 +94,16 @@ const (
 	errExpectedJSONArray                  string = "expected JSON array"
 	errOneOneAlreadyLinked                string = "target document is already linked to another document"
 	errIndexDoesNotMatchName              string = "the index used does not match the given name"
+	errCanNotIndexNonUniqueField          string = "can not index a doc's field that violates unique index"
+	errUnknownSetOperation                string = "unknown set operation"
+	errUnknownTextOperation               string = "unknown text operation"
+	errInvalidTextSplice                  string = "text splice is out of range"
+	errInvalidResumeToken                 string = "invalid subscription resume token"
+	errWebhooksNotAllowed                 string = "webhooks are not allowed, update events must be enabled"
+	errInvalidWebhookURL                  string = "invalid webhook url"
+	errInvalidWebhookFilter               string = "invalid webhook filter"
+	errWebhookNotFound                    string = "webhook not found"
+	errWebhookResponse                    string = "webhook responded with an unsuccessful status"
 )
 
 var (
@@ -141,9 +159,17 @@ var (
 	ErrCannotMoveField                    = errors.New(errCannotMoveField)
 	ErrInvalidCRDTType                    = errors.New(errInvalidCRDTType)
 	ErrCannotDeleteField                  = errors.New(errCannotDeleteField)
+	ErrCannotRenameField                  = errors.New(errCannotRenameField)
+	ErrCannotDeleteIndexedField           = errors.New(errCannotDeleteIndexedField)
+	ErrCannotRenameIndexedField           = errors.New(errCannotRenameIndexedField)
+	ErrFieldNameReused                    = errors.New(errFieldNameReused)
+	ErrCannotChangeFieldKind              = errors.New(errCannotChangeFieldKind)
+	ErrCannotChangeCRDTFieldKind          = errors.New(errCannotChangeCRDTFieldKind)
+	ErrCannotChangeIndexedFieldKind       = errors.New(errCannotChangeIndexedFieldKind)
 	ErrFieldKindNotFound                  = errors.New(errFieldKindNotFound)
 	ErrFieldKindDoesNotMatchFieldSchema   = errors.New(errFieldKindDoesNotMatchFieldSchema)
 	ErrSchemaNotFound                     = errors.New(errSchemaNotFound)
+	ErrExtendedTypeNotFound               = errors.New(errExtendedTypeNotFound)
 	ErrIndexMissingFields                 = errors.New(errIndexMissingFields)
 	ErrIndexFieldMissingName              = errors.New(errIndexFieldMissingName)
 	ErrIndexFieldMissingDirection         = errors.New(errIndexFieldMissingDirection)
@@ -165,6 +191,16 @@ var (
 	ErrExpectedJSONArray                  = errors.New(errExpectedJSONArray)
 	ErrOneOneAlreadyLinked                = errors.New(errOneOneAlreadyLinked)
 	ErrIndexDoesNotMatchName              = errors.New(errIndexDoesNotMatchName)
+	ErrCanNotIndexNonUniqueField          = errors.New(errCanNotIndexNonUniqueField)
+	ErrUnknownSetOperation                = errors.New(errUnknownSetOperation)
+	ErrUnknownTextOperation               = errors.New(errUnknownTextOperation)
+	ErrInvalidTextSplice                  = errors.New(errInvalidTextSplice)
+	ErrInvalidResumeToken                 = errors.New(errInvalidResumeToken)
+	ErrWebhooksNotAllowed                 = errors.New(errWebhooksNotAllowed)
+	ErrInvalidWebhookURL                  = errors.New(errInvalidWebhookURL)
+	ErrInvalidWebhookFilter               = errors.New(errInvalidWebhookFilter)
+	ErrWebhookNotFound                    = errors.New(errWebhookNotFound)
+	ErrWebhookResponse                    = errors.New(errWebhookResponse)
 )
 
 // NewErrFieldOrAliasToFieldNotExist returns an error indicating that the given field or an alias field does not exist.
@@ -411,6 +447,10 @@ func NewErrSchemaNotFound(name string, schema string) error {
 	)
 }
 
+func NewErrExtendedTypeNotFound(name string) error {
+	return errors.New(errExtendedTypeNotFound, errors.NewKV("Name", name))
+}
+
 func NewErrDuplicateField(name string) error {
 	return errors.New(errDuplicateField, errors.NewKV("Name", name))
 }
@@ -448,6 +488,59 @@ func NewErrCannotDeleteField(name string, id client.FieldID) error {
 	)
 }
 
+func NewErrCannotRenameField(name string, proposedName string) error {
+	return errors.New(
+		errCannotRenameField,
+		errors.NewKV("Name", name),
+		errors.NewKV("ProposedName", proposedName),
+	)
+}
+
+func NewErrCannotDeleteIndexedField(name string, indexName string) error {
+	return errors.New(
+		errCannotDeleteIndexedField,
+		errors.NewKV("Name", name),
+		errors.NewKV("Index", indexName),
+	)
+}
+
+func NewErrCannotRenameIndexedField(name string, indexName string) error {
+	return errors.New(
+		errCannotRenameIndexedField,
+		errors.NewKV("Name", name),
+		errors.NewKV("Index", indexName),
+	)
+}
+
+func NewErrFieldNameReused(name string) error {
+	return errors.New(errFieldNameReused, errors.NewKV("Name", name))
+}
+
+func NewErrCannotChangeFieldKind(name string, existingKind client.FieldKind, proposedKind client.FieldKind) error {
+	return errors.New(
+		errCannotChangeFieldKind,
+		errors.NewKV("Name", name),
+		errors.NewKV("ExistingKind", existingKind),
+		errors.NewKV("ProposedKind", proposedKind),
+	)
+}
+
+func NewErrCannotChangeCRDTFieldKind(name string, crdtType client.CType) error {
+	return errors.New(
+		errCannotChangeCRDTFieldKind,
+		errors.NewKV("Name", name),
+		errors.NewKV("CRDT", crdtType),
+	)
+}
+
+func NewErrCannotChangeIndexedFieldKind(name string, indexName string) error {
+	return errors.New(
+		errCannotChangeIndexedFieldKind,
+		errors.NewKV("Name", name),
+		errors.NewKV("Index", indexName),
+	)
+}
+
 func NewErrDocumentAlreadyExists(dockey string) error {
 	return errors.New(
 		errDocumentAlreadyExists,
@@ -632,3 +725,73 @@ func NewErrIndexDoesNotMatchName(index, name string) error {
 		errors.NewKV("Name", name),
 	)
 }
+
+// NewErrCanNotIndexNonUniqueField returns a new error indicating that the given document
+// can not be indexed because its field values violate a unique index.
+func NewErrCanNotIndexNonUniqueField(dockey string, fieldValues ...errors.KV) error {
+	kvPairs := make([]errors.KV, 0, len(fieldValues)+1)
+	kvPairs = append(kvPairs, errors.NewKV("DocKey", dockey))
+	kvPairs = append(kvPairs, fieldValues...)
+
+	return errors.New(errCanNotIndexNonUniqueField, kvPairs...)
+}
+
+// NewErrUnknownSetOperation returns a new error indicating that the update of a set field
+// contains an operation other than adding or removing elements.
+func NewErrUnknownSetOperation(field string, operation string) error {
+	return errors.New(
+		errUnknownSetOperation,
+		errors.NewKV("Field", field),
+		errors.NewKV("Operation", operation),
+	)
+}
+
+// NewErrUnknownTextOperation returns a new error indicating that the update of a text field
+// contains an operation other than splicing the text.
+func NewErrUnknownTextOperation(field string, operation string) error {
+	return errors.New(
+		errUnknownTextOperation,
+		errors.NewKV("Field", field),
+		errors.NewKV("Operation", operation),
+	)
+}
+
+// NewErrInvalidTextSplice returns a new error indicating that a splice of a text field
+// is not within the bounds of the text.
+func NewErrInvalidTextSplice(field string, index int, deleted int) error {
+	return errors.New(
+		errInvalidTextSplice,
+		errors.NewKV("Field", field),
+		errors.NewKV("Index", index),
+		errors.NewKV("Delete", deleted),
+	)
+}
+
+// NewErrInvalidResumeToken returns a new error indicating that the resume token given
+// to a subscription is not a token yielded by a previous subscription.
+func NewErrInvalidResumeToken(token string, inner error) error {
+	return errors.Wrap(errInvalidResumeToken, inner, errors.NewKV("Token", token))
+}
+
+// NewErrInvalidWebhookURL returns a new error indicating that the url of a webhook
+// is not an absolute http or https url.
+func NewErrInvalidWebhookURL(url string) error {
+	return errors.New(errInvalidWebhookURL, errors.NewKV("URL", url))
+}
+
+// NewErrInvalidWebhookFilter returns a new error indicating that the filter of a webhook
+// is not a valid filter of its collection.
+func NewErrInvalidWebhookFilter(filter string, inner error) error {
+	return errors.Wrap(errInvalidWebhookFilter, inner, errors.NewKV("Filter", filter))
+}
+
+// NewErrWebhookNotFound returns a new error indicating that no webhook exists with the given ID.
+func NewErrWebhookNotFound(id uint32) error {
+	return errors.New(errWebhookNotFound, errors.NewKV("ID", id))
+}
+
+// NewErrWebhookResponse returns a new error indicating that a webhook did not accept
+// a delivery.
+func NewErrWebhookResponse(url string, status int) error {
+	return errors.New(errWebhookResponse, errors.NewKV("URL", url), errors.NewKV("Status", status))
+}
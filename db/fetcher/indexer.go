@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
@@ -21,7 +22,7 @@ import (
 )
 
 // IndexFetcher is a fetcher that fetches documents by index.
-// It fetches only the indexed field and the rest of the fields are fetched by the internal fetcher.
+// It fetches only the indexed fields and the rest of the fields are fetched by the internal fetcher.
 type IndexFetcher struct {
 	docFetcher        Fetcher
 	col               client.Collection
@@ -30,7 +31,8 @@ type IndexFetcher struct {
 	docFilter         *mapper.Filter
 	doc               *encodedDocument
 	mapping           *core.DocumentMapping
-	indexedField      client.FieldDescription
+	indexedFields     []indexedField
+	indexDesc         client.IndexDescription
 	docFields         []client.FieldDescription
 	indexIter         indexIterator
 	indexDataStoreKey core.IndexDataStoreKey
@@ -42,13 +44,13 @@ var _ Fetcher = (*IndexFetcher)(nil)
 // NewIndexFetcher creates a new IndexFetcher.
 func NewIndexFetcher(
 	docFetcher Fetcher,
-	indexedFieldDesc client.FieldDescription,
+	indexDesc client.IndexDescription,
 	indexFilter *mapper.Filter,
 ) *IndexFetcher {
 	return &IndexFetcher{
-		docFetcher:   docFetcher,
-		indexedField: indexedFieldDesc,
-		indexFilter:  indexFilter,
+		docFetcher:  docFetcher,
+		indexDesc:   indexDesc,
+		indexFilter: indexFilter,
 	}
 }
 
@@ -63,40 +65,145 @@ func (f *IndexFetcher) Init(
 	showDeleted bool,
 ) error {
 	f.col = col
-	f.docFilter = filter
 	f.doc = &encodedDocument{}
 	f.mapping = docMapper
 	f.txn = txn
 
-	for _, index := range col.Description().Indexes {
-		if index.Fields[0].Name == f.indexedField.Name {
-			f.indexDataStoreKey.IndexID = index.ID
-			break
+	f.indexedFields = make([]indexedField, 0, len(f.indexDesc.Fields))
+	for _, indexField := range f.indexDesc.Fields {
+		fieldDesc, ok := f.col.Schema().GetField(indexField.Name)
+		if !ok {
+			return client.NewErrFieldNotExist(indexField.Name)
 		}
+		f.indexedFields = append(f.indexedFields, indexedField{
+			desc:       fieldDesc,
+			descending: indexField.Direction == client.Descending,
+			docIndex:   f.mapping.FirstIndexOfName(indexField.Name),
+		})
 	}
 
 	f.indexDataStoreKey.CollectionID = f.col.ID()
+	f.indexDataStoreKey.IndexID = f.indexDesc.ID
 
+	indexFilter, docFilter := f.splitIndexedConditions(filter)
+	f.docFilter = docFilter
+
+	// the fetcher is initialized again for every document a type join looks up
+	// related documents for, so the iterator of the previous lookup has to be closed
+	if f.indexIter != nil {
+		err := f.indexIter.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	// if no fields are given all of them are fetched, like the document fetcher does
+	if len(fields) == 0 {
+		fields = f.col.Schema().Fields
+	}
+	f.docFields = make([]client.FieldDescription, 0, len(fields))
 	for i := range fields {
-		if fields[i].Name == f.indexedField.Name {
-			f.docFields = append(fields[:i], fields[i+1:]...)
-			break
+		if !f.isFetchedFromIndex(fields[i].Name) {
+			f.docFields = append(f.docFields, fields[i])
 		}
 	}
 
-	iter, err := createIndexIterator(f.indexDataStoreKey, f.indexFilter, &f.execInfo)
+	iter, err := createIndexIterator(
+		f.indexDataStoreKey,
+		indexFilter,
+		f.mapping,
+		f.indexDesc,
+		f.indexedFields,
+		reverse,
+		&f.execInfo,
+	)
 	if err != nil {
 		return err
 	}
 	f.indexIter = iter
 
-	if f.docFetcher != nil && len(f.docFields) > 0 {
+	if f.isDocFetchNeeded() {
 		err = f.docFetcher.Init(ctx, f.txn, f.col, f.docFields, f.docFilter, f.mapping, false, false)
 	}
 
 	return err
 }
 
+// splitIndexedConditions returns the index filter and the document filter to fetch with.
+// Top level conditions on the indexed fields can be added to the document filter after
+// planning, like the foreign key value a type join looks up related documents with,
+// so they are moved to the index filter.
+func (f *IndexFetcher) splitIndexedConditions(docFilter *mapper.Filter) (*mapper.Filter, *mapper.Filter) {
+	if docFilter == nil {
+		return f.indexFilter, nil
+	}
+
+	var indexConditions map[connor.FilterKey]any
+	for key, cond := range docFilter.Conditions {
+		propIndex, ok := key.(*mapper.PropertyIndex)
+		if !ok || !f.isIndexedField(propIndex.Index) {
+			continue
+		}
+		if indexConditions == nil {
+			indexConditions = map[connor.FilterKey]any{}
+		}
+		indexConditions[key] = cond
+	}
+	if indexConditions == nil {
+		return f.indexFilter, docFilter
+	}
+
+	restFilter := &mapper.Filter{
+		Conditions:         map[connor.FilterKey]any{},
+		ExternalConditions: docFilter.ExternalConditions,
+	}
+	for key, cond := range docFilter.Conditions {
+		if _, ok := indexConditions[key]; !ok {
+			restFilter.Conditions[key] = cond
+		}
+	}
+	if len(restFilter.Conditions) == 0 {
+		restFilter = nil
+	}
+
+	if f.indexFilter != nil {
+		for key, cond := range f.indexFilter.Conditions {
+			indexConditions[key] = cond
+		}
+	}
+	return &mapper.Filter{Conditions: indexConditions}, restFilter
+}
+
+// isIndexedField returns true if the field with the given document mapping index
+// is one of the indexed fields.
+func (f *IndexFetcher) isIndexedField(docIndex int) bool {
+	for _, field := range f.indexedFields {
+		if field.docIndex == docIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// isDocFetchNeeded returns true if the documents found in the index have to be fetched
+// to read the fields that are not indexed or to check the rest of the filter.
+func (f *IndexFetcher) isDocFetchNeeded() bool {
+	return f.docFetcher != nil && (len(f.docFields) > 0 || f.docFilter != nil)
+}
+
+// isFetchedFromIndex returns true if the value of the field with the given name
+// is read from the index key.
+// Datetime values are stored in the index without their time zone, so they are
+// fetched together with the rest of the document.
+func (f *IndexFetcher) isFetchedFromIndex(fieldName string) bool {
+	for _, field := range f.indexedFields {
+		if field.desc.Name == fieldName {
+			return field.desc.Kind != client.FieldKind_DATETIME
+		}
+	}
+	return false
+}
+
 func (f *IndexFetcher) Start(ctx context.Context, spans core.Spans) error {
 	err := f.indexIter.Init(ctx, f.txn.Datastore())
 	if err != nil {
@@ -112,25 +219,43 @@ func (f *IndexFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo
 	for {
 		f.doc.Reset()
 
-		indexKey, hasValue, err := f.indexIter.Next()
+		res, err := f.indexIter.Next()
 		if err != nil {
 			return nil, ExecInfo{}, err
 		}
 
-		if !hasValue {
+		if !res.foundKey {
 			return nil, f.execInfo, nil
 		}
 
-		property := &encProperty{
-			Desc: f.indexedField,
-			Raw:  indexKey.FieldValues[0],
+		for i, field := range f.indexedFields {
+			if field.desc.Kind == client.FieldKind_DATETIME {
+				continue
+			}
+			val, err := core.DecodeIndexFieldValue(res.key.FieldValues[i], field.descending)
+			if err != nil {
+				return nil, ExecInfo{}, err
+			}
+			raw, err := client.NewCBORValue(client.LWW_REGISTER, val).Bytes()
+			if err != nil {
+				return nil, ExecInfo{}, err
+			}
+			f.doc.properties[field.desc] = &encProperty{
+				Desc: field.desc,
+				Raw:  raw,
+			}
+			f.execInfo.FieldsFetched++
 		}
 
-		f.doc.key = indexKey.FieldValues[1]
-		f.doc.properties[f.indexedField] = property
-		f.execInfo.FieldsFetched++
+		// unique indexes store the document key as the value unless one of the
+		// indexed values is nil, in which case it is appended to the key
+		if f.indexDesc.Unique && len(res.key.FieldValues) == len(f.indexedFields) {
+			f.doc.key = res.value
+		} else {
+			f.doc.key = res.key.FieldValues[len(res.key.FieldValues)-1]
+		}
 
-		if f.docFetcher != nil && len(f.docFields) > 0 {
+		if f.isDocFetchNeeded() {
 			targetKey := base.MakeDocKey(f.col.Description(), string(f.doc.key))
 			spans := core.NewSpans(core.NewSpan(targetKey, targetKey.PrefixEnd()))
 			err = f.docFetcher.Start(ctx, spans)
@@ -14,30 +14,37 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"sort"
 	"strings"
 
-	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/datastore/iterable"
 	"github.com/sourcenetwork/defradb/planner/mapper"
-
-	"github.com/ipfs/go-datastore/query"
 )
 
 const (
-	opEq    = "_eq"
-	opGt    = "_gt"
-	opGe    = "_ge"
-	opLt    = "_lt"
-	opLe    = "_le"
-	opNe    = "_ne"
-	opIn    = "_in"
-	opNin   = "_nin"
-	opLike  = "_like"
-	opNlike = "_nlike"
+	opEq     = "_eq"
+	opGt     = "_gt"
+	opGe     = "_ge"
+	opLt     = "_lt"
+	opLe     = "_le"
+	opNe     = "_ne"
+	opIn     = "_in"
+	opNin    = "_nin"
+	opLike   = "_like"
+	opNlike  = "_nlike"
+	opIlike  = "_ilike"
+	opNilike = "_nilike"
+	opRegex  = "_regex"
 )
 
 // indexIterator is an iterator over index keys.
@@ -45,45 +52,56 @@ const (
 // For example, iteration over condition _eq and _gt will have completely different logic.
 type indexIterator interface {
 	Init(context.Context, datastore.DSReaderWriter) error
-	Next() (core.IndexDataStoreKey, bool, error)
+	Next() (indexIterResult, error)
 	Close() error
 }
 
+// indexIterResult is a single result of an index iteration.
+type indexIterResult struct {
+	// key is the index key that was found.
+	key core.IndexDataStoreKey
+	// foundKey is true if a key was found, false if the iteration is finished.
+	foundKey bool
+	// value is the value stored under the index key.
+	value []byte
+}
+
 type queryResultIterator struct {
 	resultIter query.Results
+	execInfo   *ExecInfo
 }
 
-func (i queryResultIterator) Next() (core.IndexDataStoreKey, bool, error) {
+func (i *queryResultIterator) Next() (indexIterResult, error) {
 	res, hasVal := i.resultIter.NextSync()
 	if res.Error != nil {
-		return core.IndexDataStoreKey{}, false, res.Error
+		return indexIterResult{}, res.Error
 	}
 	if !hasVal {
-		return core.IndexDataStoreKey{}, false, nil
+		return indexIterResult{}, nil
 	}
+	i.execInfo.IndexesFetched++
 	key, err := core.NewIndexDataStoreKey(res.Key)
 	if err != nil {
-		return core.IndexDataStoreKey{}, false, err
+		return indexIterResult{}, err
 	}
-	return key, true, nil
+	return indexIterResult{key: key, value: res.Value, foundKey: true}, nil
 }
 
-func (i queryResultIterator) Close() error {
+func (i *queryResultIterator) Close() error {
 	return i.resultIter.Close()
 }
 
-type eqIndexIterator struct {
+// prefixIndexIterator iterates over all index keys that start with the field values
+// of the given key.
+// Without any field values it iterates over the whole index.
+type prefixIndexIterator struct {
 	queryResultIterator
-	indexKey  core.IndexDataStoreKey
-	filterVal []byte
-	execInfo  *ExecInfo
+	indexKey core.IndexDataStoreKey
 }
 
-func (i *eqIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
-	i.indexKey.FieldValues = [][]byte{i.filterVal}
+func (i *prefixIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
 	resultIter, err := store.Query(ctx, query.Query{
-		Prefix:   i.indexKey.ToString(),
-		KeysOnly: true,
+		Prefix: i.indexKey.ToString(),
 	})
 	if err != nil {
 		return err
@@ -92,40 +110,199 @@ func (i *eqIndexIterator) Init(ctx context.Context, store datastore.DSReaderWrit
 	return nil
 }
 
-func (i *eqIndexIterator) Next() (core.IndexDataStoreKey, bool, error) {
-	key, hasValue, err := i.queryResultIterator.Next()
-	if hasValue {
-		i.execInfo.IndexesFetched++
+// keyRangeMax is appended to an index key to get a key that sorts after all keys
+// that start with it, as hex encoded field values never contain this byte.
+const keyRangeMax = "\xff"
+
+// keyRange is an inclusive range of index keys.
+type keyRange struct {
+	start string
+	end   string
+}
+
+// rangeIndexIterator iterates over the index keys that fall within the given
+// key ranges. The ranges are read one after another in the given order.
+// If reverse is true, the ranges and the keys within them are read backwards.
+type rangeIndexIterator struct {
+	queryResultIterator
+	ranges    []keyRange
+	reverse   bool
+	nextRange int
+	ctx       context.Context
+	iter      iterable.Iterator
+}
+
+func (i *rangeIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.ctx = ctx
+	q := query.Query{}
+	if i.reverse {
+		q.Orders = []query.Order{query.OrderByKeyDescending{}}
+	}
+	iter, err := store.GetIterator(q)
+	if err != nil {
+		return err
+	}
+	i.iter = iter
+	return i.nextResults()
+}
+
+func (i *rangeIndexIterator) nextResults() error {
+	if i.resultIter != nil {
+		err := i.resultIter.Close()
+		i.resultIter = nil
+		if err != nil {
+			return err
+		}
+	}
+	if i.nextRange >= len(i.ranges) {
+		return nil
+	}
+	var resultIter query.Results
+	var err error
+	if i.reverse {
+		r := i.ranges[len(i.ranges)-1-i.nextRange]
+		resultIter, err = i.iter.IteratePrefix(i.ctx, ds.NewKey(r.end), ds.NewKey(r.start))
+	} else {
+		r := i.ranges[i.nextRange]
+		resultIter, err = i.iter.IteratePrefix(i.ctx, ds.NewKey(r.start), ds.NewKey(r.end))
+	}
+	i.nextRange++
+	if err != nil {
+		return err
+	}
+	i.resultIter = resultIter
+	return nil
+}
+
+func (i *rangeIndexIterator) Next() (indexIterResult, error) {
+	for i.resultIter != nil {
+		res, err := i.queryResultIterator.Next()
+		if err != nil || res.foundKey {
+			return res, err
+		}
+		err = i.nextResults()
+		if err != nil {
+			return indexIterResult{}, err
+		}
+	}
+	return indexIterResult{}, nil
+}
+
+func (i *rangeIndexIterator) Close() error {
+	if i.resultIter != nil {
+		err := i.resultIter.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if i.iter != nil {
+		return i.iter.Close()
+	}
+	return nil
+}
+
+// eqSingleIndexIterator fetches a single index key that exactly matches the given key.
+// It is used for unique indexes where at most one document can have the values.
+type eqSingleIndexIterator struct {
+	indexKey core.IndexDataStoreKey
+	execInfo *ExecInfo
+
+	ctx   context.Context
+	store datastore.DSReaderWriter
+}
+
+func (i *eqSingleIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.ctx = ctx
+	i.store = store
+	return nil
+}
+
+func (i *eqSingleIndexIterator) Next() (indexIterResult, error) {
+	if i.store == nil {
+		return indexIterResult{}, nil
 	}
-	return key, hasValue, err
+	val, err := i.store.Get(i.ctx, i.indexKey.ToDS())
+	i.store = nil
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return indexIterResult{key: i.indexKey}, nil
+		}
+		return indexIterResult{}, err
+	}
+	i.execInfo.IndexesFetched++
+	return indexIterResult{key: i.indexKey, value: val, foundKey: true}, nil
+}
+
+func (i *eqSingleIndexIterator) Close() error {
+	return nil
 }
 
+// newEqIndexIterator returns an iterator over the index keys that start with the
+// field values of the given key.
+// If isFullUniqueMatch is true, the key identifies at most a single document
+// and can be fetched directly.
+// If reverse is true, the keys are returned in descending order.
+func newEqIndexIterator(
+	indexKey core.IndexDataStoreKey,
+	isFullUniqueMatch bool,
+	reverse bool,
+	execInfo *ExecInfo,
+) indexIterator {
+	if isFullUniqueMatch {
+		return &eqSingleIndexIterator{
+			indexKey: indexKey,
+			execInfo: execInfo,
+		}
+	}
+	if reverse {
+		prefix := indexKey.ToString()
+		return &rangeIndexIterator{
+			ranges:              []keyRange{{start: prefix, end: prefix + "/" + keyRangeMax}},
+			reverse:             true,
+			queryResultIterator: queryResultIterator{execInfo: execInfo},
+		}
+	}
+	return &prefixIndexIterator{
+		indexKey:            indexKey,
+		queryResultIterator: queryResultIterator{execInfo: execInfo},
+	}
+}
+
+// inIndexIterator iterates over the index keys that start with any of the given
+// field values. It creates a new eq iterator for every value, so the values are
+// expected to be sorted in the order the keys should be returned in.
 type inIndexIterator struct {
-	eqIndexIterator
-	filterValues [][]byte
-	nextValIndex int
-	ctx          context.Context
-	store        datastore.DSReaderWriter
-	hasIterator  bool
+	indexIterator
+	indexKey          core.IndexDataStoreKey
+	filterValues      [][]byte
+	isFullUniqueMatch bool
+	reverse           bool
+	execInfo          *ExecInfo
+	nextValIndex      int
+	ctx               context.Context
+	store             datastore.DSReaderWriter
+	hasIterator       bool
 }
 
 func newInIndexIterator(
 	indexKey core.IndexDataStoreKey,
 	filterValues [][]byte,
+	isFullUniqueMatch bool,
+	reverse bool,
 	execInfo *ExecInfo,
 ) *inIndexIterator {
 	return &inIndexIterator{
-		eqIndexIterator: eqIndexIterator{
-			indexKey: indexKey,
-			execInfo: execInfo,
-		},
-		filterValues: filterValues,
+		indexKey:          indexKey,
+		filterValues:      filterValues,
+		isFullUniqueMatch: isFullUniqueMatch,
+		reverse:           reverse,
+		execInfo:          execInfo,
 	}
 }
 
 func (i *inIndexIterator) nextIterator() (bool, error) {
 	if i.nextValIndex > 0 {
-		err := i.eqIndexIterator.Close()
+		err := i.indexIterator.Close()
 		if err != nil {
 			return false, err
 		}
@@ -135,8 +312,13 @@ func (i *inIndexIterator) nextIterator() (bool, error) {
 		return false, nil
 	}
 
-	i.filterVal = i.filterValues[i.nextValIndex]
-	err := i.eqIndexIterator.Init(i.ctx, i.store)
+	valKey := i.indexKey
+	valKey.FieldValues = make([][]byte, 0, len(i.indexKey.FieldValues)+1)
+	valKey.FieldValues = append(valKey.FieldValues, i.indexKey.FieldValues...)
+	valKey.FieldValues = append(valKey.FieldValues, i.filterValues[i.nextValIndex])
+
+	i.indexIterator = newEqIndexIterator(valKey, i.isFullUniqueMatch, i.reverse, i.execInfo)
+	err := i.indexIterator.Init(i.ctx, i.store)
 	if err != nil {
 		return false, err
 	}
@@ -152,92 +334,52 @@ func (i *inIndexIterator) Init(ctx context.Context, store datastore.DSReaderWrit
 	return err
 }
 
-func (i *inIndexIterator) Next() (core.IndexDataStoreKey, bool, error) {
+func (i *inIndexIterator) Next() (indexIterResult, error) {
 	for i.hasIterator {
-		key, hasValue, err := i.eqIndexIterator.Next()
+		res, err := i.indexIterator.Next()
 		if err != nil {
-			return core.IndexDataStoreKey{}, false, err
+			return indexIterResult{}, err
 		}
-		if !hasValue {
+		if !res.foundKey {
 			i.hasIterator, err = i.nextIterator()
 			if err != nil {
-				return core.IndexDataStoreKey{}, false, err
+				return indexIterResult{}, err
 			}
 			continue
 		}
-		return key, true, nil
+		return res, nil
 	}
-	return core.IndexDataStoreKey{}, false, nil
+	return indexIterResult{}, nil
 }
 
 func (i *inIndexIterator) Close() error {
+	if i.hasIterator {
+		return i.indexIterator.Close()
+	}
 	return nil
 }
 
-type errorCheckingFilter struct {
+// matchingIndexIterator skips all keys of the underlying iterator that are
+// not accepted by the matcher.
+type matchingIndexIterator struct {
+	indexIterator
 	matcher indexMatcher
-	err     error
-}
-
-func (f *errorCheckingFilter) Filter(e query.Entry) bool {
-	if f.err != nil {
-		return false
-	}
-	indexKey, err := core.NewIndexDataStoreKey(e.Key)
-	if err != nil {
-		f.err = err
-		return false
-	}
-	res, err := f.matcher.Match(indexKey)
-	if err != nil {
-		f.err = err
-		return false
-	}
-	return res
-}
-
-// execInfoIndexMatcherDecorator is a decorator for indexMatcher that counts the number
-// of indexes fetched on every call to Match.
-type execInfoIndexMatcherDecorator struct {
-	matcher  indexMatcher
-	execInfo *ExecInfo
-}
-
-func (d *execInfoIndexMatcherDecorator) Match(key core.IndexDataStoreKey) (bool, error) {
-	d.execInfo.IndexesFetched++
-	return d.matcher.Match(key)
-}
-
-type scanningIndexIterator struct {
-	queryResultIterator
-	indexKey core.IndexDataStoreKey
-	matcher  indexMatcher
-	filter   errorCheckingFilter
-	execInfo *ExecInfo
 }
 
-func (i *scanningIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
-	i.filter.matcher = &execInfoIndexMatcherDecorator{matcher: i.matcher, execInfo: i.execInfo}
-
-	iter, err := store.Query(ctx, query.Query{
-		Prefix:   i.indexKey.ToString(),
-		KeysOnly: true,
-		Filters:  []query.Filter{&i.filter},
-	})
-	if err != nil {
-		return err
-	}
-	i.resultIter = iter
-
-	return nil
-}
-
-func (i *scanningIndexIterator) Next() (core.IndexDataStoreKey, bool, error) {
-	key, hasValue, err := i.queryResultIterator.Next()
-	if i.filter.err != nil {
-		return core.IndexDataStoreKey{}, false, i.filter.err
+func (i *matchingIndexIterator) Next() (indexIterResult, error) {
+	for {
+		res, err := i.indexIterator.Next()
+		if err != nil || !res.foundKey {
+			return res, err
+		}
+		matches, err := i.matcher.Match(res.key)
+		if err != nil {
+			return indexIterResult{}, err
+		}
+		if matches {
+			return res, nil
+		}
 	}
-	return key, hasValue, err
 }
 
 // checks if the stored index value satisfies the condition
@@ -245,220 +387,373 @@ type indexMatcher interface {
 	Match(core.IndexDataStoreKey) (bool, error)
 }
 
-// indexByteValuesMatcher is a filter that compares the index value with a given value.
-// It uses bytes.Compare to compare the values and evaluate the result with evalFunc.
-type indexByteValuesMatcher struct {
-	value []byte
-	// evalFunc receives a result of bytes.Compare
-	evalFunc func(int) bool
-}
-
-func (m *indexByteValuesMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	res := bytes.Compare(key.FieldValues[0], m.value)
-	return m.evalFunc(res), nil
+// indexFilterMatcher decodes the field values of the index key and matches
+// them against the index filter conditions.
+type indexFilterMatcher struct {
+	filter  *mapper.Filter
+	mapping *core.DocumentMapping
+	fields  []indexedField
 }
 
-// matcher if _ne condition is met
-type neIndexMatcher struct {
-	value []byte
-}
-
-func (m *neIndexMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	return !bytes.Equal(key.FieldValues[0], m.value), nil
+func (m *indexFilterMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
+	doc := m.mapping.NewDoc()
+	for i, field := range m.fields {
+		val, err := core.DecodeIndexFieldValue(key.FieldValues[i], field.descending)
+		if err != nil {
+			return false, err
+		}
+		doc.Fields[field.docIndex] = val
+	}
+	return mapper.RunFilter(doc, m.filter)
+}
+
+// indexedField describes a field of the index that is being iterated.
+type indexedField struct {
+	desc       client.FieldDescription
+	descending bool
+	// docIndex is the index of the field in the document mapping.
+	docIndex int
+}
+
+// getFieldConditions returns the operators and their values that the index filter
+// contains for the given field.
+func getFieldConditions(
+	filter *mapper.Filter,
+	docIndex int,
+) (map[string]any, error) {
+	result := map[string]any{}
+	for key, cond := range filter.Conditions {
+		propIndex, ok := key.(*mapper.PropertyIndex)
+		if !ok || propIndex.Index != docIndex {
+			continue
+		}
+		condMap, ok := cond.(map[connor.FilterKey]any)
+		if !ok {
+			return nil, NewErrInvalidIndexFilterCondition(cond)
+		}
+		for opKey, filterVal := range condMap {
+			op, ok := opKey.(*mapper.Operator)
+			if !ok {
+				return nil, NewErrInvalidIndexFilterCondition(cond)
+			}
+			switch op.Operation {
+			case opEq, opGt, opGe, opLt, opLe, opNe, opIn, opNin, opLike, opNlike, opIlike, opNilike, opRegex:
+				result[op.Operation] = filterVal
+			default:
+				return nil, NewErrInvalidIndexFilterCondition(cond)
+			}
+		}
+	}
+	return result, nil
 }
 
-// checks if the index value is or is not in the given array
-type indexInArrayMatcher struct {
-	values map[string]bool
-	isIn   bool
+// isLossyIndexValue returns true if the value loses precision when it is encoded
+// as a value of the given field kind.
+func isLossyIndexValue(kind client.FieldKind, val any) bool {
+	f, ok := val.(float64)
+	return ok && kind == client.FieldKind_INT && f != math.Trunc(f)
 }
 
-func newNinIndexCmp(values [][]byte, isIn bool) *indexInArrayMatcher {
-	valuesMap := make(map[string]bool)
-	for _, v := range values {
-		valuesMap[string(v)] = true
+// getLikePrefix returns the literal prefix that all strings matching the given
+// _like pattern start with.
+// It returns false if the pattern starts with a wildcard.
+func getLikePrefix(pattern string) (string, bool) {
+	if len(pattern) < 2 {
+		return pattern, true
 	}
-	return &indexInArrayMatcher{values: valuesMap, isIn: isIn}
-}
-
-func (m *indexInArrayMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	_, found := m.values[string(key.FieldValues[0])]
-	return found == m.isIn, nil
+	if pattern[0] == '%' {
+		return "", false
+	}
+	if pattern[len(pattern)-1] == '%' {
+		return pattern[:len(pattern)-1], true
+	}
+	parts := strings.Split(pattern, "%")
+	if len(parts) == 2 {
+		return parts[0], true
+	}
+	return pattern, true
 }
 
-// checks if the index value satisfies the LIKE condition
-type indexLikeMatcher struct {
-	hasPrefix   bool
-	hasSuffix   bool
-	startAndEnd []string
-	isLike      bool
-	value       string
+// getRegexPrefix returns the literal prefix that all strings matching the given
+// _regex pattern start with.
+// It returns false if the pattern is not anchored to the start of the string.
+func getRegexPrefix(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	re = re.Simplify()
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+	}
+	if subs[0].Op != syntax.OpBeginText {
+		return "", false
+	}
+	var prefix strings.Builder
+	for _, sub := range subs[1:] {
+		if sub.Op != syntax.OpLiteral || sub.Flags&syntax.FoldCase != 0 {
+			break
+		}
+		prefix.WriteString(string(sub.Rune))
+	}
+	return prefix.String(), true
 }
 
-func newLikeIndexCmp(filterValue string, isLike bool) *indexLikeMatcher {
-	matcher := &indexLikeMatcher{
-		isLike: isLike,
+// getFieldKeyRanges returns the ranges of index keys that can contain values of
+// the given field that satisfy the conditions.
+// The prefix key holds the values of the preceding index fields.
+//
+// The ranges are only narrowed down, the exact check is left to the matcher.
+// It returns nil if none of the conditions can narrow down the range.
+func getFieldKeyRanges(
+	prefix core.IndexDataStoreKey,
+	field indexedField,
+	conds map[string]any,
+) []keyRange {
+	prefixStr := prefix.ToString()
+	valueKey := func(val []byte) string {
+		return prefixStr + "/" + string(val)
 	}
-	if len(filterValue) >= 2 {
-		if filterValue[0] == '%' {
-			matcher.hasPrefix = true
-			filterValue = strings.TrimPrefix(filterValue, "%")
+	r := keyRange{start: prefixStr, end: prefixStr + "/" + keyRangeMax}
+	isNarrowed := false
+	setStart := func(start string) {
+		if start > r.start {
+			r.start = start
 		}
-		if filterValue[len(filterValue)-1] == '%' {
-			matcher.hasSuffix = true
-			filterValue = strings.TrimSuffix(filterValue, "%")
-		}
-		if !matcher.hasPrefix && !matcher.hasSuffix {
-			matcher.startAndEnd = strings.Split(filterValue, "%")
+		isNarrowed = true
+	}
+	setEnd := func(end string) {
+		if end < r.end {
+			r.end = end
 		}
+		isNarrowed = true
 	}
-	matcher.value = filterValue
 
-	return matcher
-}
+	for op, val := range conds {
+		switch op {
+		case opGt, opGe, opLt, opLe:
+			if val == nil {
+				continue
+			}
+			valueBytes, err := core.EncodeIndexFieldValue(field.desc.Kind, val, field.descending)
+			if err != nil {
+				// the matcher will decide how to treat the value
+				continue
+			}
+			// nil never satisfies a range condition and is the lowest value
+			nilBytes, _ := core.EncodeIndexFieldValue(field.desc.Kind, nil, field.descending)
+			if field.descending {
+				setEnd(valueKey(nilBytes))
+			} else {
+				setStart(valueKey(nilBytes) + keyRangeMax)
+			}
 
-func (m *indexLikeMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	var currentVal string
-	err := cbor.Unmarshal(key.FieldValues[0], &currentVal)
-	if err != nil {
-		return false, err
+			isLowerBound := op == opGt || op == opGe
+			if field.descending {
+				isLowerBound = !isLowerBound
+			}
+			// Keys of the value itself are only skipped if the value converts to the
+			// field kind exactly, otherwise (e.g. 4.5 for an int field) they have to
+			// be checked by the matcher.
+			isExclusive := (op == opGt || op == opLt) && !isLossyIndexValue(field.desc.Kind, val)
+			switch {
+			case isLowerBound && isExclusive:
+				setStart(valueKey(valueBytes) + keyRangeMax)
+			case isLowerBound:
+				setStart(valueKey(valueBytes))
+			case isExclusive:
+				setEnd(valueKey(valueBytes))
+			default:
+				setEnd(valueKey(valueBytes) + keyRangeMax)
+			}
+		case opLike, opRegex:
+			if re, isRegex := val.(*regexp.Regexp); isRegex {
+				val = re.String()
+			}
+			pattern, ok := val.(string)
+			if !ok || (field.desc.Kind != client.FieldKind_STRING &&
+				field.desc.Kind != client.FieldKind_FOREIGN_OBJECT) {
+				continue
+			}
+			var stringPrefix string
+			if op == opLike {
+				stringPrefix, ok = getLikePrefix(pattern)
+			} else {
+				stringPrefix, ok = getRegexPrefix(pattern)
+				ok = ok && stringPrefix != ""
+			}
+			if !ok {
+				continue
+			}
+			start := valueKey(core.EncodeIndexStringPrefix(stringPrefix, field.descending))
+			setStart(start)
+			setEnd(start + keyRangeMax)
+		}
 	}
 
-	return m.doesMatch(currentVal) == m.isLike, nil
-}
+	ranges := []keyRange{r}
+	// a value that does not convert to the field kind exactly is not equal to any of its keys
+	if neVal, ok := conds[opNe]; ok && !isLossyIndexValue(field.desc.Kind, neVal) {
+		valueBytes, err := core.EncodeIndexFieldValue(field.desc.Kind, neVal, field.descending)
+		if err == nil {
+			// skip all keys of the value, but keep those before and after it
+			key := valueKey(valueBytes)
+			before, after := r, r
+			if key < before.end {
+				before.end = key
+			}
+			if key+keyRangeMax > after.start {
+				after.start = key + keyRangeMax
+			}
+			ranges = []keyRange{before, after}
+			isNarrowed = true
+		}
+	}
+	if !isNarrowed {
+		return nil
+	}
 
-func (m *indexLikeMatcher) doesMatch(currentVal string) bool {
-	switch {
-	case m.hasPrefix && m.hasSuffix:
-		return strings.Contains(currentVal, m.value)
-	case m.hasPrefix:
-		return strings.HasSuffix(currentVal, m.value)
-	case m.hasSuffix:
-		return strings.HasPrefix(currentVal, m.value)
-	// there might be 2 ends only for LIKE with 1 % in the middle "ab%cd"
-	case len(m.startAndEnd) == 2:
-		return strings.HasPrefix(currentVal, m.startAndEnd[0]) &&
-			strings.HasSuffix(currentVal, m.startAndEnd[1])
-	default:
-		return m.value == currentVal
+	result := make([]keyRange, 0, len(ranges))
+	for _, kr := range ranges {
+		if kr.start <= kr.end {
+			result = append(result, kr)
+		}
 	}
+	return result
 }
 
+// createIndexIterator creates an iterator that returns index keys matching the
+// index filter conditions.
+//
+// Leading index fields that are filtered with _eq form a key prefix, so that only
+// the matching part of the index is read. If the next field is filtered with _in,
+// a prefix is read for every value. Range conditions (_gt, _ge, _lt, _le, _ne, and
+// _like or anchored _regex with a literal prefix) on the next field limit the keys
+// that are read to the matching key ranges. All conditions that can not be resolved
+// exactly by the read keys are checked by a matcher for each key read.
+//
+// The keys are returned in the order of the index, or in the opposite order if
+// reverse is true.
 func createIndexIterator(
 	indexDataStoreKey core.IndexDataStoreKey,
 	indexFilterConditions *mapper.Filter,
+	mapping *core.DocumentMapping,
+	indexDesc client.IndexDescription,
+	fields []indexedField,
+	reverse bool,
 	execInfo *ExecInfo,
 ) (indexIterator, error) {
-	var op string
-	var filterVal any
-	for _, indexFilterCond := range indexFilterConditions.Conditions {
-		condMap := indexFilterCond.(map[connor.FilterKey]any)
-		var key connor.FilterKey
-		for key, filterVal = range condMap {
-			break
-		}
-		opKey := key.(*mapper.Operator)
-		op = opKey.Operation
-		break
+	indexDataStoreKey.FieldValues = nil
+	if indexFilterConditions == nil {
+		indexFilterConditions = mapper.NewFilter()
 	}
+	needsMatcher := false
+	hasNilValue := false
+	var inValues [][]byte
+	var ranges []keyRange
 
-	switch op {
-	case opEq, opGt, opGe, opLt, opLe, opNe:
-		writableValue := client.NewCBORValue(client.LWW_REGISTER, filterVal)
-
-		valueBytes, err := writableValue.Bytes()
+	for _, field := range fields {
+		conds, err := getFieldConditions(indexFilterConditions, field.docIndex)
 		if err != nil {
 			return nil, err
 		}
-
-		switch op {
-		case opEq:
-			return &eqIndexIterator{
-				indexKey:  indexDataStoreKey,
-				filterVal: valueBytes,
-				execInfo:  execInfo,
-			}, nil
-		case opGt:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res > 0 },
-				},
-				execInfo: execInfo,
-			}, nil
-		case opGe:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res > 0 || res == 0 },
-				},
-				execInfo: execInfo,
-			}, nil
-		case opLt:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res < 0 },
-				},
-				execInfo: execInfo,
-			}, nil
-		case opLe:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res < 0 || res == 0 },
-				},
-				execInfo: execInfo,
-			}, nil
-		case opNe:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &neIndexMatcher{
-					value: valueBytes,
-				},
-				execInfo: execInfo,
-			}, nil
-		}
-	case opIn, opNin:
-		inArr, ok := filterVal.([]any)
-		if !ok {
-			return nil, errors.New("invalid _in/_nin value")
+		if len(conds) == 0 {
+			break
 		}
-		valArr := make([][]byte, 0, len(inArr))
-		for _, v := range inArr {
-			writableValue := client.NewCBORValue(client.LWW_REGISTER, v)
-			valueBytes, err := writableValue.Bytes()
+
+		if eqVal, ok := conds[opEq]; ok {
+			if isLossyIndexValue(field.desc.Kind, eqVal) {
+				// no value of the field kind is equal to the value (e.g. 2.5 for an int
+				// field), so none of the keys can match
+				inValues = [][]byte{}
+				break
+			}
+			valueBytes, err := core.EncodeIndexFieldValue(field.desc.Kind, eqVal, field.descending)
 			if err != nil {
 				return nil, err
 			}
-			valArr = append(valArr, valueBytes)
+			indexDataStoreKey.FieldValues = append(indexDataStoreKey.FieldValues, valueBytes)
+			hasNilValue = hasNilValue || eqVal == nil
+			if len(conds) > 1 {
+				needsMatcher = true
+			}
+			continue
 		}
-		if op == opIn {
-			return newInIndexIterator(indexDataStoreKey, valArr, execInfo), nil
+
+		if inVal, ok := conds[opIn]; ok {
+			inArr, ok := inVal.([]any)
+			if !ok {
+				return nil, NewErrInvalidIndexFilterCondition(inVal)
+			}
+			seen := map[string]struct{}{}
+			inValues = make([][]byte, 0, len(inArr))
+			for _, v := range inArr {
+				if isLossyIndexValue(field.desc.Kind, v) {
+					// the value can not be equal to any value of the field kind
+					continue
+				}
+				valueBytes, err := core.EncodeIndexFieldValue(field.desc.Kind, v, field.descending)
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := seen[string(valueBytes)]; ok {
+					continue
+				}
+				seen[string(valueBytes)] = struct{}{}
+				inValues = append(inValues, valueBytes)
+				hasNilValue = hasNilValue || v == nil
+			}
+			sort.Slice(inValues, func(i, j int) bool {
+				return (bytes.Compare(inValues[i], inValues[j]) < 0) != reverse
+			})
+			if len(conds) > 1 {
+				needsMatcher = true
+			}
 		} else {
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher:  newNinIndexCmp(valArr, false),
-				execInfo: execInfo,
-			}, nil
+			ranges = getFieldKeyRanges(indexDataStoreKey, field, conds)
+			needsMatcher = true
 		}
-	case opLike:
-		return &scanningIndexIterator{
-			indexKey: indexDataStoreKey,
-			matcher:  newLikeIndexCmp(filterVal.(string), true),
-			execInfo: execInfo,
-		}, nil
-	case opNlike:
-		return &scanningIndexIterator{
-			indexKey: indexDataStoreKey,
-			matcher:  newLikeIndexCmp(filterVal.(string), false),
-			execInfo: execInfo,
-		}, nil
+		break
+	}
+
+	// conditions on fields that come after the prefix can only be checked by the matcher
+	for i := len(indexDataStoreKey.FieldValues) + 1; i < len(fields); i++ {
+		conds, err := getFieldConditions(indexFilterConditions, fields[i].docIndex)
+		if err != nil {
+			return nil, err
+		}
+		if len(conds) > 0 {
+			needsMatcher = true
+		}
+	}
+
+	var iter indexIterator
+	if ranges != nil {
+		iter = &rangeIndexIterator{
+			ranges:              ranges,
+			reverse:             reverse,
+			queryResultIterator: queryResultIterator{execInfo: execInfo},
+		}
+	} else if inValues != nil {
+		isFullUniqueMatch := indexDesc.Unique && !hasNilValue &&
+			len(indexDataStoreKey.FieldValues)+1 == len(fields)
+		iter = newInIndexIterator(indexDataStoreKey, inValues, isFullUniqueMatch, reverse, execInfo)
+	} else {
+		isFullUniqueMatch := indexDesc.Unique && !hasNilValue &&
+			len(indexDataStoreKey.FieldValues) == len(fields)
+		iter = newEqIndexIterator(indexDataStoreKey, isFullUniqueMatch, reverse, execInfo)
 	}
 
-	return nil, errors.New("invalid index filter condition")
+	if needsMatcher {
+		iter = &matchingIndexIterator{
+			indexIterator: iter,
+			matcher: &indexFilterMatcher{
+				filter:  indexFilterConditions,
+				mapping: mapping,
+				fields:  fields,
+			},
+		}
+	}
+	return iter, nil
 }
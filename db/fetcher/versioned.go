@@ -21,9 +21,11 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/memory"
 	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/description"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/merkle/crdt"
@@ -95,6 +97,9 @@ type VersionedFetcher struct {
 	col client.Collection
 	// @todo index  *client.IndexDescription
 	mCRDTs map[uint32]crdt.MerkleCRDT
+
+	// Cache the schema versions that the fetched commits were made at, by version ID.
+	schemasByVersionID map[string]client.SchemaDescription
 }
 
 // Init initializes the VersionedFetcher.
@@ -111,6 +116,7 @@ func (vf *VersionedFetcher) Init(
 	vf.col = col
 	vf.queuedCids = list.New()
 	vf.mCRDTs = make(map[uint32]crdt.MerkleCRDT)
+	vf.schemasByVersionID = make(map[string]client.SchemaDescription)
 	vf.txn = txn
 
 	// create store
@@ -344,6 +350,13 @@ func (vf *VersionedFetcher) merge(c cid.Cid) error {
 		return err
 	}
 
+	// The links are named after the fields as they were at the time of commit, which
+	// may have since been renamed or deleted.
+	commitSchema, err := vf.getCommitSchema(nd)
+	if err != nil {
+		return err
+	}
+
 	// handle subgraphs
 	// loop over links and ignore head links
 	for _, l := range nd.Links() {
@@ -357,14 +370,16 @@ func (vf *VersionedFetcher) merge(c cid.Cid) error {
 			return err
 		}
 
-		schema := vf.col.Schema()
-		field, ok := vf.col.Description().GetFieldByName(l.Name, &schema)
+		commitField, ok := commitSchema.GetField(l.Name)
 		if !ok {
 			return client.NewErrFieldNotExist(l.Name)
 		}
-		// @todo: Right now we ONLY handle LWW_REGISTER, need to swith on this and
-		//        get CType from descriptions
-		if err := vf.processNode(uint32(field.ID), subNd, client.LWW_REGISTER, l.Name); err != nil {
+		field, ok := getFieldByID(vf.col.Schema(), commitField.ID)
+		if !ok {
+			// The field has since been deleted
+			continue
+		}
+		if err := vf.processNode(uint32(field.ID), subNd, field.Typ, field.Name); err != nil {
 			return err
 		}
 	}
@@ -372,6 +387,41 @@ func (vf *VersionedFetcher) merge(c cid.Cid) error {
 	return nil
 }
 
+// getCommitSchema returns the schema version that the given composite node was committed at.
+func (vf *VersionedFetcher) getCommitSchema(nd format.Node) (client.SchemaDescription, error) {
+	delta, err := vf.mCRDTs[0].DeltaDecode(nd)
+	if err != nil {
+		return client.SchemaDescription{}, err
+	}
+
+	compositeDelta, ok := delta.(*corecrdt.CompositeDAGDelta)
+	if !ok || compositeDelta.SchemaVersionID == "" ||
+		compositeDelta.SchemaVersionID == vf.col.Schema().VersionID {
+		return vf.col.Schema(), nil
+	}
+
+	if schema, ok := vf.schemasByVersionID[compositeDelta.SchemaVersionID]; ok {
+		return schema, nil
+	}
+
+	schema, err := description.GetSchemaVersion(vf.ctx, vf.txn, compositeDelta.SchemaVersionID)
+	if err != nil {
+		return client.SchemaDescription{}, err
+	}
+	vf.schemasByVersionID[compositeDelta.SchemaVersionID] = schema
+
+	return schema, nil
+}
+
+func getFieldByID(schema client.SchemaDescription, id client.FieldID) (client.FieldDescription, bool) {
+	for _, field := range schema.Fields {
+		if field.ID == id {
+			return field, true
+		}
+	}
+	return client.FieldDescription{}, false
+}
+
 func (vf *VersionedFetcher) processNode(
 	crdtIndex uint32,
 	nd format.Node,
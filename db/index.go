@@ -28,6 +28,8 @@ type CollectionIndex interface {
 	Save(context.Context, datastore.Txn, *client.Document) error
 	// Update updates an existing document in the index
 	Update(context.Context, datastore.Txn, *client.Document, *client.Document) error
+	// Delete removes a document from the index
+	Delete(context.Context, datastore.Txn, *client.Document) error
 	// RemoveAll removes all documents from the index
 	RemoveAll(context.Context, datastore.Txn) error
 	// Name returns the name of the index
@@ -43,7 +45,7 @@ func canConvertIndexFieldValue[T any](val any) bool {
 
 func getValidateIndexFieldFunc(kind client.FieldKind) func(any) bool {
 	switch kind {
-	case client.FieldKind_STRING, client.FieldKind_FOREIGN_OBJECT:
+	case client.FieldKind_STRING, client.FieldKind_FOREIGN_OBJECT, client.FieldKind_DocKey:
 		return canConvertIndexFieldValue[string]
 	case client.FieldKind_INT:
 		return canConvertIndexFieldValue[int64]
@@ -81,60 +83,150 @@ func NewCollectionIndex(
 	if len(desc.Fields) == 0 {
 		return nil, NewErrIndexDescHasNoFields(desc)
 	}
-	index := &collectionSimpleIndex{collection: collection, desc: desc}
-	field, foundField := collection.Schema().GetField(desc.Fields[0].Name)
-	if !foundField {
-		return nil, NewErrIndexDescHasNonExistingField(desc, desc.Fields[0].Name)
+	base := collectionBaseIndex{collection: collection, desc: desc}
+	base.fieldsDescs = make([]client.FieldDescription, len(desc.Fields))
+	base.validateFieldFuncs = make([]func(any) bool, len(desc.Fields))
+	for i := range desc.Fields {
+		field, foundField := collection.Schema().GetField(desc.Fields[i].Name)
+		if !foundField {
+			return nil, NewErrIndexDescHasNonExistingField(desc, desc.Fields[i].Name)
+		}
+		base.fieldsDescs[i] = field
+		validateFunc, err := getFieldValidateFunc(field.Kind)
+		if err != nil {
+			return nil, err
+		}
+		base.validateFieldFuncs[i] = validateFunc
+	}
+	if desc.Unique {
+		return &collectionUniqueIndex{collectionBaseIndex: base}, nil
 	}
-	var e error
-	index.fieldDesc = field
-	index.validateFieldFunc, e = getFieldValidateFunc(field.Kind)
-	return index, e
+	return &collectionSimpleIndex{collectionBaseIndex: base}, nil
 }
 
-// collectionSimpleIndex is an non-unique index that indexes documents by a single field.
-// Single-field indexes store values only in ascending order.
-type collectionSimpleIndex struct {
-	collection        client.Collection
-	desc              client.IndexDescription
-	validateFieldFunc func(any) bool
-	fieldDesc         client.FieldDescription
+// collectionBaseIndex contains the functionality shared by all index types.
+type collectionBaseIndex struct {
+	collection         client.Collection
+	desc               client.IndexDescription
+	validateFieldFuncs []func(any) bool
+	fieldsDescs        []client.FieldDescription
 }
 
-var _ CollectionIndex = (*collectionSimpleIndex)(nil)
-
-func (i *collectionSimpleIndex) getDocumentsIndexKey(
-	doc *client.Document,
-) (core.IndexDataStoreKey, error) {
-	fieldValue, err := i.getDocFieldValue(doc)
-	if err != nil {
-		return core.IndexDataStoreKey{}, err
+// getDocFieldValues returns the values of all indexed fields of the document
+// in the order they are defined in the index.
+// Fields that the document does not have a value for are returned as nil.
+func (i *collectionBaseIndex) getDocFieldValues(doc *client.Document) ([]any, error) {
+	result := make([]any, len(i.fieldsDescs))
+	for j, field := range i.fieldsDescs {
+		fieldVal, err := doc.GetValue(field.Name)
+		if err != nil {
+			if errors.Is(err, client.ErrFieldNotExist) {
+				continue
+			} else {
+				return nil, err
+			}
+		}
+		writeableVal, ok := fieldVal.(client.WriteableValue)
+		if !ok || !i.validateFieldFuncs[j](fieldVal.Value()) {
+			return nil, NewErrInvalidFieldValue(field.Kind, writeableVal)
+		}
+		result[j] = fieldVal.Value()
 	}
+	return result, nil
+}
 
+// getDocumentsIndexKey returns the index key of the document that contains
+// only the indexed field values.
+func (i *collectionBaseIndex) getDocumentsIndexKey(
+	fieldValues []any,
+) (core.IndexDataStoreKey, error) {
 	indexDataStoreKey := core.IndexDataStoreKey{}
 	indexDataStoreKey.CollectionID = i.collection.ID()
 	indexDataStoreKey.IndexID = i.desc.ID
-	indexDataStoreKey.FieldValues = [][]byte{fieldValue, []byte(doc.Key().String())}
+	indexDataStoreKey.FieldValues = make([][]byte, len(fieldValues))
+	for j, val := range fieldValues {
+		encodedVal, err := core.EncodeIndexFieldValue(
+			i.fieldsDescs[j].Kind,
+			val,
+			i.desc.Fields[j].Direction == client.Descending,
+		)
+		if err != nil {
+			return core.IndexDataStoreKey{}, err
+		}
+		indexDataStoreKey.FieldValues[j] = encodedVal
+	}
 	return indexDataStoreKey, nil
 }
 
-func (i *collectionSimpleIndex) getDocFieldValue(doc *client.Document) ([]byte, error) {
-	// collectionSimpleIndex only supports single field indexes, that's why we
-	// can safely access the first field
-	indexedFieldName := i.desc.Fields[0].Name
-	fieldVal, err := doc.GetValue(indexedFieldName)
+func (i *collectionBaseIndex) deleteIndexKey(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.IndexDataStoreKey,
+) error {
+	exists, err := txn.Datastore().Has(ctx, key.ToDS())
 	if err != nil {
-		if errors.Is(err, client.ErrFieldNotExist) {
-			return client.NewCBORValue(client.LWW_REGISTER, nil).Bytes()
-		} else {
-			return nil, err
+		return err
+	}
+	if !exists {
+		return NewErrCorruptedIndex(i.desc.Name)
+	}
+	return txn.Datastore().Delete(ctx, key.ToDS())
+}
+
+// RemoveAll remove all artifacts of the index from the storage, i.e. all index
+// field values for all documents.
+func (i *collectionBaseIndex) RemoveAll(ctx context.Context, txn datastore.Txn) error {
+	prefixKey := core.IndexDataStoreKey{}
+	prefixKey.CollectionID = i.collection.ID()
+	prefixKey.IndexID = i.desc.ID
+
+	keys, err := datastore.FetchKeysForPrefix(ctx, prefixKey.ToString(), txn.Datastore())
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err := txn.Datastore().Delete(ctx, key)
+		if err != nil {
+			return NewCanNotDeleteIndexedField(err)
 		}
 	}
-	writeableVal, ok := fieldVal.(client.WriteableValue)
-	if !ok || !i.validateFieldFunc(fieldVal.Value()) {
-		return nil, NewErrInvalidFieldValue(i.fieldDesc.Kind, writeableVal)
+
+	return nil
+}
+
+// Name returns the name of the index
+func (i *collectionBaseIndex) Name() string {
+	return i.desc.Name
+}
+
+// Description returns the description of the index
+func (i *collectionBaseIndex) Description() client.IndexDescription {
+	return i.desc
+}
+
+// collectionSimpleIndex is an non-unique index that indexes documents by one or
+// more fields. The document key is always the last segment of the key.
+type collectionSimpleIndex struct {
+	collectionBaseIndex
+}
+
+var _ CollectionIndex = (*collectionSimpleIndex)(nil)
+
+func (i *collectionSimpleIndex) getDocumentsIndexKey(
+	doc *client.Document,
+) (core.IndexDataStoreKey, error) {
+	fieldValues, err := i.getDocFieldValues(doc)
+	if err != nil {
+		return core.IndexDataStoreKey{}, err
 	}
-	return writeableVal.Bytes()
+	key, err := i.collectionBaseIndex.getDocumentsIndexKey(fieldValues)
+	if err != nil {
+		return core.IndexDataStoreKey{}, err
+	}
+
+	key.FieldValues = append(key.FieldValues, []byte(doc.Key().String()))
+	return key, nil
 }
 
 // Save indexes a document by storing the indexed field value.
@@ -162,52 +254,125 @@ func (i *collectionSimpleIndex) Update(
 	oldDoc *client.Document,
 	newDoc *client.Document,
 ) error {
-	key, err := i.getDocumentsIndexKey(oldDoc)
+	err := i.Delete(ctx, txn, oldDoc)
 	if err != nil {
 		return err
 	}
-	exists, err := txn.Datastore().Has(ctx, key.ToDS())
+	return i.Save(ctx, txn, newDoc)
+}
+
+// Delete removes the document from the index.
+func (i *collectionSimpleIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	key, err := i.getDocumentsIndexKey(doc)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return NewErrCorruptedIndex(i.desc.Name)
+	return i.deleteIndexKey(ctx, txn, key)
+}
+
+// collectionUniqueIndex is a unique index that indexes documents by one or more fields.
+// The key of a unique index contains only the indexed field values and the document
+// key is stored as the value.
+// Documents that do not have a value for any of the indexed fields are not considered
+// to violate the uniqueness constraint. Their keys additionally contain the document key.
+type collectionUniqueIndex struct {
+	collectionBaseIndex
+}
+
+var _ CollectionIndex = (*collectionUniqueIndex)(nil)
+
+func (i *collectionUniqueIndex) getDocumentsIndexKey(
+	doc *client.Document,
+) (core.IndexDataStoreKey, error) {
+	fieldValues, err := i.getDocFieldValues(doc)
+	if err != nil {
+		return core.IndexDataStoreKey{}, err
 	}
-	err = txn.Datastore().Delete(ctx, key.ToDS())
+	key, err := i.collectionBaseIndex.getDocumentsIndexKey(fieldValues)
 	if err != nil {
-		return err
+		return core.IndexDataStoreKey{}, err
 	}
-	return i.Save(ctx, txn, newDoc)
-}
 
-// RemoveAll remove all artifacts of the index from the storage, i.e. all index
-// field values for all documents.
-func (i *collectionSimpleIndex) RemoveAll(ctx context.Context, txn datastore.Txn) error {
-	prefixKey := core.IndexDataStoreKey{}
-	prefixKey.CollectionID = i.collection.ID()
-	prefixKey.IndexID = i.desc.ID
+	for _, val := range fieldValues {
+		if val == nil {
+			key.FieldValues = append(key.FieldValues, []byte(doc.Key().String()))
+			break
+		}
+	}
+	return key, nil
+}
 
-	keys, err := datastore.FetchKeysForPrefix(ctx, prefixKey.ToString(), txn.Datastore())
+// Save indexes a document by storing the indexed field values.
+//
+// It returns an error if another document with the same field values is already indexed.
+func (i *collectionUniqueIndex) Save(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	key, err := i.getDocumentsIndexKey(doc)
 	if err != nil {
 		return err
 	}
+	exists, err := txn.Datastore().Has(ctx, key.ToDS())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return i.newUniqueIndexError(doc)
+	}
+	err = txn.Datastore().Put(ctx, key.ToDS(), []byte(doc.Key().String()))
+	if err != nil {
+		return NewErrFailedToStoreIndexedField(key.ToDS().String(), err)
+	}
+	return nil
+}
 
-	for _, key := range keys {
-		err := txn.Datastore().Delete(ctx, key)
+func (i *collectionUniqueIndex) newUniqueIndexError(doc *client.Document) error {
+	kvs := make([]errors.KV, 0, len(i.fieldsDescs))
+	for _, field := range i.fieldsDescs {
+		fieldVal, err := doc.GetValue(field.Name)
+		var val any
 		if err != nil {
-			return NewCanNotDeleteIndexedField(err)
+			if !errors.Is(err, client.ErrFieldNotExist) {
+				return err
+			}
+		} else {
+			val = fieldVal.Value()
 		}
+		kvs = append(kvs, errors.NewKV(field.Name, val))
 	}
-
-	return nil
+	return NewErrCanNotIndexNonUniqueField(doc.Key().String(), kvs...)
 }
 
-// Name returns the name of the index
-func (i *collectionSimpleIndex) Name() string {
-	return i.desc.Name
+// Update updates indexed field values of an existing document.
+// It removes the old document from the index and adds the new one.
+func (i *collectionUniqueIndex) Update(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	err := i.Delete(ctx, txn, oldDoc)
+	if err != nil {
+		return err
+	}
+	return i.Save(ctx, txn, newDoc)
 }
 
-// Description returns the description of the index
-func (i *collectionSimpleIndex) Description() client.IndexDescription {
-	return i.desc
+// Delete removes the document from the index.
+func (i *collectionUniqueIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	key, err := i.getDocumentsIndexKey(doc)
+	if err != nil {
+		return err
+	}
+	return i.deleteIndexKey(ctx, txn, key)
 }
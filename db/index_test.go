@@ -365,6 +365,21 @@ func TestCreateIndex_IfGeneratedNameMatchesExisting_AddIncrement(t *testing.T) {
 	assert.Equal(t, name+"_3", newDesc3.Name)
 }
 
+func TestCreateIndex_IfCompositeIndexHasNoName_GenerateItFromFieldsAndDirections(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	desc := client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{
+			{Name: usersNameFieldName},
+			{Name: usersAgeFieldName, Direction: client.Descending},
+		},
+	}
+	newDesc, err := f.createCollectionIndex(desc)
+	require.NoError(t, err)
+	assert.Equal(t, usersColName+"_"+usersNameFieldName+"_ASC_"+usersAgeFieldName+"_DESC", newDesc.Name)
+}
+
 func TestCreateIndex_ShouldSaveToSystemStorage(t *testing.T) {
 	f := newIndexTestFixture(t)
 	defer f.db.Close()
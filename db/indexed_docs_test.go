@@ -16,16 +16,19 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	ipfsDatastore "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
+	"github.com/sourcenetwork/immutable"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/mocks"
@@ -77,13 +80,17 @@ func (f *indexTestFixture) newProdDoc(id int, price float64, cat string) *client
 // indexKeyBuilder is a helper for building index keys that can be turned into a string.
 // The format of the non-unique index key is: "/<collection_id>/<index_id>/<value>/<doc_id>"
 // Example: "/5/1/12/bae-61cd6879-63ca-5ca9-8731-470a3c1dac69"
+// The format of the unique index key is: "/<collection_id>/<index_id>/<value>"
+// Example: "/5/1/12"
+// Composite indexes contain a value for each of the indexed fields.
+// Values are encoded with core.EncodeIndexFieldValue.
 type indexKeyBuilder struct {
-	f         *indexTestFixture
-	colName   string
-	fieldName string
-	doc       *client.Document
-	values    [][]byte
-	isUnique  bool
+	f          *indexTestFixture
+	colName    string
+	fieldNames []string
+	doc        *client.Document
+	values     []any
+	isUnique   bool
 }
 
 func newIndexKeyBuilder(f *indexTestFixture) *indexKeyBuilder {
@@ -99,7 +106,13 @@ func (b *indexKeyBuilder) Col(colName string) *indexKeyBuilder {
 // If the field name is not set, the index key will contain only collection id.
 // When building a key it will it will find the field id to use in the key.
 func (b *indexKeyBuilder) Field(fieldName string) *indexKeyBuilder {
-	b.fieldName = fieldName
+	return b.Fields(fieldName)
+}
+
+// Fields sets the field names of a composite index for the index key.
+// They are used to find the index that indexes exactly these fields.
+func (b *indexKeyBuilder) Fields(fieldNames ...string) *indexKeyBuilder {
+	b.fieldNames = fieldNames
 	return b
 }
 
@@ -114,11 +127,13 @@ func (b *indexKeyBuilder) Doc(doc *client.Document) *indexKeyBuilder {
 
 // Values sets the values for the index key.
 // It will override the field values stored in the document.
-func (b *indexKeyBuilder) Values(values ...[]byte) *indexKeyBuilder {
+func (b *indexKeyBuilder) Values(values ...any) *indexKeyBuilder {
 	b.values = values
 	return b
 }
 
+// Unique marks the key as a unique index key.
+// The document id is then appended to the key only if any field value is nil.
 func (b *indexKeyBuilder) Unique() *indexKeyBuilder {
 	b.isUnique = true
 	return b
@@ -145,37 +160,55 @@ func (b *indexKeyBuilder) Build() core.IndexDataStoreKey {
 	}
 	key.CollectionID = collection.ID()
 
-	if b.fieldName == "" {
+	if len(b.fieldNames) == 0 {
 		return key
 	}
 
 	indexes, err := collection.GetIndexes(b.f.ctx)
 	require.NoError(b.f.t, err)
+	var indexDesc client.IndexDescription
+indexLoop:
 	for _, index := range indexes {
-		if index.Fields[0].Name == b.fieldName {
-			key.IndexID = index.ID
-			break
+		if len(index.Fields) != len(b.fieldNames) {
+			continue
 		}
+		for i := range index.Fields {
+			if index.Fields[i].Name != b.fieldNames[i] {
+				continue indexLoop
+			}
+		}
+		indexDesc = index
+		key.IndexID = index.ID
+		break
 	}
 
-	if b.doc != nil {
-		var fieldBytesVal []byte
-		var writeableVal client.WriteableValue
-		if len(b.values) == 0 {
-			fieldVal, err := b.doc.GetValue(b.fieldName)
-			require.NoError(b.f.t, err)
-			var ok bool
-			writeableVal, ok = fieldVal.(client.WriteableValue)
+	if b.doc != nil || len(b.values) > 0 {
+		hasNilValue := false
+		for i, fieldName := range b.fieldNames {
+			var fieldVal any
+			if i < len(b.values) {
+				fieldVal = b.values[i]
+			} else if b.doc != nil {
+				val, err := b.doc.GetValue(fieldName)
+				if err != nil {
+					require.ErrorIs(b.f.t, err, client.ErrFieldNotExist)
+				} else {
+					fieldVal = val.Value()
+				}
+			}
+			hasNilValue = hasNilValue || fieldVal == nil
+
+			fieldDesc, ok := collection.Schema().GetField(fieldName)
 			require.True(b.f.t, ok)
-		} else {
-			writeableVal = client.NewCBORValue(client.LWW_REGISTER, b.values[0])
+			descending := indexDesc.Fields != nil && indexDesc.Fields[i].Direction == client.Descending
+			fieldBytesVal, err := core.EncodeIndexFieldValue(fieldDesc.Kind, fieldVal, descending)
+			require.NoError(b.f.t, err)
+			key.FieldValues = append(key.FieldValues, fieldBytesVal)
 		}
-		fieldBytesVal, err = writeableVal.Bytes()
-		require.NoError(b.f.t, err)
 
-		key.FieldValues = [][]byte{fieldBytesVal, []byte(b.doc.Key().String())}
-	} else if len(b.values) > 0 {
-		key.FieldValues = b.values
+		if b.doc != nil && (!b.isUnique || hasNilValue) {
+			key.FieldValues = append(key.FieldValues, []byte(b.doc.Key().String()))
+		}
 	}
 
 	return key
@@ -236,6 +269,8 @@ func (f *indexTestFixture) stubSystemStore(systemStoreOn *mocks.DSReaderWriter_E
 	if f.users != nil {
 		sequenceKey := core.NewSequenceKey(fmt.Sprintf("%s/%d", core.COLLECTION_INDEX, f.users.ID()))
 		systemStoreOn.Get(mock.Anything, sequenceKey.ToDS()).Maybe().Return([]byte{0, 0, 0, 0, 0, 0, 0, 1}, nil)
+
+		f.stubChangeLog(systemStoreOn)
 	}
 
 	systemStoreOn.Get(mock.Anything, mock.Anything).Maybe().Return([]byte{}, nil)
@@ -247,6 +282,16 @@ func (f *indexTestFixture) stubSystemStore(systemStoreOn *mocks.DSReaderWriter_E
 	systemStoreOn.Delete(mock.Anything, mock.Anything).Maybe().Return(nil)
 }
 
+// stubChangeLog stubs the system store calls made when recording a change to the users
+// collection.
+func (f *indexTestFixture) stubChangeLog(systemStoreOn *mocks.DSReaderWriter_Expecter) {
+	prefix := core.NewPendingChangeKey(f.users.Schema().Root, "").ToString()
+	matchPendingChangeFunc := func(key ipfsDatastore.Key) bool {
+		return strings.HasPrefix(key.String(), prefix)
+	}
+	systemStoreOn.Put(mock.Anything, mock.MatchedBy(matchPendingChangeFunc), mock.Anything).Maybe().Return(nil)
+}
+
 func TestNonUnique_IfDocIsAdded_ShouldBeIndexed(t *testing.T) {
 	f := newIndexTestFixture(t)
 	defer f.db.Close()
@@ -314,6 +359,7 @@ func TestNonUnique_IfSystemStorageHasInvalidIndexDescription_Error(t *testing.T)
 	systemStoreOn := mockTxn.MockSystemstore.EXPECT()
 	systemStoreOn.Query(mock.Anything, mock.Anything).
 		Return(mocks.NewQueryResultsWithValues(t, []byte("invalid")), nil)
+	f.stubChangeLog(systemStoreOn)
 
 	err := f.users.WithTxn(mockTxn).Create(f.ctx, doc)
 	assert.ErrorIs(t, err, datastore.NewErrInvalidStoredValue(nil))
@@ -332,6 +378,7 @@ func TestNonUnique_IfSystemStorageFailsToReadIndexDesc_Error(t *testing.T) {
 	systemStoreOn := mockTxn.MockSystemstore.EXPECT()
 	systemStoreOn.Query(mock.Anything, mock.Anything).
 		Return(nil, testErr)
+	f.stubChangeLog(systemStoreOn)
 
 	err := f.users.WithTxn(mockTxn).Create(f.ctx, doc)
 	require.ErrorIs(t, err, testErr)
@@ -503,7 +550,7 @@ func TestNonUnique_IfIndexedFieldIsNil_StoreItAsNil(t *testing.T) {
 	f.saveDocToCollection(doc, f.users)
 
 	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).
-		Values([]byte(nil)).Build()
+		Values(nil).Build()
 
 	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
 	require.NoError(t, err)
@@ -953,7 +1000,7 @@ func TestNonUpdate_IfIndexedFieldWasNil_ShouldDeleteIt(t *testing.T) {
 	f.saveDocToCollection(doc, f.users)
 
 	oldKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).
-		Values([]byte(nil)).Build()
+		Values(nil).Build()
 
 	err = doc.Set(usersNameFieldName, "John")
 	require.NoError(f.t, err)
@@ -1001,3 +1048,291 @@ func (encdoc *shimEncodedDocument) Reset() {
 	encdoc.status = 0
 	encdoc.properties = map[client.FieldDescription]any{}
 }
+
+func (f *indexTestFixture) createUserCollectionUniqueIndexOnName() client.IndexDescription {
+	desc := getUsersIndexDescOnName()
+	desc.Unique = true
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+func TestUnique_IfDocIsAdded_ShouldBeIndexedWithDocKeyAsValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc.Key().String()), data)
+}
+
+func TestUnique_IfDocWithSameValueIsAdded_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+
+	err := f.users.Create(f.ctx, f.newUserDoc("John", 18))
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueField)
+}
+
+func TestUnique_IfIndexedFieldIsNil_StoreItWithDocKey(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	docJSON, err := json.Marshal(struct {
+		Age int `json:"age"`
+	}{Age: 44})
+	require.NoError(f.t, err)
+
+	doc, err := client.NewDocFromJSON(docJSON)
+	require.NoError(f.t, err)
+
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().
+		Values(nil).Build()
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc.Key().String()), data)
+}
+
+func TestUniqueCreate_IfExistingDocsHaveSameValue_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 18), f.users)
+
+	desc := getUsersIndexDescOnName()
+	desc.Unique = true
+	_, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueField)
+}
+
+func TestUniqueUpdate_ShouldDeleteOldValueAndStoreNewOne(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	oldKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	err := doc.Set(usersNameFieldName, "Islam")
+	require.NoError(t, err)
+	err = f.users.Update(f.ctx, doc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	newKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	_, err = f.txn.Datastore().Get(f.ctx, oldKey.ToDS())
+	require.Error(t, err)
+	_, err = f.txn.Datastore().Get(f.ctx, newKey.ToDS())
+	require.NoError(t, err)
+}
+
+func TestNonUniqueDelete_ShouldDeleteIndexedValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+
+	_, err := f.users.Delete(f.ctx, doc.Key())
+	require.NoError(t, err)
+	f.commitTxn()
+
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func TestUniqueDelete_ShouldDeleteIndexedValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	_, err := f.users.Delete(f.ctx, doc.Key())
+	require.NoError(t, err)
+	f.commitTxn()
+
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func (f *indexTestFixture) createUserCollectionCompositeIndexOnNameAndAge(
+	isUnique bool,
+) client.IndexDescription {
+	desc := client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{
+			{Name: usersNameFieldName, Direction: client.Ascending},
+			{Name: usersAgeFieldName, Direction: client.Descending},
+		},
+		Unique: isUnique,
+	}
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+func TestComposite_IfDocIsAdded_ShouldBeIndexedByAllFields(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionCompositeIndexOnNameAndAge(false)
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Fields(usersNameFieldName, usersAgeFieldName).
+		Doc(doc).Build()
+	require.Len(t, key.FieldValues, 3)
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Len(t, data, 0)
+}
+
+func TestComposite_IfDescendingField_ShouldStoreItInReverseOrder(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionCompositeIndexOnNameAndAge(false)
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 35), f.users)
+	f.saveDocToCollection(f.newUserDoc("Andy", 25), f.users)
+
+	prefix := newIndexKeyBuilder(f).Col(usersColName).Fields(usersNameFieldName, usersAgeFieldName).Build()
+	res, err := f.txn.Datastore().Query(f.ctx, query.Query{Prefix: prefix.ToString(), KeysOnly: true})
+	require.NoError(t, err)
+	entries, err := res.Rest()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	ages := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		key, err := core.NewIndexDataStoreKey(entry.Key)
+		require.NoError(t, err)
+		age, err := core.DecodeIndexFieldValue(key.FieldValues[1], true)
+		require.NoError(t, err)
+		ages = append(ages, age)
+	}
+	assert.Equal(t, []any{int64(25), int64(35), int64(21)}, ages)
+}
+
+func TestUniqueComposite_IfDocsHaveSameValuesForAllFields_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionCompositeIndexOnNameAndAge(true)
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 22), f.users)
+	f.saveDocToCollection(f.newUserDoc("Andy", 21), f.users)
+
+	docJSON, err := json.Marshal(userDoc{Name: "John", Age: 21, Weight: 160.5})
+	require.NoError(f.t, err)
+	doc, err := client.NewDocFromJSON(docJSON)
+	require.NoError(f.t, err)
+
+	err = f.users.Create(f.ctx, doc)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueField)
+}
+
+func TestUniqueComposite_IfAnyFieldIsNil_StoreItWithDocKey(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionCompositeIndexOnNameAndAge(true)
+
+	docJSON, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{Name: "John"})
+	require.NoError(f.t, err)
+
+	doc, err := client.NewDocFromJSON(docJSON)
+	require.NoError(f.t, err)
+
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Fields(usersNameFieldName, usersAgeFieldName).
+		Doc(doc).Unique().Build()
+	require.Len(t, key.FieldValues, 3)
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc.Key().String()), data)
+}
+
+func TestIndexFilter_WithFractionalValueOnIntField_ShouldMatchUnindexedFilter(t *testing.T) {
+	filters := []struct {
+		conditions map[string]any
+		expected   []string
+	}{
+		{map[string]any{"_eq": 20.5}, []string{}},
+		{map[string]any{"_in": []any{20.5, 21.0}}, []string{"Addo"}},
+		{map[string]any{"_ne": 20.5}, []string{"John", "Addo"}},
+		{map[string]any{"_eq": 20.0}, []string{"John"}},
+		{map[string]any{"_in": []any{20.0, 21.0}}, []string{"John", "Addo"}},
+		{map[string]any{"_ne": 20.0}, []string{"Addo"}},
+	}
+
+	for _, schema := range []string{
+		`type User { name: String age: Int @index }`,
+		`type User { name: String age: Int }`,
+	} {
+		for _, filter := range filters {
+			ctx := context.Background()
+			db, err := newMemoryDB(ctx)
+			require.NoError(t, err)
+
+			_, err = db.AddSchema(ctx, schema)
+			require.NoError(t, err)
+			col, err := db.GetCollectionByName(ctx, "User")
+			require.NoError(t, err)
+
+			keysByName := map[string]string{}
+			for _, data := range []string{`{"name": "John", "age": 20}`, `{"name": "Addo", "age": 21}`} {
+				doc, err := client.NewDocFromJSON([]byte(data))
+				require.NoError(t, err)
+				require.NoError(t, col.Create(ctx, doc))
+				name, err := doc.Get("name")
+				require.NoError(t, err)
+				keysByName[name.(string)] = doc.Key().String()
+			}
+
+			res, err := col.UpdateWithFilter(
+				ctx,
+				immutable.Some(request.Filter{Conditions: map[string]any{"age": filter.conditions}}),
+				`{"name": "Updated"}`,
+			)
+			require.NoError(t, err)
+
+			expectedKeys := make([]string, 0, len(filter.expected))
+			for _, name := range filter.expected {
+				expectedKeys = append(expectedKeys, keysByName[name])
+			}
+			assert.ElementsMatch(t, expectedKeys, res.DocKeys, "schema: %s, filter: %v", schema, filter.conditions)
+
+			db.Close()
+		}
+	}
+}
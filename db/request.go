@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/planner"
 )
@@ -60,6 +61,26 @@ func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn
 	return res
 }
 
+// isUpsertRequest returns true if the given request contains an upsert mutation.
+func (db *db) isUpsertRequest(rawRequest string) bool {
+	ast, err := db.parser.BuildRequestAST(rawRequest)
+	if err != nil {
+		return false
+	}
+	parsedRequest, errors := db.parser.Parse(ast)
+	if len(errors) > 0 {
+		return false
+	}
+	for _, operation := range parsedRequest.Mutations {
+		for _, selection := range operation.Selections {
+			if mutation, ok := selection.(*request.ObjectMutation); ok && mutation.Type == request.UpsertObjects {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ExecIntrospection executes an introspection request against the database.
 func (db *db) ExecIntrospection(request string) *client.RequestResult {
 	return db.parser.ExecuteIntrospection(request)
@@ -14,6 +14,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -49,14 +50,16 @@ func (db *db) addSchema(
 		existingDefinitions[i] = existingCollections[i].Definition()
 	}
 
-	newDefinitions, err := db.parser.ParseSDL(ctx, schemaString)
+	newDefinitions, extensions, err := db.parser.ParseSDL(ctx, schemaString, existingDefinitions)
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.parser.SetSchema(ctx, txn, append(existingDefinitions, newDefinitions...))
-	if err != nil {
-		return nil, err
+	if len(extensions) == 0 {
+		err = db.parser.SetSchema(ctx, txn, append(existingDefinitions, newDefinitions...))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	returnDescriptions := make([]client.CollectionDescription, len(newDefinitions))
@@ -68,9 +71,54 @@ func (db *db) addSchema(
 		returnDescriptions[i] = col.Description()
 	}
 
+	if len(extensions) > 0 {
+		// The fields added by type extensions may relate to the new types, so they can only be added
+		// once the new collections exist.  This will also set the schema of the parser.
+		err = db.extendSchema(ctx, txn, extensions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return returnDescriptions, nil
 }
 
+// extendSchema adds the fields of the given type extensions to the existing schemas that they extend.
+//
+// The fields are added via a schema patch, creating a new schema version for each extended schema that
+// will be made default.
+func (db *db) extendSchema(
+	ctx context.Context,
+	txn datastore.Txn,
+	extensions []client.SchemaDescription,
+) error {
+	patch := []map[string]any{}
+	for _, extension := range extensions {
+		exists, err := description.HasCollectionByName(ctx, txn, extension.Name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return NewErrExtendedTypeNotFound(extension.Name)
+		}
+
+		for _, field := range extension.Fields {
+			patch = append(patch, map[string]any{
+				"op":    "add",
+				"path":  fmt.Sprintf("/%s/Fields/-", extension.Name),
+				"value": field,
+			})
+		}
+	}
+
+	patchJson, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	return db.patchSchema(ctx, txn, string(patchJson), true)
+}
+
 func (db *db) loadSchema(ctx context.Context, txn datastore.Txn) error {
 	collections, err := db.getAllCollections(ctx, txn)
 	if err != nil {
@@ -190,7 +238,27 @@ func substituteSchemaPatch(
 		path = strings.TrimPrefix(path, "/")
 		splitPath := strings.Split(path, "/")
 
-		if value, hasValue := patchOperation["value"]; hasValue {
+		value, hasValue := patchOperation["value"]
+		if !hasValue && isFieldOrInner(splitPath) && containsLetter(splitPath[fieldIndexPathIndex]) {
+			// Operations without a value, such as `remove`, may also reference fields by name.
+			desc := schemaByName[splitPath[schemaNamePathIndex]]
+			if i, ok := fieldIndexesBySchema[desc.Name][splitPath[fieldIndexPathIndex]]; ok {
+				splitPath[fieldIndexPathIndex] = fmt.Sprint(i)
+				path = strings.Join(splitPath, "/")
+				opPath := json.RawMessage([]byte(fmt.Sprintf(`"/%s"`, path)))
+				patchOperation["path"] = &opPath
+			}
+		}
+
+		if patchOperation.Kind() == "remove" && isField(splitPath) {
+			// If a field is removed we need to shift the tracked locations of the fields that follow it,
+			// so that subsequent operations within the patch may still access them by field name.
+			if i, err := strconv.Atoi(splitPath[fieldIndexPathIndex]); err == nil {
+				removeFieldIndex(fieldIndexesBySchema[splitPath[schemaNamePathIndex]], i)
+			}
+		}
+
+		if hasValue {
 			var newPatchValue immutable.Option[any]
 			var field map[string]any
 			isField := isField(splitPath)
@@ -274,6 +342,17 @@ func substituteSchemaPatch(
 				}
 			}
 
+			if isFieldName(splitPath) && patchOperation.Kind() != "test" {
+				// If a field is renamed we need to track its new name so that subsequent operations
+				// within the patch may access it by its new name.
+				var name string
+				if err := json.Unmarshal(*value, &name); err == nil {
+					if i, err := strconv.Atoi(splitPath[fieldIndexPathIndex]); err == nil {
+						renameFieldIndex(fieldIndexesBySchema[splitPath[schemaNamePathIndex]], i, name)
+					}
+				}
+			}
+
 			if newPatchValue.HasValue() {
 				substitute, err := json.Marshal(newPatchValue.Value())
 				if err != nil {
@@ -361,6 +440,37 @@ func isField(path []string) bool {
 	return len(path) == 3 && path[fieldsPathIndex] == "Fields"
 }
 
+// isFieldName returns true if the given path points to a FieldDescription.Name property.
+func isFieldName(path []string) bool {
+	return len(path) == 4 &&
+		path[fieldIndexPathIndex+1] == "Name" &&
+		path[fieldsPathIndex] == "Fields"
+}
+
+// removeFieldIndex removes the field at the given index from the given field indexes, shifting
+// the indexes of the fields that follow it.
+func removeFieldIndex(fieldIndexesByName map[string]int, index int) {
+	for name, i := range fieldIndexesByName {
+		if i == index {
+			delete(fieldIndexesByName, name)
+		} else if i > index {
+			fieldIndexesByName[name] = i - 1
+		}
+	}
+}
+
+// renameFieldIndex sets the name of the field at the given index within the given field indexes.
+func renameFieldIndex(fieldIndexesByName map[string]int, index int, name string) {
+	for existingName, i := range fieldIndexesByName {
+		if i == index {
+			delete(fieldIndexesByName, existingName)
+		}
+	}
+	if fieldIndexesByName != nil {
+		fieldIndexesByName[name] = index
+	}
+}
+
 // isField returns true if the given path points to a FieldDescription.Kind property.
 func isFieldKind(path []string) bool {
 	return len(path) == 4 &&
@@ -12,9 +12,14 @@ package db
 
 import (
 	"context"
+	"strconv"
+
+	"github.com/ipfs/go-cid"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/planner"
@@ -30,13 +35,20 @@ func (db *db) checkForClientSubscriptions(r *request.Request) (
 		return nil, nil, nil
 	}
 
-	if !db.events.Updates.HasValue() {
+	if !db.events.Changes.HasValue() {
 		return nil, nil, ErrSubscriptionsNotAllowed
 	}
 
 	s := r.Subscription[0].Selections[0]
 	if subRequest, ok := s.(*request.ObjectSubscription); ok {
-		pub, err := events.NewPublisher(db.events.Updates.Value(), 5)
+		if subRequest.ResumeToken.HasValue() {
+			_, err := parseResumeToken(subRequest.ResumeToken.Value())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		pub, err := events.NewPublisher(db.events.Changes.Value(), 5)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -52,7 +64,25 @@ func (db *db) handleSubscription(
 	pub *events.Publisher[events.Update],
 	r *request.ObjectSubscription,
 ) {
+	var schemaRoot string
+	var replayed uint64
+	if r.ResumeToken.HasValue() {
+		var err error
+		schemaRoot, replayed, err = db.replaySubscription(ctx, pub, r)
+		if err != nil {
+			pub.Publish(client.GQLResult{
+				Errors: []error{err},
+			})
+		}
+	}
+
 	for evt := range pub.Event() {
+		// The publisher was subscribed to the changes channel before they were replayed,
+		// so it may have received changes that have already been yielded.
+		if evt.SchemaRoot == schemaRoot && evt.Sequence <= replayed {
+			continue
+		}
+
 		txn, err := db.NewTxn(ctx, false)
 		if err != nil {
 			log.Error(ctx, err.Error())
@@ -65,6 +95,58 @@ func (db *db) handleSubscription(
 	}
 }
 
+// replaySubscription yields the changes to the subscribed collection that were recorded
+// after the resume token of the given subscription.
+//
+// It returns the schema root of the collection and the sequence of the last change
+// that was replayed.
+func (db *db) replaySubscription(
+	ctx context.Context,
+	pub *events.Publisher[events.Update],
+	r *request.ObjectSubscription,
+) (string, uint64, error) {
+	after, err := parseResumeToken(r.ResumeToken.Value())
+	if err != nil {
+		return "", 0, err
+	}
+
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return "", 0, err
+	}
+	defer txn.Discard(ctx)
+
+	col, err := db.getCollectionByName(ctx, txn, r.Collection)
+	if err != nil {
+		return "", 0, err
+	}
+	schemaRoot := col.Schema().Root
+
+	changes, err := db.getChanges(ctx, txn, schemaRoot, after, 0)
+	if err != nil {
+		return "", 0, err
+	}
+	for _, change := range changes {
+		evt, err := getChangeUpdate(ctx, txn, schemaRoot, change)
+		if err != nil {
+			return "", 0, err
+		}
+		db.handleEvent(ctx, txn, pub, evt, r)
+		after = change.Sequence
+	}
+
+	return schemaRoot, after, nil
+}
+
+// parseResumeToken returns the change log sequence of the given subscription resume token.
+func parseResumeToken(token string) (uint64, error) {
+	sequence, err := strconv.ParseUint(token, 10, 64)
+	if err != nil {
+		return 0, NewErrInvalidResumeToken(token, err)
+	}
+	return sequence, nil
+}
+
 func (db *db) handleEvent(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -72,11 +154,7 @@ func (db *db) handleEvent(
 	evt events.Update,
 	r *request.ObjectSubscription,
 ) {
-	p := planner.New(ctx, db.WithTxn(txn), txn)
-
-	s := r.ToSelect(evt.DocKey, evt.Cid.String())
-
-	result, err := p.RunSubscriptionRequest(ctx, s)
+	result, err := db.getSubscriptionResult(ctx, txn, evt, r)
 	if err != nil {
 		pub.Publish(client.GQLResult{
 			Errors: []error{err},
@@ -90,6 +168,139 @@ func (db *db) handleEvent(
 	}
 
 	pub.Publish(client.GQLResult{
-		Data: result,
+		Data:        result,
+		ResumeToken: strconv.FormatUint(evt.Sequence, 10),
 	})
 }
+
+// getSubscriptionResult returns the documents yielded by the given subscription for the
+// given event.
+//
+// The result will be empty if the event is not of a subscribed type, or if the document
+// does not match the subscription filter.
+func (db *db) getSubscriptionResult(
+	ctx context.Context,
+	txn datastore.Txn,
+	evt events.Update,
+	r *request.ObjectSubscription,
+) ([]map[string]any, error) {
+	eventType, previousCid, err := getSubscriptionEvent(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.HasEvent(eventType) {
+		return nil, nil
+	}
+
+	// A deleted document has no values of its own, so the values it had
+	// before it was deleted are yielded instead.
+	docCid := evt.Cid
+	if eventType == request.DeleteEvent {
+		docCid = previousCid
+	}
+
+	p := planner.New(ctx, db.WithTxn(txn), txn)
+
+	s := r.ToSelect(evt.DocKey, docCid.String())
+
+	result, err := p.RunSubscriptionRequest(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	if r.EventField.HasValue() {
+		eventResult, err := db.getSubscriptionEventResult(ctx, txn, evt, r, eventType, previousCid)
+		if err != nil {
+			return nil, err
+		}
+		eventField := r.EventField.Value()
+		for _, doc := range result {
+			doc[getFieldResultName(eventField.Field)] = eventResult
+		}
+	}
+
+	return result, nil
+}
+
+// getSubscriptionEventResult returns the value of the `_event` metadata field
+// requested by the given subscription.
+func (db *db) getSubscriptionEventResult(
+	ctx context.Context,
+	txn datastore.Txn,
+	evt events.Update,
+	r *request.ObjectSubscription,
+	eventType request.SubscriptionEventType,
+	previousCid cid.Cid,
+) (map[string]any, error) {
+	eventField := r.EventField.Value()
+	eventResult := map[string]any{}
+
+	if eventField.Type.HasValue() {
+		typeField := eventField.Type.Value()
+		eventResult[getFieldResultName(typeField)] = string(eventType)
+	}
+
+	if eventField.Previous.HasValue() {
+		previousField := eventField.Previous.Value()
+		previousKey := getFieldResultName(previousField.Field)
+		eventResult[previousKey] = nil
+
+		if previousCid.Defined() {
+			p := planner.New(ctx, db.WithTxn(txn), txn)
+			previous, err := p.RunSubscriptionRequest(ctx, r.ToPreviousSelect(evt.DocKey, previousCid.String()))
+			if err != nil {
+				return nil, err
+			}
+			if len(previous) > 0 {
+				eventResult[previousKey] = previous[0]
+			}
+		}
+	}
+
+	return eventResult, nil
+}
+
+// getSubscriptionEvent returns the type of the given document event, along with
+// the cid of the document version that preceded it.
+//
+// The returned cid will be undefined if the event created the document.
+func getSubscriptionEvent(evt events.Update) (request.SubscriptionEventType, cid.Cid, error) {
+	var previousCid cid.Cid
+	for _, link := range evt.Block.Links() {
+		if link.Name == core.HEAD {
+			previousCid = link.Cid
+			break
+		}
+	}
+
+	delta, err := crdt.CompositeDAG{}.DeltaDecode(evt.Block)
+	if err != nil {
+		return "", cid.Undef, err
+	}
+	compositeDelta, ok := delta.(*crdt.CompositeDAGDelta)
+	if !ok {
+		return "", cid.Undef, client.NewErrUnexpectedType[*crdt.CompositeDAGDelta]("Delta", delta)
+	}
+
+	switch {
+	case compositeDelta.Status.IsDeleted():
+		return request.DeleteEvent, previousCid, nil
+	case !previousCid.Defined():
+		return request.CreateEvent, previousCid, nil
+	default:
+		return request.UpdateEvent, previousCid, nil
+	}
+}
+
+// getFieldResultName returns the name under which the given field is yielded.
+func getFieldResultName(field request.Field) string {
+	if field.Alias.HasValue() {
+		return field.Alias.Value()
+	}
+	return field.Name
+}
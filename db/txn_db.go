@@ -15,6 +15,9 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/datastore"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v4"
+	"github.com/sourcenetwork/defradb/datastore/memory"
+	"github.com/sourcenetwork/defradb/errors"
 )
 
 var _ client.DB = (*implicitTxnDB)(nil)
@@ -33,26 +36,53 @@ type explicitTxnDB struct {
 }
 
 // ExecRequest executes a request against the database.
+//
+// Requests containing an upsert mutation are retried, up to the maximum number of transaction
+// retries, if their transaction conflicts with a concurrent transaction, so that an upsert that
+// lost the race to create a document updates it instead.
 func (db *implicitTxnDB) ExecRequest(ctx context.Context, request string) *client.RequestResult {
+	var txnErr error
+	for retry := 0; retry < db.MaxTxnRetries(); retry++ {
+		res, err := db.execImplicitTxnRequest(ctx, request)
+		if err == nil {
+			return res
+		}
+		if !isTxnConflict(err) || !db.isUpsertRequest(request) {
+			res.GQL.Errors = []error{err}
+			return res
+		}
+		txnErr = err
+	}
+
+	res := &client.RequestResult{}
+	res.GQL.Errors = []error{client.NewErrMaxTxnRetries(txnErr)}
+	return res
+}
+
+// execImplicitTxnRequest executes the given request within a new transaction, returning
+// any error that occured whilst creating or committing the transaction.
+func (db *implicitTxnDB) execImplicitTxnRequest(
+	ctx context.Context,
+	request string,
+) (*client.RequestResult, error) {
 	txn, err := db.NewTxn(ctx, false)
 	if err != nil {
-		res := &client.RequestResult{}
-		res.GQL.Errors = []error{err}
-		return res
+		return &client.RequestResult{}, err
 	}
 	defer txn.Discard(ctx)
 
 	res := db.execRequest(ctx, request, txn)
 	if len(res.GQL.Errors) > 0 {
-		return res
+		return res, nil
 	}
 
-	if err := txn.Commit(ctx); err != nil {
-		res.GQL.Errors = []error{err}
-		return res
-	}
+	return res, txn.Commit(ctx)
+}
 
-	return res
+// isTxnConflict returns true if the given error was caused by a transaction conflicting
+// with another transaction that was committed before it.
+func isTxnConflict(err error) bool {
+	return errors.Is(err, badgerds.ErrTxnConflict) || errors.Is(err, memory.ErrTxnConflict)
 }
 
 // ExecRequest executes a transaction request against the database.
@@ -267,6 +297,71 @@ func (db *explicitTxnDB) GetAllIndexes(
 	return db.getAllIndexes(ctx, db.txn)
 }
 
+// AddWebhook adds the given webhook, delivering the changes recorded after it was added.
+func (db *implicitTxnDB) AddWebhook(ctx context.Context, webhook client.Webhook) (client.Webhook, error) {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	defer txn.Discard(ctx)
+
+	webhook, err = db.addWebhook(ctx, txn, webhook)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		return client.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+// AddWebhook adds the given webhook, delivering the changes recorded after it was added.
+//
+// Deliveries will only start once the transaction has been committed.
+func (db *explicitTxnDB) AddWebhook(ctx context.Context, webhook client.Webhook) (client.Webhook, error) {
+	return db.addWebhook(ctx, db.txn, webhook)
+}
+
+// DeleteWebhook deletes the webhook with the given ID.
+func (db *implicitTxnDB) DeleteWebhook(ctx context.Context, id uint32) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	err = db.deleteWebhook(ctx, txn, id)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// DeleteWebhook deletes the webhook with the given ID.
+//
+// Deliveries will only stop once the transaction has been committed.
+func (db *explicitTxnDB) DeleteWebhook(ctx context.Context, id uint32) error {
+	return db.deleteWebhook(ctx, db.txn, id)
+}
+
+// GetAllWebhooks gets all the webhooks in the database.
+func (db *implicitTxnDB) GetAllWebhooks(ctx context.Context) ([]client.Webhook, error) {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	return db.getAllWebhooks(ctx, txn)
+}
+
+// GetAllWebhooks gets all the webhooks in the database.
+func (db *explicitTxnDB) GetAllWebhooks(ctx context.Context) ([]client.Webhook, error) {
+	return db.getAllWebhooks(ctx, db.txn)
+}
+
 // AddSchema takes the provided GQL schema in SDL format, and applies it to the database,
 // creating the necessary collections, request types, etc.
 //
@@ -0,0 +1,55 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v4"
+	"github.com/sourcenetwork/defradb/datastore/memory"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+func TestIsTxnConflict_WithConflictOfEitherDatastore_ReturnsTrue(t *testing.T) {
+	assert.True(t, isTxnConflict(badgerds.ErrTxnConflict))
+	assert.True(t, isTxnConflict(memory.ErrTxnConflict))
+	assert.True(t, isTxnConflict(errors.Wrap("commit failed", memory.ErrTxnConflict)))
+	assert.False(t, isTxnConflict(ErrDocumentAlreadyExists))
+}
+
+func TestIsUpsertRequest(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	assert.True(t, db.isUpsertRequest(`mutation {
+		upsert_User(filter: {name: {_eq: "John"}}, create: "{\"name\": \"John\"}", update: "{}") {
+			name
+		}
+	}`))
+	assert.False(t, db.isUpsertRequest(`mutation {
+		create_User(data: "{\"name\": \"John\"}") {
+			name
+		}
+	}`))
+	assert.False(t, db.isUpsertRequest(`query {
+		User {
+			name
+		}
+	}`))
+}
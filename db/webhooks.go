@@ -0,0 +1,534 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+// webhookBatchSize is the maximum number of changes read from the change log at once.
+const webhookBatchSize = 100
+
+var (
+	// webhookTimeout is the time limit for a webhook to respond to a delivery.
+	webhookTimeout = 10 * time.Second
+	// webhookMinBackoff is the time waited before the first retry of a failed delivery.
+	webhookMinBackoff = time.Second
+	// webhookMaxBackoff is the maximum time waited between retries of a failed delivery.
+	webhookMaxBackoff = time.Minute
+)
+
+// webhookWorker delivers the document changes of a collection to a webhook.
+type webhookWorker struct {
+	webhook    client.Webhook
+	schemaRoot string
+
+	// request is the subscription used to filter and select the delivered documents.
+	request *request.ObjectSubscription
+
+	// notify is signalled whenever a change is recorded in the change log of the collection.
+	notify chan struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newWebhookWorker returns a worker for the given webhook.
+//
+// The documents are selected by a subscription to the collection of the webhook, so that the
+// filter of the webhook is applied in the same way as it is to subscriptions.
+func (db *db) newWebhookWorker(
+	ctx context.Context,
+	txn datastore.Txn,
+	webhook client.Webhook,
+) (*webhookWorker, error) {
+	col, err := db.getCollectionByName(ctx, txn, webhook.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{request.KeyFieldName}
+	for _, field := range col.Schema().Fields {
+		if field.IsObject() || field.Name == request.KeyFieldName {
+			continue
+		}
+		fields = append(fields, field.Name)
+	}
+
+	var args string
+	if webhook.Filter != "" {
+		args = fmt.Sprintf("(%s: %s)", request.FilterClause, webhook.Filter)
+	}
+	selections := fmt.Sprintf("%s%s { %s }", col.Name(), args, strings.Join(fields, " "))
+
+	// Subscription arguments are not validated against the schema, so the filter is
+	// validated by parsing the equivalent query first.
+	_, err = db.parseWebhookRequest(webhook, fmt.Sprintf("query { %s }", selections))
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := db.parseWebhookRequest(webhook, fmt.Sprintf("subscription { %s }", selections))
+	if err != nil {
+		return nil, err
+	}
+	// The filter must not be able to change the shape of the request.
+	if len(parsed.Queries) > 0 || len(parsed.Mutations) > 0 || len(parsed.Subscription) != 1 ||
+		len(parsed.Subscription[0].Selections) != 1 {
+		return nil, NewErrInvalidWebhookFilter(webhook.Filter, nil)
+	}
+	r, ok := parsed.Subscription[0].Selections[0].(*request.ObjectSubscription)
+	if !ok {
+		return nil, NewErrInvalidWebhookFilter(webhook.Filter, nil)
+	}
+
+	return &webhookWorker{
+		webhook:    webhook,
+		schemaRoot: col.Schema().Root,
+		request:    r,
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}, nil
+}
+
+func (db *db) parseWebhookRequest(webhook client.Webhook, req string) (*request.Request, error) {
+	ast, err := db.parser.BuildRequestAST(req)
+	if err != nil {
+		return nil, NewErrInvalidWebhookFilter(webhook.Filter, err)
+	}
+	parsed, errs := db.parser.Parse(ast)
+	if len(errs) > 0 {
+		return nil, NewErrInvalidWebhookFilter(webhook.Filter, errs[0])
+	}
+	return parsed, nil
+}
+
+// startWebhooks starts delivering changes to all persisted webhooks, and to any webhooks
+// added later.
+func (db *db) startWebhooks(ctx context.Context) error {
+	db.webhooks = make(map[uint32]*webhookWorker)
+
+	sub, err := db.events.Changes.Value().Subscribe()
+	if err != nil {
+		return err
+	}
+	go db.handleWebhookLoop(sub)
+
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	webhooks, err := db.getAllWebhooks(ctx, txn)
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		worker, err := db.newWebhookWorker(ctx, txn, webhook)
+		if err != nil {
+			// A webhook may no longer be valid for the current schema, this should not prevent
+			// the database or any other webhook from starting.
+			log.ErrorE(ctx, "Failed to start webhook", err, logging.NewKV("ID", webhook.ID))
+			continue
+		}
+		db.startWebhook(worker)
+	}
+
+	return nil
+}
+
+// handleWebhookLoop notifies the webhook workers of the changes recorded in the change log
+// of their collections.
+//
+// It never blocks on a worker, so that slow webhooks cannot hold up the event bus.
+func (db *db) handleWebhookLoop(sub events.Subscription[events.Update]) {
+	for evt := range sub {
+		db.webhooksLock.Lock()
+		for _, worker := range db.webhooks {
+			if worker.schemaRoot != evt.SchemaRoot {
+				continue
+			}
+			select {
+			case worker.notify <- struct{}{}:
+			default:
+				// the worker has already been notified
+			}
+		}
+		db.webhooksLock.Unlock()
+	}
+}
+
+func (db *db) startWebhook(worker *webhookWorker) {
+	ctx, cancel := context.WithCancel(context.Background())
+	worker.cancel = cancel
+
+	db.webhooksLock.Lock()
+	db.webhooks[worker.webhook.ID] = worker
+	db.webhooksLock.Unlock()
+
+	go db.runWebhook(ctx, worker)
+}
+
+// stopWebhook stops delivering changes to the webhook with the given ID, waiting for
+// any delivery in progress to be abandoned.
+func (db *db) stopWebhook(id uint32) {
+	db.webhooksLock.Lock()
+	worker, ok := db.webhooks[id]
+	delete(db.webhooks, id)
+	db.webhooksLock.Unlock()
+
+	if ok {
+		worker.cancel()
+		<-worker.done
+	}
+}
+
+// stopWebhooks stops delivering changes to all webhooks.
+func (db *db) stopWebhooks() {
+	db.webhooksLock.Lock()
+	ids := make([]uint32, 0, len(db.webhooks))
+	for id := range db.webhooks {
+		ids = append(ids, id)
+	}
+	db.webhooksLock.Unlock()
+
+	for _, id := range ids {
+		db.stopWebhook(id)
+	}
+}
+
+// runWebhook delivers the changes recorded in the change log to the given webhook until
+// it is stopped.
+func (db *db) runWebhook(ctx context.Context, worker *webhookWorker) {
+	defer close(worker.done)
+
+	for {
+		more, err := db.deliverWebhookChanges(ctx, worker)
+		if ctx.Err() != nil {
+			return
+		}
+
+		var retry <-chan time.Time
+		switch {
+		case err != nil:
+			log.ErrorE(ctx, "Failed to deliver webhook changes", err, logging.NewKV("ID", worker.webhook.ID))
+			retry = time.After(webhookMaxBackoff)
+		case more:
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-worker.notify:
+		case <-retry:
+		}
+	}
+}
+
+// deliverWebhookChanges delivers the next batch of changes after the cursor of the given
+// webhook, returning true if there may be more changes to deliver.
+func (db *db) deliverWebhookChanges(ctx context.Context, worker *webhookWorker) (bool, error) {
+	changes, err := db.getWebhookChanges(ctx, worker)
+	if err != nil {
+		return false, err
+	}
+
+	for _, change := range changes {
+		docs, err := db.getWebhookDocs(ctx, worker, change)
+		if err != nil {
+			return false, err
+		}
+		for _, doc := range docs {
+			err := postWebhookEvent(ctx, worker.webhook, client.WebhookEvent{
+				Webhook:    worker.webhook.ID,
+				Collection: worker.webhook.Collection,
+				Change:     change,
+				Data:       doc,
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+
+		err = db.setWebhookCursor(ctx, worker.webhook.ID, change.Sequence)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return len(changes) == webhookBatchSize, nil
+}
+
+// getWebhookChanges returns the next batch of changes after the cursor of the given webhook.
+func (db *db) getWebhookChanges(ctx context.Context, worker *webhookWorker) ([]client.Change, error) {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	cursor, err := getWebhookCursor(ctx, txn, worker.webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+	return db.getChanges(ctx, txn, worker.schemaRoot, cursor, webhookBatchSize)
+}
+
+// getWebhookDocs returns the documents of the given change that are to be delivered to
+// the given webhook.
+func (db *db) getWebhookDocs(
+	ctx context.Context,
+	worker *webhookWorker,
+	change client.Change,
+) ([]map[string]any, error) {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	evt, err := getChangeUpdate(ctx, txn, worker.schemaRoot, change)
+	if err != nil {
+		return nil, err
+	}
+	return db.getSubscriptionResult(ctx, txn, evt, worker.request)
+}
+
+// postWebhookEvent POSTs the given event to the given webhook, retrying with an exponential
+// backoff until it is accepted or the context is cancelled.
+func postWebhookEvent(ctx context.Context, webhook client.Webhook, event client.WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	backoff := webhookMinBackoff
+	for {
+		err := postWebhook(ctx, webhook.URL, body)
+		if err == nil {
+			return nil
+		}
+		log.ErrorE(
+			ctx,
+			"Failed to deliver webhook event",
+			err,
+			logging.NewKV("ID", webhook.ID),
+			logging.NewKV("Sequence", event.Sequence),
+			logging.NewKV("Backoff", backoff),
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > webhookMaxBackoff {
+			backoff = webhookMaxBackoff
+		}
+	}
+}
+
+func postWebhook(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() //nolint:errcheck
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return NewErrWebhookResponse(url, res.StatusCode)
+	}
+	return nil
+}
+
+// getWebhookCursor returns the sequence of the last change delivered to the webhook
+// with the given ID.
+func getWebhookCursor(ctx context.Context, txn datastore.Txn, id uint32) (uint64, error) {
+	val, err := txn.Systemstore().Get(ctx, core.NewWebhookCursorKey(id).ToDS())
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func putWebhookCursor(ctx context.Context, txn datastore.Txn, id uint32, sequence uint64) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], sequence)
+	return txn.Systemstore().Put(ctx, core.NewWebhookCursorKey(id).ToDS(), buf[:])
+}
+
+// setWebhookCursor persists the sequence of the last change delivered to the webhook with
+// the given ID, unless the webhook has since been deleted.
+func (db *db) setWebhookCursor(ctx context.Context, id uint32, sequence uint64) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	exists, err := txn.Systemstore().Has(ctx, core.NewWebhookKey(id).ToDS())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	err = putWebhookCursor(ctx, txn, id, sequence)
+	if err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+func (db *db) addWebhook(ctx context.Context, txn datastore.Txn, webhook client.Webhook) (client.Webhook, error) {
+	if !db.events.Changes.HasValue() {
+		return client.Webhook{}, ErrWebhooksNotAllowed
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return client.Webhook{}, NewErrInvalidWebhookURL(webhook.URL)
+	}
+
+	worker, err := db.newWebhookWorker(ctx, txn, webhook)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+
+	seq, err := db.getSequence(ctx, txn, core.WEBHOOK)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	id, err := seq.next(ctx, txn)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	webhook.ID = uint32(id)
+	worker.webhook = webhook
+
+	// Only the changes recorded after the webhook was added are delivered to it.
+	changeSeq, err := db.getSequence(ctx, txn, fmt.Sprintf("%s/%s", core.CHANGE_LOG, worker.schemaRoot))
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	err = putWebhookCursor(ctx, txn, webhook.ID, changeSeq.val)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+
+	buf, err := json.Marshal(webhook)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	err = txn.Systemstore().Put(ctx, core.NewWebhookKey(webhook.ID).ToDS(), buf)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+
+	txn.OnSuccess(func() {
+		db.startWebhook(worker)
+	})
+
+	return webhook, nil
+}
+
+func (db *db) deleteWebhook(ctx context.Context, txn datastore.Txn, id uint32) error {
+	key := core.NewWebhookKey(id)
+	exists, err := txn.Systemstore().Has(ctx, key.ToDS())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NewErrWebhookNotFound(id)
+	}
+
+	err = txn.Systemstore().Delete(ctx, key.ToDS())
+	if err != nil {
+		return err
+	}
+	err = txn.Systemstore().Delete(ctx, core.NewWebhookCursorKey(id).ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+
+	txn.OnSuccess(func() {
+		db.stopWebhook(id)
+	})
+
+	return nil
+}
+
+func (db *db) getAllWebhooks(ctx context.Context, txn datastore.Txn) ([]client.Webhook, error) {
+	q, err := txn.Systemstore().Query(ctx, query.Query{
+		Prefix: core.NewWebhookKey(0).ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := q.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close webhook query", err)
+		}
+	}()
+
+	webhooks := []client.Webhook{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		var webhook client.Webhook
+		err = json.Unmarshal(res.Value, &webhook)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
@@ -0,0 +1,278 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	badger "github.com/sourcenetwork/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v4"
+)
+
+// newWebhookServer returns a server that yields the events POSTed to it, failing the
+// given number of deliveries first.
+func newWebhookServer(t *testing.T, failures int32) (*httptest.Server, chan client.WebhookEvent) {
+	events := make(chan client.WebhookEvent, 10)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) <= failures {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var event client.WebhookEvent
+		if err := json.NewDecoder(req.Body).Decode(&event); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		events <- event
+	}))
+	t.Cleanup(server.Close)
+	return server, events
+}
+
+func receiveWebhookEvent(t *testing.T, events chan client.WebhookEvent) client.WebhookEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for webhook event")
+		return client.WebhookEvent{}
+	}
+}
+
+func assertNoWebhookEvent(t *testing.T, events chan client.WebhookEvent) {
+	select {
+	case event := <-events:
+		assert.Fail(t, "unexpected webhook event", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func createWebhookTestDoc(ctx context.Context, t *testing.T, col client.Collection, data string) *client.Document {
+	doc, err := client.NewDocFromJSON([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+	return doc
+}
+
+func TestWebhook_WithFilter_DeliversMatchingChanges(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String age: Int }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	// changes made before the webhook is added are not delivered
+	createWebhookTestDoc(ctx, t, col, `{"name": "Fred", "age": 40}`)
+
+	server, events := newWebhookServer(t, 0)
+	webhook, err := db.AddWebhook(ctx, client.Webhook{
+		Collection: "User",
+		URL:        server.URL,
+		Filter:     `{age: {_gt: 21}}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), webhook.ID)
+
+	createWebhookTestDoc(ctx, t, col, `{"name": "Shahzad", "age": 20}`)
+	doc := createWebhookTestDoc(ctx, t, col, `{"name": "John", "age": 27}`)
+	_, err = col.Delete(ctx, doc.Key())
+	require.NoError(t, err)
+
+	event := receiveWebhookEvent(t, events)
+	assert.Equal(t, uint32(1), event.Webhook)
+	assert.Equal(t, "User", event.Collection)
+	assert.Equal(t, uint64(3), event.Sequence)
+	assert.Equal(t, "CREATE", event.Type)
+	assert.Equal(t, doc.Key().String(), event.DocKey)
+	assert.Equal(t, map[string]any{"_key": doc.Key().String(), "name": "John", "age": float64(27)}, event.Data)
+
+	event = receiveWebhookEvent(t, events)
+	assert.Equal(t, uint64(4), event.Sequence)
+	assert.Equal(t, "DELETE", event.Type)
+	assert.Equal(t, "John", event.Data["name"])
+
+	assertNoWebhookEvent(t, events)
+}
+
+func TestWebhook_WithFailedDelivery_Retries(t *testing.T) {
+	minBackoff := webhookMinBackoff
+	webhookMinBackoff = time.Millisecond
+	t.Cleanup(func() { webhookMinBackoff = minBackoff })
+
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	server, events := newWebhookServer(t, 3)
+	_, err = db.AddWebhook(ctx, client.Webhook{Collection: "User", URL: server.URL})
+	require.NoError(t, err)
+
+	createWebhookTestDoc(ctx, t, col, `{"name": "John"}`)
+	createWebhookTestDoc(ctx, t, col, `{"name": "Addo"}`)
+
+	event := receiveWebhookEvent(t, events)
+	assert.Equal(t, uint64(1), event.Sequence)
+	assert.Equal(t, "John", event.Data["name"])
+
+	event = receiveWebhookEvent(t, events)
+	assert.Equal(t, uint64(2), event.Sequence)
+	assert.Equal(t, "Addo", event.Data["name"])
+}
+
+func TestWebhook_AfterRestart_ResumesDelivery(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	openDB := func() *implicitTxnDB {
+		opts := badgerds.Options{Options: badger.DefaultOptions(dir)}
+		rootstore, err := badgerds.NewDatastore(dir, &opts)
+		require.NoError(t, err)
+		db, err := newDB(ctx, rootstore, WithUpdateEvents())
+		require.NoError(t, err)
+		return db
+	}
+
+	db := openDB()
+	_, err := db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	server, events := newWebhookServer(t, 0)
+	_, err = db.AddWebhook(ctx, client.Webhook{Collection: "User", URL: server.URL})
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	createWebhookTestDoc(ctx, t, col, `{"name": "John"}`)
+	assert.Equal(t, uint64(1), receiveWebhookEvent(t, events).Sequence)
+	// the event may be redelivered if the webhook is stopped before its cursor is persisted
+	require.Eventually(t, func() bool {
+		txn, err := db.NewTxn(ctx, true)
+		require.NoError(t, err)
+		defer txn.Discard(ctx)
+		cursor, err := getWebhookCursor(ctx, txn, 1)
+		require.NoError(t, err)
+		return cursor == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// changes made whilst the webhook is stopped are delivered once it has restarted
+	db.stopWebhooks()
+	createWebhookTestDoc(ctx, t, col, `{"name": "Addo"}`)
+	db.Close()
+
+	db = openDB()
+	defer db.Close()
+
+	event := receiveWebhookEvent(t, events)
+	assert.Equal(t, uint64(2), event.Sequence)
+	assert.Equal(t, "Addo", event.Data["name"])
+	assertNoWebhookEvent(t, events)
+}
+
+func TestDeleteWebhook_StopsDelivery(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	server, events := newWebhookServer(t, 0)
+	webhook, err := db.AddWebhook(ctx, client.Webhook{Collection: "User", URL: server.URL})
+	require.NoError(t, err)
+
+	webhooks, err := db.GetAllWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Webhook{webhook}, webhooks)
+
+	require.NoError(t, db.DeleteWebhook(ctx, webhook.ID))
+	createWebhookTestDoc(ctx, t, col, `{"name": "John"}`)
+	assertNoWebhookEvent(t, events)
+
+	webhooks, err = db.GetAllWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	err = db.DeleteWebhook(ctx, webhook.ID)
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
+
+func TestAddWebhook_WithInvalidFilter_Errors(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	_, err = db.AddWebhook(ctx, client.Webhook{
+		Collection: "User",
+		URL:        "http://localhost:9000",
+		Filter:     `{unknown: {_eq: 1}}`,
+	})
+	assert.ErrorIs(t, err, ErrInvalidWebhookFilter)
+
+	_, err = db.AddWebhook(ctx, client.Webhook{
+		Collection: "User",
+		URL:        "http://localhost:9000",
+		Filter:     `{name: {_eq: "John"}}) { name } } mutation { delete_User { _key }`,
+	})
+	assert.ErrorIs(t, err, ErrInvalidWebhookFilter)
+}
+
+func TestAddWebhook_WithInvalidURL_Errors(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDBWithUpdateEvents(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	_, err = db.AddWebhook(ctx, client.Webhook{Collection: "User", URL: "localhost:9000"})
+	assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+}
+
+func TestAddWebhook_WithoutUpdateEvents_Errors(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.AddSchema(ctx, `type User { name: String }`)
+	require.NoError(t, err)
+
+	_, err = db.AddWebhook(ctx, client.Webhook{Collection: "User", URL: "http://localhost:9000"})
+	assert.ErrorIs(t, err, ErrWebhooksNotAllowed)
+}
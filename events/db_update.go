@@ -30,4 +30,9 @@ type Update struct {
 	SchemaRoot string
 	Block      ipld.Node
 	Priority   uint64
+
+	// Sequence is the position of the update within the change log of its collection.
+	//
+	// It is only set on updates published to the `Changes` channel.
+	Sequence uint64
 }
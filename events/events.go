@@ -49,4 +49,12 @@ func New[T any](commandBufferSize int, eventBufferSize int) Channel[T] {
 type Events struct {
 	// Updates publishes an `Update` for each document written to in the database.
 	Updates UpdateChannel
+
+	// Changes publishes each `Update` once it has been recorded in the change log of
+	// its collection, with its `Sequence` set.
+	Changes UpdateChannel
+
+	// Migrations publishes a `Migration` for each migration set in the database, once the
+	// transaction setting it has been committed.
+	Migrations MigrationChannel
 }
@@ -0,0 +1,26 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package events
+
+import "github.com/sourcenetwork/immutable"
+
+// MigrationChannel is the bus onto which set migrations are published.
+type MigrationChannel = immutable.Option[Channel[Migration]]
+
+// EmptyMigrationChannel is an empty MigrationChannel.
+var EmptyMigrationChannel = immutable.None[Channel[Migration]]()
+
+// Migration represents a migration that has been set in the database.
+type Migration struct {
+	// SourceSchemaVersionID is the ID of the schema version that the migration
+	// migrates from.
+	SourceSchemaVersionID string
+}
@@ -10,7 +10,10 @@
 
 package events
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // time limit we set for the client to read after publishing.
 var clientTimeout = 60 * time.Second
@@ -22,6 +25,13 @@ type Publisher[T any] struct {
 	ch     Channel[T]
 	event  Subscription[T]
 	stream chan any
+
+	// done is closed once the publisher is unsubscribed.
+	done chan struct{}
+	// streamLock is held for reading whilst publishing, so that the stream is only
+	// closed once no data is being sent to it.
+	streamLock      sync.RWMutex
+	unsubscribeOnce sync.Once
 }
 
 // NewPublisher creates a new Publisher with the given event Channel, subscribes to the
@@ -36,6 +46,7 @@ func NewPublisher[T any](ch Channel[T], streamBufferSize int) (*Publisher[T], er
 		ch:     ch,
 		event:  evtCh,
 		stream: make(chan any, streamBufferSize),
+		done:   make(chan struct{}),
 	}, nil
 }
 
@@ -51,18 +62,48 @@ func (p *Publisher[T]) Stream() chan any {
 
 // Publish sends data to the streaming channel and unsubscribes if
 // the client hangs for too long.
+//
+// Data published after the publisher has been unsubscribed is dropped.
 func (p *Publisher[T]) Publish(data any) {
-	select {
-	case p.stream <- data:
-	case <-time.After(clientTimeout):
+	if !p.publish(data) {
 		// if sending to the client times out, we assume an inactive or problematic client and
 		// unsubscribe them from the event stream
 		p.Unsubscribe()
 	}
 }
 
+// publish sends data to the streaming channel, returning false if the client
+// did not read it in time.
+func (p *Publisher[T]) publish(data any) bool {
+	p.streamLock.RLock()
+	defer p.streamLock.RUnlock()
+
+	select {
+	case <-p.done:
+		return true
+	default:
+	}
+
+	select {
+	case <-p.done:
+		return true
+	case p.stream <- data:
+		return true
+	case <-time.After(clientTimeout):
+		return false
+	}
+}
+
 // Unsubscribe unsubscribes the client for the event channel and closes the stream.
+//
+// It may be called concurrently with Publish, and more than once.
 func (p *Publisher[T]) Unsubscribe() {
-	p.ch.Unsubscribe(p.event)
-	close(p.stream)
+	p.unsubscribeOnce.Do(func() {
+		close(p.done)
+		p.ch.Unsubscribe(p.event)
+
+		p.streamLock.Lock()
+		defer p.streamLock.Unlock()
+		close(p.stream)
+	})
 }
@@ -79,3 +79,41 @@ func TestPublisherToStreamWithTimeout(t *testing.T) {
 func startEventChanel() Channel[int] {
 	return New[int](0, 0)
 }
+
+func TestPublisherUnsubscribe_WhilePublishing(t *testing.T) {
+	ch := startEventChanel()
+
+	pub, err := NewPublisher(ch, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	published := make(chan struct{})
+	go func() {
+		// nothing reads the stream, so this blocks until the publisher is unsubscribed
+		pub.Publish(10)
+		close(published)
+	}()
+
+	pub.Unsubscribe()
+	<-published
+
+	_, open := <-pub.Stream()
+	assert.Equal(t, false, open)
+}
+
+func TestPublisherUnsubscribe_Twice(t *testing.T) {
+	ch := startEventChanel()
+
+	pub, err := NewPublisher(ch, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub.Unsubscribe()
+	pub.Unsubscribe()
+	pub.Publish(10)
+
+	_, open := <-pub.Stream()
+	assert.Equal(t, false, open)
+}
@@ -17,6 +17,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
@@ -315,6 +316,49 @@ func (c *Client) GetAllIndexes(ctx context.Context) (map[client.CollectionName][
 	return indexes, nil
 }
 
+func (c *Client) AddWebhook(ctx context.Context, webhook client.Webhook) (client.Webhook, error) {
+	methodURL := c.http.baseURL.JoinPath("webhooks")
+
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	var res client.Webhook
+	if err := c.http.requestJson(req, &res); err != nil {
+		return client.Webhook{}, err
+	}
+	return res, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id uint32) error {
+	methodURL := c.http.baseURL.JoinPath("webhooks", strconv.FormatUint(uint64(id), 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, methodURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.http.request(req)
+	return err
+}
+
+func (c *Client) GetAllWebhooks(ctx context.Context) ([]client.Webhook, error) {
+	methodURL := c.http.baseURL.JoinPath("webhooks")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, methodURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []client.Webhook
+	if err := c.http.requestJson(req, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
 func (c *Client) ExecRequest(ctx context.Context, query string) *client.RequestResult {
 	methodURL := c.http.baseURL.JoinPath("graphql")
 	result := &client.RequestResult{}
@@ -384,8 +428,9 @@ func (c *Client) execRequestSubscription(ctx context.Context, r io.ReadCloser) *
 				return
 			}
 			pub.Publish(client.GQLResult{
-				Errors: response.Errors,
-				Data:   response.Data,
+				Errors:      response.Errors,
+				Data:        response.Data,
+				ResumeToken: evt.ID,
 			})
 		}
 	}()
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	sse "github.com/vito/go-sse/sse"
@@ -244,6 +245,51 @@ func (c *Collection) UpdateWithKeys(
 	})
 }
 
+func (c *Collection) UpsertWithFilter(
+	ctx context.Context,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	methodURL := c.http.baseURL.JoinPath("collections", c.Description().Name)
+
+	// We must call this here, else the doc key on the given object will not match
+	// that of the document saved in the database
+	err := doc.RemapAliasFieldsAndDockey(c.Schema().Fields)
+	if err != nil {
+		return nil, err
+	}
+	docJSON, err := doc.String()
+	if err != nil {
+		return nil, err
+	}
+	var docMap map[string]any
+	if err := json.Unmarshal([]byte(docJSON), &docMap); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(CollectionUpsertRequest{
+		Filter:  filter,
+		Create:  docMap,
+		Updater: updater,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	var result client.UpsertResult
+	if err := c.http.requestJson(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Created {
+		doc.Clean()
+	}
+	return &result, nil
+}
+
 func (c *Collection) DeleteWith(ctx context.Context, target any) (*client.DeleteResult, error) {
 	switch t := target.(type) {
 	case string, map[string]any, *request.Filter:
@@ -426,3 +472,22 @@ func (c *Collection) GetIndexes(ctx context.Context) ([]client.IndexDescription,
 	}
 	return c.Description().Indexes, nil
 }
+
+func (c *Collection) GetChanges(ctx context.Context, after uint64, limit uint64) ([]client.Change, error) {
+	methodURL := c.http.baseURL.JoinPath("collections", c.Description().Name, "changes")
+
+	query := url.Values{}
+	query.Set("after", strconv.FormatUint(after, 10))
+	query.Set("limit", strconv.FormatUint(limit, 10))
+	methodURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, methodURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var changes []client.Change
+	if err := c.http.requestJson(req, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -39,7 +39,7 @@ func NewHandler(db client.DB, opts ServerOptions) (*Handler, error) {
 	txs := &sync.Map{}
 
 	tx_handler := &txHandler{}
-	store_handler := &storeHandler{}
+	store_handler := &storeHandler{allowedOrigins: opts.AllowedOrigins}
 	collection_handler := &collectionHandler{}
 	p2p_handler := &p2pHandler{}
 	lens_handler := &lensHandler{}
@@ -38,6 +38,12 @@ type CollectionUpdateRequest struct {
 	Updater string   `json:"updater"`
 }
 
+type CollectionUpsertRequest struct {
+	Filter  any            `json:"filter"`
+	Create  map[string]any `json:"create"`
+	Updater string         `json:"updater"`
+}
+
 func (s *collectionHandler) Create(rw http.ResponseWriter, req *http.Request) {
 	col := req.Context().Value(colContextKey).(client.Collection)
 
@@ -184,6 +190,32 @@ func (s *collectionHandler) UpdateWith(rw http.ResponseWriter, req *http.Request
 	}
 }
 
+func (s *collectionHandler) UpsertWith(rw http.ResponseWriter, req *http.Request) {
+	col := req.Context().Value(colContextKey).(client.Collection)
+
+	var request CollectionUpsertRequest
+	if err := requestJSON(req, &request); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	if request.Filter == nil || request.Create == nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrInvalidRequestBody})
+		return
+	}
+
+	doc, err := client.NewDocFromMap(request.Create)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	result, err := col.UpsertWithFilter(req.Context(), request.Filter, doc, request.Updater)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, result)
+}
+
 func (s *collectionHandler) Update(rw http.ResponseWriter, req *http.Request) {
 	col := req.Context().Value(colContextKey).(client.Collection)
 
@@ -333,6 +365,34 @@ func (s *collectionHandler) DropIndex(rw http.ResponseWriter, req *http.Request)
 	rw.WriteHeader(http.StatusOK)
 }
 
+func (s *collectionHandler) GetChanges(rw http.ResponseWriter, req *http.Request) {
+	col := req.Context().Value(colContextKey).(client.Collection)
+
+	var after, limit uint64
+	var err error
+	if req.URL.Query().Has("after") {
+		after, err = strconv.ParseUint(req.URL.Query().Get("after"), 10, 64)
+		if err != nil {
+			responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+			return
+		}
+	}
+	if req.URL.Query().Has("limit") {
+		limit, err = strconv.ParseUint(req.URL.Query().Get("limit"), 10, 64)
+		if err != nil {
+			responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+			return
+		}
+	}
+
+	changes, err := col.GetChanges(req.Context(), after, limit)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, changes)
+}
+
 func (h *collectionHandler) bindRoutes(router *Router) {
 	errorResponse := &openapi3.ResponseRef{
 		Ref: "#/components/responses/error",
@@ -346,6 +406,12 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	updateResultSchema := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/update_result",
 	}
+	collectionUpsertSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/collection_upsert",
+	}
+	upsertResultSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/upsert_result",
+	}
 	collectionDeleteSchema := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/collection_delete",
 	}
@@ -358,6 +424,9 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	indexSchema := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/index",
 	}
+	changeSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/change",
+	}
 
 	collectionNamePathParam := openapi3.NewPathParameter("name").
 		WithDescription("Collection name").
@@ -408,6 +477,25 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	collectionUpdateWith.AddResponse(200, collectionUpdateWithResponse)
 	collectionUpdateWith.Responses["400"] = errorResponse
 
+	collectionUpsertWithRequest := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithJSONSchemaRef(collectionUpsertSchema))
+
+	collectionUpsertWithResponse := openapi3.NewResponse().
+		WithDescription("Upsert results").
+		WithJSONSchemaRef(upsertResultSchema)
+
+	collectionUpsertWith := openapi3.NewOperation()
+	collectionUpsertWith.OperationID = "collection_upsert_with"
+	collectionUpsertWith.Description = "Update document(s) matching a filter or create a document if none match"
+	collectionUpsertWith.Tags = []string{"collection"}
+	collectionUpsertWith.AddParameter(collectionNamePathParam)
+	collectionUpsertWith.RequestBody = &openapi3.RequestBodyRef{
+		Value: collectionUpsertWithRequest,
+	}
+	collectionUpsertWith.AddResponse(200, collectionUpsertWithResponse)
+	collectionUpsertWith.Responses["400"] = errorResponse
+
 	collectionDeleteWithRequest := openapi3.NewRequestBody().
 		WithRequired(true).
 		WithContent(openapi3.NewContentWithJSONSchemaRef(collectionDeleteSchema))
@@ -460,6 +548,30 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	getIndexes.AddResponse(200, getIndexesResponse)
 	getIndexes.Responses["400"] = errorResponse
 
+	changeArraySchema := openapi3.NewArraySchema()
+	changeArraySchema.Items = changeSchema
+
+	changeAfterQueryParam := openapi3.NewQueryParameter("after").
+		WithDescription("Sequence of the change to list changes after").
+		WithSchema(openapi3.NewInt64Schema())
+	changeLimitQueryParam := openapi3.NewQueryParameter("limit").
+		WithDescription("Maximum number of changes to list").
+		WithSchema(openapi3.NewInt64Schema())
+
+	getChangesResponse := openapi3.NewResponse().
+		WithDescription("List of changes ordered by sequence").
+		WithJSONSchema(changeArraySchema)
+
+	getChanges := openapi3.NewOperation()
+	getChanges.OperationID = "collection_changes"
+	getChanges.Description = "List the changes recorded in the change log of a collection"
+	getChanges.Tags = []string{"collection"}
+	getChanges.AddParameter(collectionNamePathParam)
+	getChanges.AddParameter(changeAfterQueryParam)
+	getChanges.AddParameter(changeLimitQueryParam)
+	getChanges.AddResponse(200, getChangesResponse)
+	getChanges.Responses["400"] = errorResponse
+
 	indexPathParam := openapi3.NewPathParameter("index").
 		WithRequired(true).
 		WithSchema(openapi3.NewStringSchema())
@@ -523,10 +635,12 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	router.AddRoute("/collections/{name}", http.MethodGet, collectionKeys, h.GetAllDocKeys)
 	router.AddRoute("/collections/{name}", http.MethodPost, collectionCreate, h.Create)
 	router.AddRoute("/collections/{name}", http.MethodPatch, collectionUpdateWith, h.UpdateWith)
+	router.AddRoute("/collections/{name}", http.MethodPut, collectionUpsertWith, h.UpsertWith)
 	router.AddRoute("/collections/{name}", http.MethodDelete, collectionDeleteWith, h.DeleteWith)
 	router.AddRoute("/collections/{name}/indexes", http.MethodPost, createIndex, h.CreateIndex)
 	router.AddRoute("/collections/{name}/indexes", http.MethodGet, getIndexes, h.GetIndexes)
 	router.AddRoute("/collections/{name}/indexes/{index}", http.MethodDelete, dropIndex, h.DropIndex)
+	router.AddRoute("/collections/{name}/changes", http.MethodGet, getChanges, h.GetChanges)
 	router.AddRoute("/collections/{name}/{key}", http.MethodGet, collectionGet, h.Get)
 	router.AddRoute("/collections/{name}/{key}", http.MethodPatch, collectionUpdate, h.Update)
 	router.AddRoute("/collections/{name}/{key}", http.MethodDelete, collectionDelete, h.Delete)
@@ -0,0 +1,371 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// graphQLTransportWSProtocol is the websocket subprotocol used to execute GraphQL requests.
+//
+// https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const graphQLTransportWSProtocol = "graphql-transport-ws"
+
+// wsConnectionInitTimeout is the time a client has to initialise the connection
+// before it is closed.
+var wsConnectionInitTimeout = 3 * time.Second
+
+// wsWriteTimeout is the time limit for writing a message to the connection.
+var wsWriteTimeout = 10 * time.Second
+
+// Message types of the graphql-transport-ws protocol.
+const (
+	wsConnectionInit = "connection_init"
+	wsConnectionAck  = "connection_ack"
+	wsPing           = "ping"
+	wsPong           = "pong"
+	wsSubscribe      = "subscribe"
+	wsNext           = "next"
+	wsError          = "error"
+	wsComplete       = "complete"
+)
+
+// Close codes of the graphql-transport-ws protocol.
+const (
+	wsCloseBadRequest            = 4400
+	wsCloseUnauthorized          = 4401
+	wsCloseSubprotocolNotAllowed = 4406
+	wsCloseInitTimeout           = 4408
+	wsCloseSubscriberExists      = 4409
+	wsCloseTooManyInitRequests   = 4429
+)
+
+// GraphQLWSMessage is a message of the graphql-transport-ws protocol.
+type GraphQLWSMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// GraphQLWSError is a GraphQL error as sent over the graphql-transport-ws protocol.
+type GraphQLWSError struct {
+	Message string `json:"message"`
+}
+
+// GraphQLWSResult is the payload of a next message.
+type GraphQLWSResult struct {
+	Data       any              `json:"data"`
+	Errors     []GraphQLWSError `json:"errors,omitempty"`
+	Extensions map[string]any   `json:"extensions,omitempty"`
+}
+
+func newGraphQLWSErrors(errs []error) []GraphQLWSError {
+	var out []GraphQLWSError
+	for _, err := range errs {
+		out = append(out, GraphQLWSError{Message: err.Error()})
+	}
+	return out
+}
+
+func newGraphQLWSResult(result client.GQLResult) GraphQLWSResult {
+	res := GraphQLWSResult{
+		Data:   result.Data,
+		Errors: newGraphQLWSErrors(result.Errors),
+	}
+	// the resume token is sent as an extension so that it
+	// can be used to resume the subscription on reconnect
+	if result.ResumeToken != "" {
+		res.Extensions = map[string]any{"resumeToken": result.ResumeToken}
+	}
+	return res
+}
+
+// newWebSocketUpgrader returns an upgrader that accepts the graphql-transport-ws protocol
+// from the same origin or any of the allowed origins.
+func newWebSocketUpgrader(allowedOrigins []string) websocket.Upgrader {
+	return websocket.Upgrader{
+		Subprotocols: []string{graphQLTransportWSProtocol},
+		CheckOrigin: func(req *http.Request) bool {
+			origin := req.Header.Get("Origin")
+			if origin == "" || slices.Contains(allowedOrigins, "*") {
+				return true
+			}
+			u, err := url.Parse(origin)
+			if err == nil && strings.EqualFold(u.Host, req.Host) {
+				return true
+			}
+			return slices.Contains(allowedOrigins, strings.ToLower(origin))
+		},
+	}
+}
+
+// graphQLWSConn is a connection executing GraphQL requests over the graphql-transport-ws protocol.
+//
+// Any number of operations can be executed concurrently, each identified by the id given by the client.
+type graphQLWSConn struct {
+	conn  *websocket.Conn
+	store client.Store
+
+	// writeLock serializes writes to the connection.
+	writeLock sync.Mutex
+
+	// operationsLock guards operations.
+	operationsLock sync.Mutex
+	// operations contains the cancel function of each operation in progress, keyed by id.
+	operations map[string]context.CancelFunc
+	// wg waits for all operations to finish.
+	wg sync.WaitGroup
+
+	initialised  bool
+	acknowledged bool
+}
+
+// ExecRequestWebSocket upgrades the request to a websocket and executes GraphQL requests
+// over the graphql-transport-ws protocol until the connection is closed.
+func (s *storeHandler) ExecRequestWebSocket(rw http.ResponseWriter, req *http.Request) {
+	store := req.Context().Value(storeContextKey).(client.Store)
+
+	upgrader := newWebSocketUpgrader(s.allowedOrigins)
+	conn, err := upgrader.Upgrade(rw, req, nil)
+	if err != nil {
+		// the upgrader has already responded with an error
+		return
+	}
+	defer conn.Close() //nolint:errcheck
+
+	c := &graphQLWSConn{
+		conn:       conn,
+		store:      store,
+		operations: make(map[string]context.CancelFunc),
+	}
+	if conn.Subprotocol() != graphQLTransportWSProtocol {
+		c.close(wsCloseSubprotocolNotAllowed, "Subprotocol not acceptable")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer func() {
+		cancel()
+		c.wg.Wait()
+	}()
+	c.serve(ctx)
+}
+
+// serve reads messages from the connection until it is closed.
+func (c *graphQLWSConn) serve(ctx context.Context) {
+	if err := c.conn.SetReadDeadline(time.Now().Add(wsConnectionInitTimeout)); err != nil {
+		return
+	}
+	for {
+		var msg GraphQLWSMessage
+		err := c.conn.ReadJSON(&msg)
+		var netErr net.Error
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case err == nil:
+		case errors.As(err, &netErr) && netErr.Timeout() && !c.acknowledged:
+			c.close(wsCloseInitTimeout, "Connection initialisation timeout")
+			return
+		case errors.As(err, &syntaxErr) || errors.As(err, &typeErr):
+			c.close(wsCloseBadRequest, "Invalid message received")
+			return
+		default:
+			return
+		}
+
+		if !c.handleMessage(ctx, msg) {
+			return
+		}
+	}
+}
+
+// handleMessage handles the given message, returning false if the connection has been closed.
+func (c *graphQLWSConn) handleMessage(ctx context.Context, msg GraphQLWSMessage) bool {
+	switch msg.Type {
+	case wsConnectionInit:
+		if c.initialised {
+			c.close(wsCloseTooManyInitRequests, "Too many initialisation requests")
+			return false
+		}
+		c.initialised = true
+		if err := c.conn.SetReadDeadline(time.Time{}); err != nil {
+			return false
+		}
+		if err := c.write(GraphQLWSMessage{Type: wsConnectionAck}); err != nil {
+			return false
+		}
+		c.acknowledged = true
+
+	case wsPing:
+		if err := c.write(GraphQLWSMessage{Type: wsPong}); err != nil {
+			return false
+		}
+
+	case wsPong:
+		// pongs may be sent unidirectionally as a heartbeat, so there is nothing to do
+
+	case wsSubscribe:
+		if !c.acknowledged {
+			c.close(wsCloseUnauthorized, "Unauthorized")
+			return false
+		}
+		var request GraphQLRequest
+		if msg.ID == "" || json.Unmarshal(msg.Payload, &request) != nil || request.Query == "" {
+			c.close(wsCloseBadRequest, "Invalid message received")
+			return false
+		}
+		if !c.subscribe(ctx, msg.ID, request.Query) {
+			c.close(wsCloseSubscriberExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+			return false
+		}
+
+	case wsComplete:
+		// the client is no longer interested in the operation, so it is
+		// stopped without sending a complete message in return
+		c.stop(msg.ID)
+
+	default:
+		c.close(wsCloseBadRequest, "Invalid message received")
+		return false
+	}
+	return true
+}
+
+// subscribe starts executing the given request as the operation with the given id,
+// returning false if an operation with the same id is already in progress.
+func (c *graphQLWSConn) subscribe(ctx context.Context, id string, query string) bool {
+	c.operationsLock.Lock()
+	defer c.operationsLock.Unlock()
+
+	if _, ok := c.operations[id]; ok {
+		return false
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.operations[id] = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer c.stop(id)
+		c.execute(ctx, id, query)
+	}()
+	return true
+}
+
+// stop stops the operation with the given id, returning false if it is not in progress.
+func (c *graphQLWSConn) stop(id string) bool {
+	c.operationsLock.Lock()
+	cancel, ok := c.operations[id]
+	delete(c.operations, id)
+	c.operationsLock.Unlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// execute executes the given request and sends its results to the client
+// until it completes or is stopped.
+func (c *graphQLWSConn) execute(ctx context.Context, id string, query string) {
+	result := c.store.ExecRequest(ctx, query)
+
+	if result.Pub == nil {
+		// errors raised before execution terminate the operation
+		if len(result.GQL.Errors) > 0 && result.GQL.Data == nil {
+			payload, err := json.Marshal(newGraphQLWSErrors(result.GQL.Errors))
+			if err != nil {
+				return
+			}
+			if c.stop(id) {
+				c.write(GraphQLWSMessage{ID: id, Type: wsError, Payload: payload}) //nolint:errcheck
+			}
+			return
+		}
+		if err := c.next(id, result.GQL); err != nil {
+			return
+		}
+		c.complete(id)
+		return
+	}
+	// the subscription is stopped along with the operation, so that no more
+	// results are published to it
+	defer result.Pub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item, open := <-result.Pub.Stream():
+			if !open {
+				c.complete(id)
+				return
+			}
+			gqlResult, ok := item.(client.GQLResult)
+			if !ok {
+				continue
+			}
+			if err := c.next(id, gqlResult); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// next sends the given result of the operation with the given id to the client.
+func (c *graphQLWSConn) next(id string, result client.GQLResult) error {
+	payload, err := json.Marshal(newGraphQLWSResult(result))
+	if err != nil {
+		return err
+	}
+	return c.write(GraphQLWSMessage{ID: id, Type: wsNext, Payload: payload})
+}
+
+// complete stops the operation with the given id and notifies the client that it has
+// completed, unless it has already been stopped by the client.
+func (c *graphQLWSConn) complete(id string) {
+	if c.stop(id) {
+		c.write(GraphQLWSMessage{ID: id, Type: wsComplete}) //nolint:errcheck
+	}
+}
+
+func (c *graphQLWSConn) write(msg GraphQLWSMessage) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteJSON(msg)
+}
+
+func (c *graphQLWSConn) close(code int, text string) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	msg := websocket.FormatCloseMessage(code, text)
+	c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteTimeout)) //nolint:errcheck
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dialGraphQLWebSocket(t *testing.T, subprotocols ...string) *websocket.Conn {
+	handler, err := NewHandler(setupDatabase(t), ServerOptions{})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	dialer := websocket.Dialer{Subprotocols: subprotocols}
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v0/graphql"
+	conn, _, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+
+	return conn
+}
+
+func initGraphQLWebSocket(t *testing.T) *websocket.Conn {
+	conn := dialGraphQLWebSocket(t, graphQLTransportWSProtocol)
+
+	err := conn.WriteJSON(GraphQLWSMessage{Type: wsConnectionInit})
+	require.NoError(t, err)
+
+	var msg GraphQLWSMessage
+	err = conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, wsConnectionAck, msg.Type)
+
+	return conn
+}
+
+func subscribeGraphQLWebSocket(t *testing.T, conn *websocket.Conn, id string, query string) {
+	payload, err := json.Marshal(GraphQLRequest{Query: query})
+	require.NoError(t, err)
+
+	err = conn.WriteJSON(GraphQLWSMessage{ID: id, Type: wsSubscribe, Payload: payload})
+	require.NoError(t, err)
+}
+
+func assertGraphQLWebSocketClosed(t *testing.T, conn *websocket.Conn, code int) {
+	var msg GraphQLWSMessage
+	err := conn.ReadJSON(&msg)
+	assert.True(t, websocket.IsCloseError(err, code), "unexpected error: %v", err)
+}
+
+func TestGraphQLWebSocket_WithQuery(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	subscribeGraphQLWebSocket(t, conn, "1", `query { User { name } }`)
+
+	var msg GraphQLWSMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "1", msg.ID)
+	assert.Equal(t, wsNext, msg.Type)
+	assert.JSONEq(t, `{"data": [{"name": "bob"}]}`, string(msg.Payload))
+
+	var complete GraphQLWSMessage
+	require.NoError(t, conn.ReadJSON(&complete))
+	assert.Equal(t, GraphQLWSMessage{ID: "1", Type: wsComplete}, complete)
+}
+
+func TestGraphQLWebSocket_WithInvalidQuery_Errors(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	subscribeGraphQLWebSocket(t, conn, "1", `query { Unknown { name } }`)
+
+	var msg GraphQLWSMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "1", msg.ID)
+	assert.Equal(t, wsError, msg.Type)
+
+	var errs []GraphQLWSError
+	require.NoError(t, json.Unmarshal(msg.Payload, &errs))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "Unknown")
+}
+
+func TestGraphQLWebSocket_WithSubscriptionAndMutation(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	// operations are executed concurrently, so the subscription is resumed after the
+	// existing document to ensure that the created document is yielded exactly once
+	subscribeGraphQLWebSocket(t, conn, "sub", `subscription { User(after: "1") { name } }`)
+	subscribeGraphQLWebSocket(t, conn, "mut", `mutation { create_User(data: "{\"name\": \"alice\"}") { name } }`)
+
+	// the results of the mutation and subscription may be interleaved
+	results := map[string][]GraphQLWSMessage{}
+	for len(results["sub"]) == 0 || len(results["mut"]) < 2 {
+		var msg GraphQLWSMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		results[msg.ID] = append(results[msg.ID], msg)
+	}
+
+	require.Len(t, results["mut"], 2)
+	assert.Equal(t, wsNext, results["mut"][0].Type)
+	assert.JSONEq(t, `{"data": [{"name": "alice"}]}`, string(results["mut"][0].Payload))
+	assert.Equal(t, wsComplete, results["mut"][1].Type)
+
+	require.Len(t, results["sub"], 1)
+	assert.Equal(t, wsNext, results["sub"][0].Type)
+	assert.JSONEq(
+		t,
+		`{"data": [{"name": "alice"}], "extensions": {"resumeToken": "2"}}`,
+		string(results["sub"][0].Payload),
+	)
+
+	// the client may stop the subscription and reuse its id
+	require.NoError(t, conn.WriteJSON(GraphQLWSMessage{ID: "sub", Type: wsComplete}))
+	subscribeGraphQLWebSocket(t, conn, "sub", `query { User { name } }`)
+
+	var msg GraphQLWSMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "sub", msg.ID)
+	assert.Equal(t, wsNext, msg.Type)
+}
+
+func TestGraphQLWebSocket_WithPing_Pongs(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	require.NoError(t, conn.WriteJSON(GraphQLWSMessage{Type: wsPing}))
+
+	var msg GraphQLWSMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, GraphQLWSMessage{Type: wsPong}, msg)
+}
+
+func TestGraphQLWebSocket_WithoutSubprotocol_Closes(t *testing.T) {
+	conn := dialGraphQLWebSocket(t)
+	assertGraphQLWebSocketClosed(t, conn, wsCloseSubprotocolNotAllowed)
+}
+
+func TestGraphQLWebSocket_WithSubscribeBeforeInit_Closes(t *testing.T) {
+	conn := dialGraphQLWebSocket(t, graphQLTransportWSProtocol)
+	subscribeGraphQLWebSocket(t, conn, "1", `query { User { name } }`)
+	assertGraphQLWebSocketClosed(t, conn, wsCloseUnauthorized)
+}
+
+func TestGraphQLWebSocket_WithDuplicateInit_Closes(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	require.NoError(t, conn.WriteJSON(GraphQLWSMessage{Type: wsConnectionInit}))
+	assertGraphQLWebSocketClosed(t, conn, wsCloseTooManyInitRequests)
+}
+
+func TestGraphQLWebSocket_WithDuplicateID_Closes(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	subscribeGraphQLWebSocket(t, conn, "1", `subscription { User { name } }`)
+	subscribeGraphQLWebSocket(t, conn, "1", `subscription { User { name } }`)
+	assertGraphQLWebSocketClosed(t, conn, wsCloseSubscriberExists)
+}
+
+func TestGraphQLWebSocket_WithInvalidMessage_Closes(t *testing.T) {
+	conn := initGraphQLWebSocket(t)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+	assertGraphQLWebSocketClosed(t, conn, wsCloseBadRequest)
+}
+
+func TestGraphQLWebSocket_WithoutInit_ClosesAfterTimeout(t *testing.T) {
+	timeout := wsConnectionInitTimeout
+	wsConnectionInitTimeout = 0
+	t.Cleanup(func() { wsConnectionInitTimeout = timeout })
+
+	conn := dialGraphQLWebSocket(t, graphQLTransportWSProtocol)
+	assertGraphQLWebSocketClosed(t, conn, wsCloseInitTimeout)
+}
@@ -16,13 +16,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
 
 	"github.com/sourcenetwork/defradb/client"
 )
 
-type storeHandler struct{}
+type storeHandler struct {
+	// allowedOrigins is the list of origins allowed to open websocket connections.
+	allowedOrigins []string
+}
 
 func (s *storeHandler) BasicImport(rw http.ResponseWriter, req *http.Request) {
 	store := req.Context().Value(storeContextKey).(client.Store)
@@ -199,6 +205,49 @@ func (s *storeHandler) GetAllIndexes(rw http.ResponseWriter, req *http.Request)
 	responseJSON(rw, http.StatusOK, indexes)
 }
 
+func (s *storeHandler) AddWebhook(rw http.ResponseWriter, req *http.Request) {
+	store := req.Context().Value(storeContextKey).(client.Store)
+
+	var webhook client.Webhook
+	if err := requestJSON(req, &webhook); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	webhook, err := store.AddWebhook(req.Context(), webhook)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, webhook)
+}
+
+func (s *storeHandler) DeleteWebhook(rw http.ResponseWriter, req *http.Request) {
+	store := req.Context().Value(storeContextKey).(client.Store)
+
+	id, err := strconv.ParseUint(chi.URLParam(req, "id"), 10, 32)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	err = store.DeleteWebhook(req.Context(), uint32(id))
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (s *storeHandler) GetAllWebhooks(rw http.ResponseWriter, req *http.Request) {
+	store := req.Context().Value(storeContextKey).(client.Store)
+
+	webhooks, err := store.GetAllWebhooks(req.Context())
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, webhooks)
+}
+
 func (s *storeHandler) PrintDump(rw http.ResponseWriter, req *http.Request) {
 	db := req.Context().Value(dbContextKey).(client.DB)
 
@@ -264,6 +313,10 @@ func (res *GraphQLResponse) UnmarshalJSON(data []byte) error {
 }
 
 func (s *storeHandler) ExecRequest(rw http.ResponseWriter, req *http.Request) {
+	if websocket.IsWebSocketUpgrade(req) {
+		s.ExecRequestWebSocket(rw, req)
+		return
+	}
 	store := req.Context().Value(storeContextKey).(client.Store)
 
 	var request GraphQLRequest
@@ -310,6 +363,11 @@ func (s *storeHandler) ExecRequest(rw http.ResponseWriter, req *http.Request) {
 			if err != nil {
 				return
 			}
+			// the resume token is sent as the event id so that it
+			// can be used to resume the subscription on reconnect
+			if gqlResult, ok := item.(client.GQLResult); ok && gqlResult.ResumeToken != "" {
+				fmt.Fprintf(rw, "id: %s\n", gqlResult.ResumeToken)
+			}
 			fmt.Fprintf(rw, "data: %s\n\n", data)
 			flusher.Flush()
 		}
@@ -503,13 +561,64 @@ func (h *storeHandler) bindRoutes(router *Router) {
 		WithSchema(openapi3.NewStringSchema())
 
 	graphQLGet := openapi3.NewOperation()
-	graphQLGet.Description = "GraphQL GET endpoint"
+	graphQLGet.Description = "GraphQL GET endpoint. Upgrades to a websocket using the graphql-transport-ws protocol " +
+		"when requested, allowing many requests and subscriptions to be executed over one connection."
 	graphQLGet.OperationID = "graphql_get"
 	graphQLGet.Tags = []string{"graphql"}
 	graphQLGet.AddParameter(graphQLQueryParam)
 	graphQLGet.AddResponse(200, graphQLResponse)
 	graphQLGet.Responses["400"] = errorResponse
 
+	webhookSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/webhook",
+	}
+
+	webhookRequest := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithJSONSchemaRef(webhookSchema))
+
+	webhookResponse := openapi3.NewResponse().
+		WithDescription("Webhook").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(webhookSchema))
+
+	addWebhook := openapi3.NewOperation()
+	addWebhook.Description = "Add a webhook delivering the document changes of a collection"
+	addWebhook.OperationID = "webhook_add"
+	addWebhook.Tags = []string{"webhook"}
+	addWebhook.RequestBody = &openapi3.RequestBodyRef{
+		Value: webhookRequest,
+	}
+	addWebhook.AddResponse(200, webhookResponse)
+	addWebhook.Responses["400"] = errorResponse
+
+	webhooksSchema := openapi3.NewArraySchema()
+	webhooksSchema.Items = webhookSchema
+
+	getWebhooksResponse := openapi3.NewResponse().
+		WithDescription("Webhooks").
+		WithContent(openapi3.NewContentWithJSONSchema(webhooksSchema))
+
+	getWebhooks := openapi3.NewOperation()
+	getWebhooks.Description = "List webhooks"
+	getWebhooks.OperationID = "webhook_list"
+	getWebhooks.Tags = []string{"webhook"}
+	getWebhooks.AddResponse(200, getWebhooksResponse)
+	getWebhooks.Responses["400"] = errorResponse
+
+	webhookIDPathParam := openapi3.NewPathParameter("id").
+		WithDescription("Webhook id").
+		WithRequired(true).
+		WithSchema(openapi3.NewInt32Schema())
+
+	deleteWebhook := openapi3.NewOperation()
+	deleteWebhook.Description = "Delete a webhook"
+	deleteWebhook.OperationID = "webhook_delete"
+	deleteWebhook.Tags = []string{"webhook"}
+	deleteWebhook.AddParameter(webhookIDPathParam)
+	deleteWebhook.Responses = make(openapi3.Responses)
+	deleteWebhook.Responses["200"] = successResponse
+	deleteWebhook.Responses["400"] = errorResponse
+
 	debugDump := openapi3.NewOperation()
 	debugDump.Description = "Dump database"
 	debugDump.OperationID = "debug_dump"
@@ -528,4 +637,7 @@ func (h *storeHandler) bindRoutes(router *Router) {
 	router.AddRoute("/schema", http.MethodPatch, patchSchema, h.PatchSchema)
 	router.AddRoute("/schema", http.MethodGet, schemaDescribe, h.GetSchema)
 	router.AddRoute("/schema/default", http.MethodPost, setDefaultSchemaVersion, h.SetDefaultSchemaVersion)
+	router.AddRoute("/webhooks", http.MethodGet, getWebhooks, h.GetAllWebhooks)
+	router.AddRoute("/webhooks", http.MethodPost, addWebhook, h.AddWebhook)
+	router.AddRoute("/webhooks/{id}", http.MethodDelete, deleteWebhook, h.DeleteWebhook)
 }
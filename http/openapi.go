@@ -23,6 +23,7 @@ var openApiSchemas = map[string]any{
 	"error":                &errorResponse{},
 	"create_tx":            &CreateTxResponse{},
 	"collection_update":    &CollectionUpdateRequest{},
+	"collection_upsert":    &CollectionUpsertRequest{},
 	"collection_delete":    &CollectionDeleteRequest{},
 	"peer_info":            &peer.AddrInfo{},
 	"graphql_request":      &GraphQLRequest{},
@@ -33,8 +34,11 @@ var openApiSchemas = map[string]any{
 	"index":                &client.IndexDescription{},
 	"delete_result":        &client.DeleteResult{},
 	"update_result":        &client.UpdateResult{},
+	"upsert_result":        &client.UpsertResult{},
+	"change":               &client.Change{},
 	"lens_config":          &client.LensConfig{},
 	"replicator":           &client.Replicator{},
+	"webhook":              &client.Webhook{},
 	"ccip_request":         &CCIPRequest{},
 	"ccip_response":        &CCIPResponse{},
 	"patch_schema_request": &patchSchemaRequest{},
@@ -134,6 +138,10 @@ func NewOpenAPISpec() (*openapi3.T, error) {
 				Name:        "backup",
 				Description: "Database backup operations",
 			},
+			&openapi3.Tag{
+				Name:        "webhook",
+				Description: "Deliver document changes to external services",
+			},
 			&openapi3.Tag{
 				Name:        "graphql",
 				Description: "GraphQL query endpoints",
@@ -0,0 +1,56 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lens
+
+import "github.com/sourcenetwork/defradb/errors"
+
+const (
+	errInvalidNativeModuleArgument string = "invalid native lens module argument"
+	errInvalidWasmModuleID         string = "invalid wasm module ID"
+	errWasmModuleNotFound          string = "the content of the wasm module could not be found"
+)
+
+var (
+	ErrInvalidNativeModuleArgument = errors.New(errInvalidNativeModuleArgument)
+	ErrInvalidWasmModuleID         = errors.New(errInvalidWasmModuleID)
+	ErrWasmModuleNotFound          = errors.New(errWasmModuleNotFound)
+)
+
+// NewErrInvalidNativeModuleArgument returns a new error indicating that the given argument of
+// a native lens module is missing or of the wrong type.
+func NewErrInvalidNativeModuleArgument(path string, argument string, value any) error {
+	return errors.New(
+		errInvalidNativeModuleArgument,
+		errors.NewKV("Path", path),
+		errors.NewKV("Argument", argument),
+		errors.NewKV("Value", value),
+	)
+}
+
+// NewErrInvalidWasmModuleID returns a new error indicating that the given wasm module ID is
+// not a valid content ID.
+func NewErrInvalidWasmModuleID(path string, id string) error {
+	return errors.New(
+		errInvalidWasmModuleID,
+		errors.NewKV("Path", path),
+		errors.NewKV("ID", id),
+	)
+}
+
+// NewErrWasmModuleNotFound returns a new error indicating that the content of the wasm module
+// with the given ID is neither stored within the database, nor found at the given path.
+func NewErrWasmModuleNotFound(path string, id string) error {
+	return errors.New(
+		errWasmModuleNotFound,
+		errors.NewKV("Path", path),
+		errors.NewKV("ID", id),
+	)
+}
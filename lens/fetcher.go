@@ -42,6 +42,11 @@ type lensedFetcher struct {
 
 	// If true there are migrations registered for the collection being fetched.
 	hasMigrations bool
+
+	// The filter to run against migrated documents, this is only set if there are migrations
+	// registered for the collection being fetched, otherwise the source fetcher runs it.
+	filter    *mapper.Filter
+	docMapper *core.DocumentMapping
 }
 
 var _ fetcher.Fetcher = (*lensedFetcher)(nil)
@@ -103,15 +108,103 @@ func (f *lensedFetcher) Init(
 	f.targetVersionID = col.Schema().VersionID
 
 	var innerFetcherFields []client.FieldDescription
+	innerFetcherFilter := filter
 	if f.hasMigrations {
 		// If there are migrations present, they may require fields that are not otherwise
 		// requested.  At the moment this means we need to pass in nil so that the underlying
 		// fetcher fetches everything.
 		innerFetcherFields = nil
+
+		// Migrations may change the values that are filtered on, so the filter must be run
+		// against the migrated documents instead of within the underlying fetcher.
+		//
+		// The conditions on fields that the migrations do not change are still passed to the
+		// underlying fetcher, so that it may read them from an index.  If the changed fields cannot
+		// be known, as some of the migrations are wasm modules, only the conditions on relation id
+		// fields are passed, like the key a type join looks up the related documents with.
+		// Migrations are not expected to change which documents are related, and all conditions are
+		// checked again against the migrated documents.
+		migratedFields, isKnown := getMigratedFields(cfg, history)
+		innerFetcherFilter = unmigratedConditions(filter, docmapper, col.Schema(), migratedFields, isKnown)
+		f.filter = filter
+		f.docMapper = docmapper
 	} else {
 		innerFetcherFields = fields
 	}
-	return f.source.Init(ctx, txn, col, innerFetcherFields, filter, docmapper, reverse, showDeleted)
+	return f.source.Init(ctx, txn, col, innerFetcherFields, innerFetcherFilter, docmapper, reverse, showDeleted)
+}
+
+// getMigratedFields returns the names of the fields that the migrations of the given history may
+// change, and false if they cannot be known as some of the migrations are not native modules.
+func getMigratedFields(
+	cfgs []client.LensConfig,
+	history map[schemaVersionID]*targetedSchemaHistoryLink,
+) (map[string]struct{}, bool) {
+	fields := map[string]struct{}{}
+	for _, cfg := range cfgs {
+		_, isSource := history[cfg.SourceSchemaVersionID]
+		_, isDestination := history[cfg.DestinationSchemaVersionID]
+		if !isSource && !isDestination {
+			continue
+		}
+		for _, module := range cfg.Lenses {
+			moduleFields, ok := nativeModuleFields(module)
+			if !ok {
+				return nil, false
+			}
+			for _, field := range moduleFields {
+				fields[field] = struct{}{}
+			}
+		}
+	}
+	return fields, true
+}
+
+// unmigratedConditions returns the top level conditions of the given filter on fields that are not
+// changed by migrations, or nil if there are none.
+//
+// If the migrated fields are not known, only the conditions on relation id fields are returned.
+func unmigratedConditions(
+	filter *mapper.Filter,
+	docMapper *core.DocumentMapping,
+	schema client.SchemaDescription,
+	migratedFields map[string]struct{},
+	isKnown bool,
+) *mapper.Filter {
+	if filter == nil || docMapper == nil {
+		return nil
+	}
+
+	fieldsByIndex := map[int]client.FieldDescription{}
+	for _, field := range schema.Fields {
+		for _, index := range docMapper.IndexesByName[field.Name] {
+			fieldsByIndex[index] = field
+		}
+	}
+
+	var conditions *mapper.Filter
+	for key, cond := range filter.Conditions {
+		propIndex, ok := key.(*mapper.PropertyIndex)
+		if !ok {
+			continue
+		}
+		field, ok := fieldsByIndex[propIndex.Index]
+		if !ok {
+			continue
+		}
+		if isKnown {
+			if _, isMigrated := migratedFields[field.Name]; isMigrated {
+				continue
+			}
+		} else if !field.RelationType.IsSet(client.Relation_Type_INTERNAL_ID) {
+			continue
+		}
+		if conditions == nil {
+			conditions = mapper.NewFilter()
+		}
+		conditions.Conditions[key] = cond
+	}
+	return conditions
 }
 
 func (f *lensedFetcher) Start(ctx context.Context, spans core.Spans) error {
@@ -119,6 +212,37 @@ func (f *lensedFetcher) Start(ctx context.Context, spans core.Spans) error {
 }
 
 func (f *lensedFetcher) FetchNext(ctx context.Context) (fetcher.EncodedDocument, fetcher.ExecInfo, error) {
+	var execInfo fetcher.ExecInfo
+	for {
+		doc, nextExecInfo, err := f.fetchNextMigrated(ctx)
+		execInfo.Add(nextExecInfo)
+		if err != nil {
+			return nil, fetcher.ExecInfo{}, err
+		}
+
+		if doc == nil || f.filter == nil {
+			return doc, execInfo, nil
+		}
+
+		decodedDoc, err := fetcher.DecodeToDoc(doc, f.docMapper, false)
+		if err != nil {
+			return nil, fetcher.ExecInfo{}, err
+		}
+
+		passedFilter, err := mapper.RunFilter(decodedDoc, f.filter)
+		if err != nil {
+			return nil, fetcher.ExecInfo{}, err
+		}
+
+		if passedFilter {
+			return doc, execInfo, nil
+		}
+	}
+}
+
+// fetchNextMigrated returns the next document yielded by the source fetcher, migrated to the
+// target schema version.
+func (f *lensedFetcher) fetchNextMigrated(ctx context.Context) (fetcher.EncodedDocument, fetcher.ExecInfo, error) {
 	doc, execInfo, err := f.source.FetchNext(ctx)
 	if err != nil {
 		return nil, fetcher.ExecInfo{}, err
@@ -150,7 +274,7 @@ func (f *lensedFetcher) FetchNext(ctx context.Context) (fetcher.EncodedDocument,
 	}
 	if !hasNext {
 		// The migration decided to not yield a document, so we cycle through the next fetcher doc
-		doc, nextExecInfo, err := f.FetchNext(ctx)
+		doc, nextExecInfo, err := f.fetchNextMigrated(ctx)
 		execInfo.Add(nextExecInfo)
 		return doc, execInfo, err
 	}
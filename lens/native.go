@@ -0,0 +1,612 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lens
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/lens-vm/lens/host-go/config/model"
+	"github.com/sourcenetwork/immutable/enumerable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+const (
+	// RenameFieldModulePath is the path of the native lens module that renames a document property.
+	//
+	// The module has two parameters:
+	//   - `src` is a string and is the name of the property you wish to rename.
+	//   - `dst` is a string and is the new name of the property.
+	//
+	// This module has an inverse, which will rename the `dst` property back to `src`.
+	RenameFieldModulePath = "defradb:rename-field"
+
+	// DropFieldModulePath is the path of the native lens module that removes a document property.
+	//
+	// The module has one parameter:
+	//   - `target` is a string and is the name of the property you wish to remove.
+	//
+	// This module has an inverse, which leaves documents unchanged as the removed values
+	// cannot be recovered.
+	DropFieldModulePath = "defradb:drop-field"
+
+	// CoerceFieldKindModulePath is the path of the native lens module that converts the value of a
+	// document property from one field kind to another.
+	//
+	// The module has three parameters:
+	//   - `target` is a string and is the name of the property you wish to convert.
+	//   - `srcKind` is a string and is the [client.FieldKind] the value is converted from.
+	//   - `dstKind` is a string and is the [client.FieldKind] the value is converted to.
+	//
+	// Values that cannot be represented by the destination kind become nil.
+	//
+	// This module has an inverse, which will convert the value from `dstKind` back to `srcKind`.
+	CoerceFieldKindModulePath = "defradb:coerce-field-kind"
+
+	// SetDefaultModulePath is the path of the native lens module that sets the value of a document
+	// property, should it not already have one.
+	//
+	// The module has two parameters:
+	//   - `dst` is a string and is the name of the property you wish to set.
+	//   - `value` can be any valid json value and is the value that the `dst` property of documents
+	//     without a `dst` value will be set to.
+	//
+	// This module has an inverse, which will remove the `dst` property from all documents.
+	SetDefaultModulePath = "defradb:set-default"
+
+	// CopyFieldModulePath is the path of the native lens module that copies the value of a document
+	// property into another property.
+	//
+	// The module has two parameters:
+	//   - `src` is a string and is the name of the property you wish to copy the value from.
+	//   - `dst` is a string and is the name of the property you wish to copy the value to.
+	//
+	// This module has an inverse, which will remove the `dst` property from all documents.
+	CopyFieldModulePath = "defradb:copy-field"
+
+	// MapValuesModulePath is the path of the native lens module that replaces the values of a document
+	// property using a mapping of values.
+	//
+	// The module has two parameters:
+	//   - `target` is a string and is the name of the property whose values you wish to replace.
+	//   - `values` is an array of objects, each with a `from` and a `to` property, containing the values
+	//     you wish to replace and the values to replace them with.
+	//
+	// Values not found within the mapping are left unchanged.
+	//
+	// This module has an inverse, which will replace the `to` values with their `from` values.  Should
+	// multiple values be mapped to the same `to` value, the first of them will be used.
+	MapValuesModulePath = "defradb:map-values"
+)
+
+// transform transforms a single document.
+//
+// Transforms must not mutate the given document.
+type transform func(LensDoc) (LensDoc, error)
+
+// nativeModules contains the constructors of the native lens modules by module path.
+//
+// Native modules are executed in-process instead of within the wasm runtime, and may be
+// used wherever a wasm module may be used.
+var nativeModules = map[string]func(cfg model.LensModule) (transform, error){
+	RenameFieldModulePath:     newRenameFieldTransform,
+	DropFieldModulePath:       newDropFieldTransform,
+	CoerceFieldKindModulePath: newCoerceFieldKindTransform,
+	SetDefaultModulePath:      newSetDefaultTransform,
+	CopyFieldModulePath:       newCopyFieldTransform,
+	MapValuesModulePath:       newMapValuesTransform,
+}
+
+// ValueMapping describes the replacement of a single value by a map values native lens module.
+type ValueMapping struct {
+	// From is the value to be replaced.
+	From any
+	// To is the value to replace it with.
+	To any
+}
+
+// NewRenameFieldModule returns the configuration of a native lens module that renames
+// the given field.
+func NewRenameFieldModule(src string, dst string) model.LensModule {
+	return model.LensModule{
+		Path: RenameFieldModulePath,
+		Arguments: map[string]any{
+			"src": src,
+			"dst": dst,
+		},
+	}
+}
+
+// NewDropFieldModule returns the configuration of a native lens module that removes the given
+// field.
+func NewDropFieldModule(target string) model.LensModule {
+	return model.LensModule{
+		Path: DropFieldModulePath,
+		Arguments: map[string]any{
+			"target": target,
+		},
+	}
+}
+
+// NewCoerceFieldKindModule returns the configuration of a native lens module that converts the
+// value of the given field from the source kind to the destination kind.
+func NewCoerceFieldKindModule(target string, srcKind client.FieldKind, dstKind client.FieldKind) model.LensModule {
+	return model.LensModule{
+		Path: CoerceFieldKindModulePath,
+		Arguments: map[string]any{
+			"target":  target,
+			"srcKind": srcKind.String(),
+			"dstKind": dstKind.String(),
+		},
+	}
+}
+
+// NewSetDefaultModule returns the configuration of a native lens module that sets the given
+// field to the given value, should it not already have one.
+func NewSetDefaultModule(dst string, value any) model.LensModule {
+	return model.LensModule{
+		Path: SetDefaultModulePath,
+		Arguments: map[string]any{
+			"dst":   dst,
+			"value": value,
+		},
+	}
+}
+
+// NewCopyFieldModule returns the configuration of a native lens module that copies the value of
+// the source field into the destination field.
+func NewCopyFieldModule(src string, dst string) model.LensModule {
+	return model.LensModule{
+		Path: CopyFieldModulePath,
+		Arguments: map[string]any{
+			"src": src,
+			"dst": dst,
+		},
+	}
+}
+
+// NewMapValuesModule returns the configuration of a native lens module that replaces the values
+// of the given field using the given mappings.
+func NewMapValuesModule(target string, mappings ...ValueMapping) model.LensModule {
+	values := make([]any, len(mappings))
+	for i, mapping := range mappings {
+		values[i] = map[string]any{
+			"from": mapping.From,
+			"to":   mapping.To,
+		}
+	}
+
+	return model.LensModule{
+		Path: MapValuesModulePath,
+		Arguments: map[string]any{
+			"target": target,
+			"values": values,
+		},
+	}
+}
+
+func isNativeModule(path string) bool {
+	_, ok := nativeModules[path]
+	return ok
+}
+
+// nativeModuleFields returns the names of the document properties that the given module may change,
+// and false if it is not a native module, in which case any property may be changed.
+func nativeModuleFields(cfg model.LensModule) ([]string, bool) {
+	if !isNativeModule(cfg.Path) {
+		return nil, false
+	}
+
+	fields := []string{}
+	for _, name := range []string{"src", "dst", "target"} {
+		if field, ok := cfg.Arguments[name].(string); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields, true
+}
+
+func newRenameFieldTransform(cfg model.LensModule) (transform, error) {
+	src, err := getStringArgument(cfg, "src")
+	if err != nil {
+		return nil, err
+	}
+	dst, err := getStringArgument(cfg, "dst")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Inverse {
+		src, dst = dst, src
+	}
+
+	return func(doc LensDoc) (LensDoc, error) {
+		value, hasValue := doc[src]
+		if !hasValue {
+			return doc, nil
+		}
+
+		result := copyLensDoc(doc)
+		delete(result, src)
+		result[dst] = value
+		return result, nil
+	}, nil
+}
+
+func newDropFieldTransform(cfg model.LensModule) (transform, error) {
+	target, err := getStringArgument(cfg, "target")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Inverse {
+		return func(doc LensDoc) (LensDoc, error) {
+			return doc, nil
+		}, nil
+	}
+
+	return newRemoveFieldTransform(target), nil
+}
+
+func newCoerceFieldKindTransform(cfg model.LensModule) (transform, error) {
+	target, err := getStringArgument(cfg, "target")
+	if err != nil {
+		return nil, err
+	}
+	srcKind, err := getFieldKindArgument(cfg, "srcKind")
+	if err != nil {
+		return nil, err
+	}
+	dstKind, err := getFieldKindArgument(cfg, "dstKind")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Inverse {
+		srcKind, dstKind = dstKind, srcKind
+	}
+
+	return func(doc LensDoc) (LensDoc, error) {
+		value, hasValue := doc[target]
+		if !hasValue {
+			return doc, nil
+		}
+
+		result := copyLensDoc(doc)
+		result[target] = coerceValue(value, srcKind, dstKind)
+		return result, nil
+	}, nil
+}
+
+func newSetDefaultTransform(cfg model.LensModule) (transform, error) {
+	dst, err := getStringArgument(cfg, "dst")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Inverse {
+		return newRemoveFieldTransform(dst), nil
+	}
+
+	defaultValue, hasDefault := cfg.Arguments["value"]
+	if !hasDefault {
+		return nil, NewErrInvalidNativeModuleArgument(cfg.Path, "value", nil)
+	}
+
+	return func(doc LensDoc) (LensDoc, error) {
+		if value := doc[dst]; value != nil {
+			return doc, nil
+		}
+
+		result := copyLensDoc(doc)
+		result[dst] = defaultValue
+		return result, nil
+	}, nil
+}
+
+func newCopyFieldTransform(cfg model.LensModule) (transform, error) {
+	src, err := getStringArgument(cfg, "src")
+	if err != nil {
+		return nil, err
+	}
+	dst, err := getStringArgument(cfg, "dst")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Inverse {
+		return newRemoveFieldTransform(dst), nil
+	}
+
+	return func(doc LensDoc) (LensDoc, error) {
+		value, hasValue := doc[src]
+		if !hasValue {
+			return doc, nil
+		}
+
+		result := copyLensDoc(doc)
+		result[dst] = value
+		return result, nil
+	}, nil
+}
+
+func newMapValuesTransform(cfg model.LensModule) (transform, error) {
+	target, err := getStringArgument(cfg, "target")
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := getValueMappingsArgument(cfg, "values")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Inverse {
+		for i, mapping := range mappings {
+			mappings[i] = ValueMapping{From: mapping.To, To: mapping.From}
+		}
+	}
+
+	return func(doc LensDoc) (LensDoc, error) {
+		value, hasValue := doc[target]
+		if !hasValue {
+			return doc, nil
+		}
+
+		for _, mapping := range mappings {
+			if lensValuesEqual(value, mapping.From) {
+				result := copyLensDoc(doc)
+				result[target] = mapping.To
+				return result, nil
+			}
+		}
+
+		return doc, nil
+	}, nil
+}
+
+// newRemoveFieldTransform returns a transform that removes the given property from documents.
+func newRemoveFieldTransform(target string) transform {
+	return func(doc LensDoc) (LensDoc, error) {
+		if _, hasValue := doc[target]; !hasValue {
+			return doc, nil
+		}
+
+		result := copyLensDoc(doc)
+		delete(result, target)
+		return result, nil
+	}
+}
+
+// lensValuesEqual returns true if the given values are equal, regardless of the type used to
+// represent any numbers within them.
+//
+// Argument values are json decoded when loaded from the store, whereas document values are
+// decoded using the field kind, so the same number may be held as a different type by each.
+func lensValuesEqual(a any, b any) bool {
+	return reflect.DeepEqual(normalizeLensValue(a), normalizeLensValue(b))
+}
+
+func normalizeLensValue(value any) any {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = normalizeLensValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// coerceValue converts the given value from the source kind to the destination kind.
+//
+// Values that cannot be represented by the destination kind are returned as nil.  Values that
+// are already represented as the destination kind, for example arrays that were decoded using the
+// destination kind, are returned unchanged.
+func coerceValue(value any, srcKind client.FieldKind, dstKind client.FieldKind) any {
+	if value == nil || srcKind == dstKind {
+		return value
+	}
+
+	if srcKind.IsScalarArray() && dstKind.IsScalarArray() {
+		array, isArray := value.([]any)
+		if !isArray {
+			return value
+		}
+
+		srcElementKind, _ := srcKind.ElementKind()
+		dstElementKind, dstIsNillable := dstKind.ElementKind()
+		result := make([]any, len(array))
+		for i, item := range array {
+			result[i] = coerceValue(item, srcElementKind, dstElementKind)
+			if result[i] == nil && !dstIsNillable {
+				return nil
+			}
+		}
+		return result
+	}
+
+	switch dstKind {
+	case client.FieldKind_STRING:
+		switch v := value.(type) {
+		case string:
+			return v
+		case bool:
+			return strconv.FormatBool(v)
+		case int64:
+			return strconv.FormatInt(v, 10)
+		case uint64:
+			return strconv.FormatUint(v, 10)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+
+	case client.FieldKind_DATETIME:
+		if v, isString := value.(string); isString {
+			if _, err := time.Parse(time.RFC3339, v); err == nil {
+				return v
+			}
+		}
+
+	case client.FieldKind_FLOAT:
+		switch v := value.(type) {
+		case float64:
+			return v
+		case int64:
+			return float64(v)
+		case uint64:
+			return float64(v)
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+
+	case client.FieldKind_INT:
+		switch v := value.(type) {
+		case int64:
+			return v
+		case uint64:
+			return int64(v)
+		case float64:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+
+	case client.FieldKind_BOOL:
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+	}
+
+	return nil
+}
+
+func getStringArgument(cfg model.LensModule, name string) (string, error) {
+	value, ok := cfg.Arguments[name].(string)
+	if !ok || value == "" {
+		return "", NewErrInvalidNativeModuleArgument(cfg.Path, name, cfg.Arguments[name])
+	}
+	return value, nil
+}
+
+func getValueMappingsArgument(cfg model.LensModule, name string) ([]ValueMapping, error) {
+	values, ok := cfg.Arguments[name].([]any)
+	if !ok {
+		return nil, NewErrInvalidNativeModuleArgument(cfg.Path, name, cfg.Arguments[name])
+	}
+
+	mappings := make([]ValueMapping, len(values))
+	for i, value := range values {
+		mapping, ok := value.(map[string]any)
+		if !ok {
+			return nil, NewErrInvalidNativeModuleArgument(cfg.Path, name, value)
+		}
+		from, hasFrom := mapping["from"]
+		to, hasTo := mapping["to"]
+		if !hasFrom || !hasTo {
+			return nil, NewErrInvalidNativeModuleArgument(cfg.Path, name, value)
+		}
+		mappings[i] = ValueMapping{From: from, To: to}
+	}
+	return mappings, nil
+}
+
+func getFieldKindArgument(cfg model.LensModule, name string) (client.FieldKind, error) {
+	value, err := getStringArgument(cfg, name)
+	if err != nil {
+		return 0, err
+	}
+	kind, ok := client.FieldKindStringToEnumMapping[value]
+	if !ok {
+		return 0, NewErrInvalidNativeModuleArgument(cfg.Path, name, value)
+	}
+	return kind, nil
+}
+
+func copyLensDoc(doc LensDoc) LensDoc {
+	result := make(LensDoc, len(doc))
+	for key, value := range doc {
+		result[key] = value
+	}
+	return result
+}
+
+// nativeEnumerable applies a native transform to the items yielded by its source.
+type nativeEnumerable struct {
+	source    enumerable.Enumerable[LensDoc]
+	transform transform
+	current   LensDoc
+}
+
+var _ enumerable.Enumerable[LensDoc] = (*nativeEnumerable)(nil)
+
+func newNativeEnumerable(
+	source enumerable.Enumerable[LensDoc],
+	cfg model.LensModule,
+) (enumerable.Enumerable[LensDoc], error) {
+	transform, err := nativeModules[cfg.Path](cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nativeEnumerable{
+		source:    source,
+		transform: transform,
+	}, nil
+}
+
+func (e *nativeEnumerable) Next() (bool, error) {
+	hasNext, err := e.source.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	value, err := e.source.Value()
+	if err != nil {
+		return false, err
+	}
+
+	e.current, err = e.transform(value)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (e *nativeEnumerable) Value() (LensDoc, error) {
+	return e.current, nil
+}
+
+func (e *nativeEnumerable) Reset() {
+	e.current = nil
+	e.source.Reset()
+}
@@ -13,13 +13,18 @@ package lens
 import (
 	"context"
 	"encoding/json"
+	"os"
 	"sync"
 
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore/query"
+	ipld "github.com/ipfs/go-ipld-format"
 	"github.com/lens-vm/lens/host-go/config"
 	"github.com/lens-vm/lens/host-go/config/model"
 	"github.com/lens-vm/lens/host-go/engine/module"
 	"github.com/lens-vm/lens/host-go/runtimes/wasmtime"
+	mh "github.com/multiformats/go-multihash"
 	"github.com/sourcenetwork/immutable"
 	"github.com/sourcenetwork/immutable/enumerable"
 
@@ -27,6 +32,7 @@ import (
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/events"
 )
 
 // todo: This file, particularly the `lensPool` stuff, contains fairly sensitive code that is both
@@ -42,7 +48,7 @@ type lensRegistry struct {
 	// The runtime used to execute lens wasm modules.
 	runtime module.Runtime
 
-	// The modules by file path used to instantiate lens wasm module instances.
+	// The modules by file path, or content ID if stored, used to instantiate lens wasm module instances.
 	modulesByPath map[string]module.Module
 	moduleLock    sync.Mutex
 
@@ -54,6 +60,9 @@ type lensRegistry struct {
 	configs    map[string]client.LensConfig
 	configLock sync.RWMutex
 
+	// The channel onto which set migrations are published, if any.
+	migrations events.MigrationChannel
+
 	// Writable transaction contexts by transaction ID.
 	//
 	// Read-only transaction contexts are not tracked.
@@ -91,8 +100,13 @@ const DefaultPoolSize int = 5
 
 // NewRegistry instantiates a new registery.
 //
-// It will be of size 5 (per schema version) if a size is not provided.
-func NewRegistry(lensPoolSize immutable.Option[int], db TxnSource) client.LensRegistry {
+// It will be of size 5 (per schema version) if a size is not provided.  Migrations set via the
+// registry will be published to the given channel, if it has a value, once committed.
+func NewRegistry(
+	lensPoolSize immutable.Option[int],
+	db TxnSource,
+	migrations events.MigrationChannel,
+) client.LensRegistry {
 	var size int
 	if lensPoolSize.HasValue() {
 		size = lensPoolSize.Value()
@@ -109,6 +123,7 @@ func NewRegistry(lensPoolSize immutable.Option[int], db TxnSource) client.LensRe
 			lensPoolsBySchemaVersionID:     map[string]*lensPool{},
 			reversedPoolsBySchemaVersionID: map[string]*lensPool{},
 			configs:                        map[string]client.LensConfig{},
+			migrations:                     migrations,
 			txnCtxs:                        map[uint64]*txnContext{},
 		},
 	}
@@ -172,6 +187,11 @@ func (r *lensRegistry) getCtx(txn datastore.Txn, readonly bool) *txnContext {
 func (r *lensRegistry) setMigration(ctx context.Context, txnCtx *txnContext, cfg client.LensConfig) error {
 	key := core.NewSchemaVersionMigrationKey(cfg.SourceSchemaVersionID)
 
+	cfg, err := storeWasmModules(ctx, txnCtx.txn, cfg)
+	if err != nil {
+		return err
+	}
+
 	json, err := json.Marshal(cfg)
 	if err != nil {
 		return err
@@ -182,15 +202,147 @@ func (r *lensRegistry) setMigration(ctx context.Context, txnCtx *txnContext, cfg
 		return err
 	}
 
-	err = r.cacheLens(txnCtx, cfg)
+	err = r.cacheLens(ctx, txnCtx, cfg)
 	if err != nil {
 		return err
 	}
 
+	if r.migrations.HasValue() {
+		txnCtx.txn.OnSuccess(func() {
+			r.migrations.Value().Publish(events.Migration{
+				SourceSchemaVersionID: cfg.SourceSchemaVersionID,
+			})
+		})
+	}
+
+	return nil
+}
+
+// storeWasmModules stores the content of the wasm modules used by the given migration within the
+// blockstore, returning a copy of the given configuration referencing the modules by their content ID.
+//
+// Modules already referenced by content ID must either have been previously stored, or have a file at
+// their path with matching content.
+func storeWasmModules(ctx context.Context, txn datastore.Txn, cfg client.LensConfig) (client.LensConfig, error) {
+	moduleIDs := map[string]string{}
+	for _, moduleCfg := range cfg.Lenses {
+		if isNativeModule(moduleCfg.Path) {
+			continue
+		}
+		if _, ok := moduleIDs[moduleCfg.Path]; ok {
+			continue
+		}
+
+		id, hasID := cfg.WasmModuleIDs[moduleCfg.Path]
+		if hasID {
+			moduleCID, err := cid.Decode(id)
+			if err != nil {
+				return client.LensConfig{}, NewErrInvalidWasmModuleID(moduleCfg.Path, id)
+			}
+			isStored, err := txn.DAGstore().Has(ctx, moduleCID)
+			if err != nil {
+				return client.LensConfig{}, err
+			}
+			if isStored {
+				moduleIDs[moduleCfg.Path] = id
+				continue
+			}
+		}
+
+		content, err := os.ReadFile(moduleCfg.Path)
+		if err != nil {
+			if hasID {
+				return client.LensConfig{}, NewErrWasmModuleNotFound(moduleCfg.Path, id)
+			}
+			return client.LensConfig{}, err
+		}
+
+		block, err := newWasmModuleBlock(content)
+		if err != nil {
+			return client.LensConfig{}, err
+		}
+
+		if hasID && id != block.Cid().String() {
+			return client.LensConfig{}, NewErrWasmModuleNotFound(moduleCfg.Path, id)
+		}
+
+		err = txn.DAGstore().Put(ctx, block)
+		if err != nil {
+			return client.LensConfig{}, err
+		}
+		moduleIDs[moduleCfg.Path] = block.Cid().String()
+	}
+
+	if len(moduleIDs) == 0 {
+		moduleIDs = nil
+	}
+	cfg.WasmModuleIDs = moduleIDs
+	return cfg, nil
+}
+
+// wasmModuleCIDPrefix is the prefix of the content IDs of stored wasm modules.
+var wasmModuleCIDPrefix = cid.Prefix{
+	Version:  1,
+	Codec:    cid.Raw,
+	MhType:   mh.SHA2_256,
+	MhLength: -1,
+}
+
+// NewWasmModuleID returns the content ID under which the given wasm module content is stored
+// within the database.
+//
+// It may be provided via [client.LensConfig.WasmModuleIDs] to reference stored modules.
+func NewWasmModuleID(content []byte) (string, error) {
+	moduleCID, err := wasmModuleCIDPrefix.Sum(content)
+	if err != nil {
+		return "", err
+	}
+	return moduleCID.String(), nil
+}
+
+// newWasmModuleBlock returns a raw block containing the given wasm module content.
+func newWasmModuleBlock(content []byte) (blocks.Block, error) {
+	moduleCID, err := wasmModuleCIDPrefix.Sum(content)
+	if err != nil {
+		return nil, err
+	}
+	return blocks.NewBlockWithCid(content, moduleCID)
+}
+
+// loadStoredWasmModules loads the content of the wasm modules referenced by content ID within the given
+// configuration from the blockstore, caching the modules by their ID.
+func (r *lensRegistry) loadStoredWasmModules(ctx context.Context, txn datastore.Txn, cfg client.LensConfig) error {
+	r.moduleLock.Lock()
+	defer r.moduleLock.Unlock()
+
+	for path, id := range cfg.WasmModuleIDs {
+		if _, ok := r.modulesByPath[id]; ok {
+			continue
+		}
+
+		moduleCID, err := cid.Decode(id)
+		if err != nil {
+			return NewErrInvalidWasmModuleID(path, id)
+		}
+		block, err := txn.DAGstore().Get(ctx, moduleCID)
+		if err != nil {
+			if ipld.IsNotFound(err) {
+				return NewErrWasmModuleNotFound(path, id)
+			}
+			return err
+		}
+
+		lensModule, err := r.runtime.NewModule(block.RawData())
+		if err != nil {
+			return err
+		}
+		r.modulesByPath[id] = lensModule
+	}
+
 	return nil
 }
 
-func (r *lensRegistry) cacheLens(txnCtx *txnContext, cfg client.LensConfig) error {
+func (r *lensRegistry) cacheLens(ctx context.Context, txnCtx *txnContext, cfg client.LensConfig) error {
 	inversedModuleCfgs := make([]model.LensModule, len(cfg.Lenses))
 	for i, moduleCfg := range cfg.Lenses {
 		// Reverse the order of the lenses for the inverse migration.
@@ -210,9 +362,15 @@ func (r *lensRegistry) cacheLens(txnCtx *txnContext, cfg client.LensConfig) erro
 		Lens: model.Lens{
 			Lenses: inversedModuleCfgs,
 		},
+		WasmModuleIDs: cfg.WasmModuleIDs,
 	}
 
-	err := r.cachePool(txnCtx.txn, txnCtx.lensPoolsBySchemaVersionID, cfg)
+	err := r.loadStoredWasmModules(ctx, txnCtx.txn, cfg)
+	if err != nil {
+		return err
+	}
+
+	err = r.cachePool(txnCtx.txn, txnCtx.lensPoolsBySchemaVersionID, cfg)
 	if err != nil {
 		return err
 	}
@@ -287,7 +445,7 @@ func (r *lensRegistry) reloadLenses(ctx context.Context, txnCtx *txnContext) err
 			return err
 		}
 
-		err = r.cacheLens(txnCtx, cfg)
+		err = r.cacheLens(ctx, txnCtx, cfg)
 		if err != nil {
 			err = q.Close()
 			if err != nil {
@@ -475,10 +633,34 @@ var _ enumerable.Socket[LensDoc] = (*lensPipe)(nil)
 func (r *lensRegistry) newLensPipe(cfg client.LensConfig) (*lensPipe, error) {
 	socket := enumerable.NewSocket[LensDoc]()
 
-	r.moduleLock.Lock()
-	enumerable, err := config.LoadInto[LensDoc, LensDoc](r.runtime, r.modulesByPath, cfg.Lens, socket)
-	r.moduleLock.Unlock()
+	// Consecutive wasm modules are loaded together, with any native modules
+	// appended in between them.
+	var source enumerable.Enumerable[LensDoc] = socket
+	wasmModules := []model.LensModule{}
+	for _, moduleCfg := range cfg.Lenses {
+		if !isNativeModule(moduleCfg.Path) {
+			// Modules with stored content have been loaded into the module cache by their ID.
+			if id, ok := cfg.WasmModuleIDs[moduleCfg.Path]; ok {
+				moduleCfg.Path = id
+			}
+			wasmModules = append(wasmModules, moduleCfg)
+			continue
+		}
 
+		var err error
+		source, err = r.loadWasmModules(source, wasmModules)
+		if err != nil {
+			return nil, err
+		}
+		wasmModules = []model.LensModule{}
+
+		source, err = newNativeEnumerable(source, moduleCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	enumerable, err := r.loadWasmModules(source, wasmModules)
 	if err != nil {
 		return nil, err
 	}
@@ -489,6 +671,21 @@ func (r *lensRegistry) newLensPipe(cfg client.LensConfig) (*lensPipe, error) {
 	}, nil
 }
 
+// loadWasmModules appends the given wasm modules to the given source.
+func (r *lensRegistry) loadWasmModules(
+	source enumerable.Enumerable[LensDoc],
+	modules []model.LensModule,
+) (enumerable.Enumerable[LensDoc], error) {
+	if len(modules) == 0 {
+		return source, nil
+	}
+
+	r.moduleLock.Lock()
+	defer r.moduleLock.Unlock()
+
+	return config.LoadInto[LensDoc, LensDoc](r.runtime, r.modulesByPath, model.Lens{Lenses: modules}, source)
+}
+
 func (p *lensPipe) SetSource(newSource enumerable.Enumerable[LensDoc]) {
 	p.input.SetSource(newSource)
 }
@@ -0,0 +1,103 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+var (
+	pnCounterFactoryFn = MerkleCRDTFactory(
+		func(
+			mstore datastore.MultiStore,
+			schemaRoot core.CollectionSchemaVersionKey,
+			_ events.UpdateChannel,
+			fieldName string,
+		) MerkleCRDTInitFn {
+			return func(key core.DataStoreKey) MerkleCRDT {
+				return NewMerklePNCounter(
+					mstore.Datastore(),
+					mstore.Headstore(),
+					mstore.DAGstore(),
+					schemaRoot,
+					key,
+					fieldName,
+				)
+			}
+		},
+	)
+)
+
+func init() {
+	err := DefaultFactory.Register(client.PN_COUNTER, &pnCounterFactoryFn)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MerklePNCounter is a MerkleCRDT implementation of the PNCounter using MerkleClocks.
+type MerklePNCounter struct {
+	*baseMerkleCRDT
+
+	counter corecrdt.PNCounter
+}
+
+// NewMerklePNCounter creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by a PNCounter CRDT.
+func NewMerklePNCounter(
+	datastore datastore.DSReaderWriter,
+	headstore datastore.DSReaderWriter,
+	dagstore datastore.DAGStore,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) *MerklePNCounter {
+	counter := corecrdt.NewPNCounter(datastore, schemaVersionKey, key, fieldName)
+	clk := clock.NewMerkleClock(headstore, dagstore, key.ToHeadStoreKey(), counter)
+	base := &baseMerkleCRDT{clock: clk, crdt: counter}
+	return &MerklePNCounter{
+		baseMerkleCRDT: base,
+		counter:        counter,
+	}
+}
+
+// Set changes the value of the counter to the given CBOR encoded value.
+//
+// The published delta holds the difference to the current value, so that
+// changes made concurrently by other nodes are kept.
+func (mpncounter *MerklePNCounter) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+	delta, err := mpncounter.counter.Set(ctx, value)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := mpncounter.Publish(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
+
+// Value will retrieve the current value from the db.
+func (mpncounter *MerklePNCounter) Value(ctx context.Context) ([]byte, error) {
+	return mpncounter.counter.Value(ctx)
+}
+
+// Merge writes the provided delta to state using a supplied
+// merge semantic.
+func (mpncounter *MerklePNCounter) Merge(ctx context.Context, other core.Delta) error {
+	return mpncounter.counter.Merge(ctx, other)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+var (
+	rgaFactoryFn = MerkleCRDTFactory(
+		func(
+			mstore datastore.MultiStore,
+			schemaRoot core.CollectionSchemaVersionKey,
+			_ events.UpdateChannel,
+			fieldName string,
+		) MerkleCRDTInitFn {
+			return func(key core.DataStoreKey) MerkleCRDT {
+				return NewMerkleRGA(
+					mstore.Datastore(),
+					mstore.Headstore(),
+					mstore.DAGstore(),
+					schemaRoot,
+					key,
+					fieldName,
+				)
+			}
+		},
+	)
+)
+
+func init() {
+	err := DefaultFactory.Register(client.RGA, &rgaFactoryFn)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MerkleRGA is a MerkleCRDT implementation of the RGA using MerkleClocks.
+type MerkleRGA struct {
+	*baseMerkleCRDT
+
+	rga corecrdt.RGA
+}
+
+// NewMerkleRGA creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by an RGA CRDT.
+func NewMerkleRGA(
+	datastore datastore.DSReaderWriter,
+	headstore datastore.DSReaderWriter,
+	dagstore datastore.DAGStore,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) *MerkleRGA {
+	rga := corecrdt.NewRGA(datastore, schemaVersionKey, key, fieldName)
+	clk := clock.NewMerkleClock(headstore, dagstore, key.ToHeadStoreKey(), rga)
+	base := &baseMerkleCRDT{clock: clk, crdt: rga}
+	return &MerkleRGA{
+		baseMerkleCRDT: base,
+		rga:            rga,
+	}
+}
+
+// Set changes the text to the given CBOR encoded string.
+//
+// The published delta only holds the inserted and deleted characters, so that
+// edits made concurrently by other nodes are kept.
+func (mrga *MerkleRGA) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+	delta, err := mrga.rga.Set(ctx, value)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := mrga.Publish(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
+
+// Value will retrieve the current value from the db.
+func (mrga *MerkleRGA) Value(ctx context.Context) ([]byte, error) {
+	return mrga.rga.Value(ctx)
+}
+
+// Merge writes the provided delta to state using a supplied
+// merge semantic.
+func (mrga *MerkleRGA) Merge(ctx context.Context, other core.Delta) error {
+	return mrga.rga.Merge(ctx, other)
+}
@@ -13,10 +13,12 @@
 package net
 
 import (
+	"strings"
 	"time"
 
 	cconnmgr "github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	ma "github.com/multiformats/go-multiaddr"
 	"google.golang.org/grpc"
@@ -26,13 +28,15 @@ import (
 
 // Options is the node options.
 type Options struct {
-	ListenAddrs       []ma.Multiaddr
-	PrivateKey        crypto.PrivKey
-	EnablePubSub      bool
-	EnableRelay       bool
-	GRPCServerOptions []grpc.ServerOption
-	GRPCDialOptions   []grpc.DialOption
-	ConnManager       cconnmgr.ConnManager
+	ListenAddrs                []ma.Multiaddr
+	PrivateKey                 crypto.PrivKey
+	EnablePubSub               bool
+	EnableRelay                bool
+	EnableMigrationReplication bool
+	MigrationTrustedPeers      []peer.ID
+	GRPCServerOptions          []grpc.ServerOption
+	GRPCDialOptions            []grpc.DialOption
+	ConnManager                cconnmgr.ConnManager
 }
 
 type NodeOpt func(*Options) error
@@ -70,6 +74,13 @@ func WithConfig(cfg *config.Config) NodeOpt {
 		}
 		opt.EnableRelay = cfg.Net.RelayEnabled
 		opt.EnablePubSub = cfg.Net.PubSubEnabled
+		opt.EnableMigrationReplication = cfg.Net.MigrationReplicationEnabled
+		if len(cfg.Net.MigrationTrustedPeers) > 0 {
+			err = WithMigrationTrustedPeers(strings.Split(cfg.Net.MigrationTrustedPeers, ",")...)(opt)
+			if err != nil {
+				return err
+			}
+		}
 		opt.ConnManager, err = NewConnManager(100, 400, time.Second*20)
 		if err != nil {
 			return err
@@ -102,6 +113,33 @@ func WithEnableRelay(enable bool) NodeOpt {
 	}
 }
 
+// WithEnableMigrationReplication enables the replication of schema migrations to and from peers
+// on the pubsub network.
+//
+// Migrations, along with the schema versions that they migrate to, are only accepted from
+// replicators and the peers set by [WithMigrationTrustedPeers].
+func WithEnableMigrationReplication(enable bool) NodeOpt {
+	return func(opt *Options) error {
+		opt.EnableMigrationReplication = enable
+		return nil
+	}
+}
+
+// WithMigrationTrustedPeers sets the IDs of the peers, in addition to the replicators, from which
+// replicated schema migrations are accepted.
+func WithMigrationTrustedPeers(ids ...string) NodeOpt {
+	return func(opt *Options) error {
+		for _, id := range ids {
+			peerID, err := peer.Decode(id)
+			if err != nil {
+				return err
+			}
+			opt.MigrationTrustedPeers = append(opt.MigrationTrustedPeers, peerID)
+		}
+		return nil
+	}
+}
+
 // ListenP2PAddrStrings sets the address to listen on given as strings.
 func WithListenP2PAddrStrings(addrs ...string) NodeOpt {
 	return func(opt *Options) error {
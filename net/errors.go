@@ -26,6 +26,9 @@ const (
 	errReplicatorExists        = "replicator already exists for %s with peerID %s"
 	errReplicatorDocKey        = "failed to get dockey for replicator %s with peerID %s"
 	errReplicatorCollections   = "failed to get collections for replicator"
+	errUntrustedMigrationPeer  = "migration received from untrusted peer %s"
+	errInvalidMigrationSig     = "invalid signature on migration from peer %s"
+	errReplicatedSchemaVersion = "replicated schema version %s could not be recreated"
 )
 
 var (
@@ -34,6 +37,7 @@ var (
 	ErrPushLogWaitTimeout       = errors.New("waiting for pushlog timed out")
 	ErrNilDB                    = errors.New("database object can't be nil")
 	ErrNilUpdateChannel         = errors.New("tried to subscribe to update channel, but update channel is nil")
+	ErrNilMigrationChannel      = errors.New("tried to subscribe to migration channel, but migration channel is nil")
 	ErrSelfTargetForReplicator  = errors.New("can't target ourselves as a replicator")
 )
 
@@ -64,3 +68,15 @@ func NewErrReplicatorDocKey(inner error, collection string, peerID peer.ID, kv .
 func NewErrReplicatorCollections(inner error, kv ...errors.KV) error {
 	return errors.Wrap(errReplicatorCollections, inner, kv...)
 }
+
+func NewErrUntrustedMigrationPeer(peerID peer.ID, kv ...errors.KV) error {
+	return errors.New(fmt.Sprintf(errUntrustedMigrationPeer, peerID), kv...)
+}
+
+func NewErrInvalidMigrationSignature(inner error, peerID peer.ID, kv ...errors.KV) error {
+	return errors.Wrap(fmt.Sprintf(errInvalidMigrationSig, peerID), inner, kv...)
+}
+
+func NewErrReplicatedSchemaVersionMismatch(schemaVersionID string, kv ...errors.KV) error {
+	return errors.New(fmt.Sprintf(errReplicatedSchemaVersion, schemaVersionID), kv...)
+}
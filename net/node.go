@@ -164,6 +164,10 @@ func NewNode(
 		cancel()
 		return nil, fin.Cleanup(err)
 	}
+	peer.replicateMigrations = options.EnableMigrationReplication
+	for _, id := range options.MigrationTrustedPeers {
+		peer.migrationTrustedPeers[id] = struct{}{}
+	}
 
 	n := &Node{
 		// WARNING: The current usage of these channels means that consumers of them
@@ -31,6 +31,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	peerstore "github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/routing"
+	rpc "github.com/sourcenetwork/go-libp2p-pubsub-rpc"
 	"google.golang.org/grpc"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -56,6 +57,16 @@ type Peer struct {
 	db            client.DB
 	updateChannel chan events.Update
 
+	// replicateMigrations determines whether migrations are replicated to and from peers.
+	replicateMigrations bool
+	// migrationTrustedPeers contains the peers, in addition to the replicators, from which
+	// replicated migrations are accepted.
+	migrationTrustedPeers map[peer.ID]struct{}
+	migrationChannel      chan events.Migration
+	migrationTopic        *rpc.Topic
+	// migrationLock ensures that local and replicated migrations are handled one at a time.
+	migrationLock sync.Mutex
+
 	host host.Host
 	dht  routing.Routing
 	ps   *pubsub.PubSub
@@ -111,6 +122,8 @@ func NewPeer(
 		sendJobs:       make(chan *dagJob),
 		replicators:    make(map[string]map[peer.ID]struct{}),
 		queuedChildren: newCidSafeSet(),
+
+		migrationTrustedPeers: make(map[peer.ID]struct{}),
 	}
 	var err error
 	p.server, err = newServer(p, db, dialOptions...)
@@ -175,6 +188,13 @@ func (p *Peer) Start() error {
 
 		log.Info(p.ctx, "Starting internal broadcaster for pubsub network")
 		go p.handleBroadcastLoop()
+
+		if p.replicateMigrations {
+			err = p.startMigrationReplication()
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// register the P2P gRPC server
@@ -224,6 +244,8 @@ func (p *Peer) Close() {
 		p.db.Events().Updates.Value().Unsubscribe(p.updateChannel)
 	}
 
+	p.stopMigrationReplication()
+
 	if err := p.bserv.Close(); err != nil {
 		log.ErrorE(p.ctx, "Error closing block service", err)
 	}
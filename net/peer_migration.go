@@ -0,0 +1,589 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	libpeer "github.com/libp2p/go-libp2p/core/peer"
+	rpc "github.com/sourcenetwork/go-libp2p-pubsub-rpc"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+// migrationsTopic is the pubsub topic on which schema migrations are replicated.
+const migrationsTopic = "migrations"
+
+// migrationMessage is the message published to the migrations topic.
+//
+// Pubsub messages may be relayed by any peer, so the payload is signed by the peer that
+// created it, allowing its receivers to verify who created it.
+type migrationMessage struct {
+	// Creator is the ID of the peer that created the payload.
+	Creator string
+	// Payload is the json encoded [migrationPayload].
+	Payload []byte
+	// Signature is the signature of the payload by the creator.
+	Signature []byte
+}
+
+// migrationPayload is the replicated migration, along with the destination schema version
+// that it migrates to.
+type migrationPayload struct {
+	Config client.LensConfig
+	// Schema is the description of the destination schema version of the migration, it will
+	// be nil if the creator did not have it.
+	Schema *client.SchemaDescription `json:",omitempty"`
+	// Clock is the logical clock of the migration, see [migrationClock].
+	Clock uint64
+}
+
+// migrationClock is the logical (Lamport) clock of the migration set from a schema version.
+//
+// Whenever a migration is set locally the clock is incremented, a replicated migration is
+// only set if its clock is later than that of the local migration.  Ties are broken by the
+// ID of the creating peer, so that peers that concurrently set different migrations from the
+// same schema version converge on the same migration without overwriting each other indefinitely.
+type migrationClock struct {
+	Clock   uint64
+	Creator string
+	// ConfigHash is the hash of the migration configuration that the clock is for, a local
+	// migration with a different hash has been set since the clock was last updated.
+	ConfigHash []byte
+}
+
+// isBefore returns true if the given clock is later than this one.
+func (c migrationClock) isBefore(other migrationClock) bool {
+	if c.Clock != other.Clock {
+		return c.Clock < other.Clock
+	}
+	return c.Creator < other.Creator
+}
+
+// startMigrationReplication subscribes to the migrations topic on the pubsub network, and starts
+// publishing the migrations set on the local database to it.
+func (p *Peer) startMigrationReplication() error {
+	if !p.db.Events().Migrations.HasValue() {
+		return ErrNilMigrationChannel
+	}
+
+	migrationChannel, err := p.db.Events().Migrations.Value().Subscribe()
+	if err != nil {
+		return err
+	}
+	p.migrationChannel = migrationChannel
+
+	topic, err := rpc.NewTopic(p.ctx, p.ps, p.host.ID(), migrationsTopic, true)
+	if err != nil {
+		return err
+	}
+	topic.SetMessageHandler(p.handleMigrationMessage)
+	p.migrationTopic = topic
+
+	log.Info(p.ctx, "Starting migration replication on the pubsub network")
+	go p.handleMigrationBroadcastLoop()
+
+	return nil
+}
+
+// stopMigrationReplication unsubscribes from the migrations topic and the local migration events.
+func (p *Peer) stopMigrationReplication() {
+	if p.migrationTopic != nil {
+		if err := p.migrationTopic.Close(); err != nil {
+			log.ErrorE(p.ctx, "Error closing migrations topic", err)
+		}
+	}
+	if p.migrationChannel != nil {
+		p.db.Events().Migrations.Value().Unsubscribe(p.migrationChannel)
+	}
+}
+
+// handleMigrationBroadcastLoop publishes the migrations set on the local database to the
+// migrations topic.
+func (p *Peer) handleMigrationBroadcastLoop() {
+	for {
+		migration, isOpen := <-p.migrationChannel
+		if !isOpen {
+			return
+		}
+
+		err := p.publishMigration(p.ctx, migration.SourceSchemaVersionID)
+		if err != nil {
+			log.ErrorE(
+				p.ctx,
+				"Error while publishing migration",
+				err,
+				logging.NewKV("SourceSchemaVersionID", migration.SourceSchemaVersionID),
+			)
+		}
+	}
+}
+
+// publishMigration publishes the local migration from the given schema version to the
+// migrations topic, along with its destination schema version.
+//
+// Migrations that were set by the replication of a migration from another peer are not
+// published, as the pubsub network has already delivered it to all subscribed peers.
+func (p *Peer) publishMigration(ctx context.Context, sourceSchemaVersionID string) error {
+	p.migrationLock.Lock()
+	defer p.migrationLock.Unlock()
+
+	txn, err := p.db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	cfg, hasCfg, err := p.getMigration(ctx, txn, sourceSchemaVersionID)
+	if err != nil {
+		return err
+	}
+	if !hasCfg {
+		return nil
+	}
+	configHash, err := hashMigration(cfg)
+	if err != nil {
+		return err
+	}
+
+	clock, err := getMigrationClock(ctx, txn, sourceSchemaVersionID)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(clock.ConfigHash, configHash) {
+		return nil
+	}
+	clock = migrationClock{
+		Clock:      clock.Clock + 1,
+		Creator:    p.host.ID().String(),
+		ConfigHash: configHash,
+	}
+	err = setMigrationClock(ctx, txn, sourceSchemaVersionID, clock)
+	if err != nil {
+		return err
+	}
+
+	payload := migrationPayload{
+		Config: cfg,
+		Clock:  clock.Clock,
+	}
+	schema, hasSchema, err := p.getSchemaVersion(ctx, txn, cfg.DestinationSchemaVersionID)
+	if err != nil {
+		return err
+	}
+	if hasSchema {
+		payload.Schema = &schema
+	}
+
+	data, err := p.signMigration(payload)
+	if err != nil {
+		return err
+	}
+
+	err = txn.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.migrationTopic.Publish(ctx, data, rpc.WithIgnoreResponse(true))
+	if err != nil {
+		return errors.Wrap("failed publishing migration", err)
+	}
+
+	return nil
+}
+
+// handleMigrationMessage sets the migration received from the migrations topic on the local database.
+//
+// Migrations are only accepted if they were created by a replicator or a trusted peer, and if they are
+// later than the local migration from the same schema version.  The destination schema version of the
+// migration is recreated locally if the local collections of its schema are at the source version of the
+// migration.  The content of any wasm modules used by the migration that are not stored locally is
+// fetched from the network before setting it.
+func (p *Peer) handleMigrationMessage(from libpeer.ID, topic string, msg []byte) ([]byte, error) {
+	log.Debug(
+		p.ctx,
+		"Handling new migration message",
+		logging.NewKV("SenderID", from),
+		logging.NewKV("Topic", topic),
+	)
+
+	// Migrations published by this peer have already been set locally.
+	if from == p.host.ID() {
+		return nil, nil
+	}
+
+	payload, creator, err := p.verifyMigration(msg)
+	if err != nil {
+		log.ErrorE(p.ctx, "Rejected migration message", err, logging.NewKV("SenderID", from))
+		return nil, err
+	}
+
+	err = p.setReplicatedMigration(payload, creator)
+	if err != nil {
+		log.ErrorE(
+			p.ctx,
+			"Failed to set replicated migration",
+			err,
+			logging.NewKV("SourceSchemaVersionID", payload.Config.SourceSchemaVersionID),
+		)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// setReplicatedMigration sets the given replicated migration, created by the given peer, if it is
+// later than the local migration.
+func (p *Peer) setReplicatedMigration(payload migrationPayload, creator libpeer.ID) error {
+	p.migrationLock.Lock()
+	defer p.migrationLock.Unlock()
+
+	cfg := payload.Config
+	txn, err := p.db.NewTxn(p.ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(p.ctx)
+
+	localClock, err := getMigrationClock(p.ctx, txn, cfg.SourceSchemaVersionID)
+	if err != nil {
+		return err
+	}
+	clock := migrationClock{
+		Clock:   payload.Clock,
+		Creator: creator.String(),
+	}
+	if !localClock.isBefore(clock) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, DAGSyncTimeout)
+	defer cancel()
+
+	for _, id := range cfg.WasmModuleIDs {
+		moduleCID, err := cid.Decode(id)
+		if err != nil {
+			return err
+		}
+		// The block service stores the fetched block in the local blockstore, from which the
+		// lens registry will load it.
+		_, err = p.bserv.GetBlock(ctx, moduleCID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if payload.Schema != nil {
+		err = p.replicateSchemaVersion(ctx, txn, cfg, *payload.Schema)
+		if err != nil {
+			return err
+		}
+	}
+
+	existingCfg, hasExisting, err := p.getMigration(ctx, txn, cfg.SourceSchemaVersionID)
+	if err != nil {
+		return err
+	}
+	configHash, err := hashMigration(cfg)
+	if err != nil {
+		return err
+	}
+	existingHash, err := hashMigration(existingCfg)
+	if err != nil {
+		return err
+	}
+	if !hasExisting || !bytes.Equal(configHash, existingHash) {
+		err = p.db.WithTxn(txn).SetMigration(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		// The migration is read back, as the stored configuration is what will be compared
+		// against when the local migration events are handled.
+		cfg, _, err = p.getMigration(ctx, txn, cfg.SourceSchemaVersionID)
+		if err != nil {
+			return err
+		}
+		configHash, err = hashMigration(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	clock.ConfigHash = configHash
+	err = setMigrationClock(ctx, txn, cfg.SourceSchemaVersionID, clock)
+	if err != nil {
+		return err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// replicateSchemaVersion recreates the given destination schema version of the given migration
+// within the given transaction, and sets it as the default version of the collections of its schema.
+//
+// The schema version is only recreated if it is unknown to the local node, and the collections of its
+// schema are at the source version of the migration.  The schema version is recreated by patching the
+// source version, the resulting version must have the ID of the given schema version.
+func (p *Peer) replicateSchemaVersion(
+	ctx context.Context,
+	txn datastore.Txn,
+	cfg client.LensConfig,
+	schema client.SchemaDescription,
+) error {
+	if schema.VersionID != cfg.DestinationSchemaVersionID {
+		return nil
+	}
+
+	store := p.db.WithTxn(txn)
+	_, hasSchema, err := p.getSchemaVersion(ctx, txn, schema.VersionID)
+	if err != nil {
+		return err
+	}
+	if hasSchema {
+		return nil
+	}
+
+	cols, err := store.GetCollectionsBySchemaRoot(ctx, schema.Root)
+	if err != nil {
+		return err
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	for _, col := range cols {
+		if col.Schema().VersionID != cfg.SourceSchemaVersionID {
+			return nil
+		}
+	}
+
+	source := cols[0].Schema()
+	sourceFieldsByID := map[client.FieldID]client.FieldDescription{}
+	for _, field := range source.Fields {
+		sourceFieldsByID[field.ID] = field
+	}
+
+	proposed := schema
+	proposed.VersionID = source.VersionID
+	proposed.Fields = make([]client.FieldDescription, len(schema.Fields))
+	for i, field := range schema.Fields {
+		sourceField, ok := sourceFieldsByID[field.ID]
+		if ok && (field.ID != 0 || field.Name == request.KeyFieldName) {
+			// The CRDT type of existing fields may not be changed, it will be defaulted
+			// the same way as it was on the creating peer when the version is created.
+			field.Typ = sourceField.Typ
+		} else {
+			// Fields new to this version are given their IDs when the version is created.
+			field.ID = 0
+		}
+		proposed.Fields[i] = field
+	}
+
+	patch, err := json.Marshal([]map[string]any{
+		{
+			"op":    "replace",
+			"path":  "/" + schema.Name,
+			"value": proposed,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = store.PatchSchema(ctx, string(patch), true)
+	if err != nil {
+		return err
+	}
+
+	_, hasSchema, err = p.getSchemaVersion(ctx, txn, schema.VersionID)
+	if err != nil {
+		return err
+	}
+	if !hasSchema {
+		return NewErrReplicatedSchemaVersionMismatch(schema.VersionID)
+	}
+
+	return nil
+}
+
+// verifyMigration decodes the given migration message, verifying that it was created by a trusted
+// peer, and returns its payload along with the ID of the creating peer.
+func (p *Peer) verifyMigration(msg []byte) (migrationPayload, libpeer.ID, error) {
+	var message migrationMessage
+	err := json.Unmarshal(msg, &message)
+	if err != nil {
+		return migrationPayload{}, "", err
+	}
+
+	creator, err := libpeer.Decode(message.Creator)
+	if err != nil {
+		return migrationPayload{}, "", err
+	}
+	if !p.isMigrationTrustedPeer(creator) {
+		return migrationPayload{}, "", NewErrUntrustedMigrationPeer(creator)
+	}
+
+	pubKey, err := creator.ExtractPublicKey()
+	if err != nil {
+		return migrationPayload{}, "", NewErrInvalidMigrationSignature(err, creator)
+	}
+	isValid, err := pubKey.Verify(message.Payload, message.Signature)
+	if err != nil {
+		return migrationPayload{}, "", NewErrInvalidMigrationSignature(err, creator)
+	}
+	if !isValid {
+		return migrationPayload{}, "", NewErrInvalidMigrationSignature(nil, creator)
+	}
+
+	var payload migrationPayload
+	err = json.Unmarshal(message.Payload, &payload)
+	if err != nil {
+		return migrationPayload{}, "", err
+	}
+
+	return payload, creator, nil
+}
+
+// signMigration returns the json encoded migration message of the given payload, signed by the
+// host of this peer.
+func (p *Peer) signMigration(payload migrationPayload) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := p.host.Peerstore().PrivKey(p.host.ID()).Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(migrationMessage{
+		Creator:   p.host.ID().String(),
+		Payload:   data,
+		Signature: signature,
+	})
+}
+
+// isMigrationTrustedPeer returns true if migrations created by the given peer may be set on the
+// local database.
+//
+// Replicators and the peers configured as trusted migration peers are trusted.
+func (p *Peer) isMigrationTrustedPeer(id libpeer.ID) bool {
+	if _, ok := p.migrationTrustedPeers[id]; ok {
+		return true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, reps := range p.replicators {
+		if _, ok := reps[id]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getMigration returns the local migration from the given schema version, if there is one.
+func (p *Peer) getMigration(
+	ctx context.Context,
+	txn datastore.Txn,
+	sourceSchemaVersionID string,
+) (client.LensConfig, bool, error) {
+	cfgs, err := p.db.LensRegistry().WithTxn(txn).Config(ctx)
+	if err != nil {
+		return client.LensConfig{}, false, err
+	}
+
+	for _, cfg := range cfgs {
+		if cfg.SourceSchemaVersionID == sourceSchemaVersionID {
+			return cfg, true, nil
+		}
+	}
+
+	return client.LensConfig{}, false, nil
+}
+
+// getSchemaVersion returns the local schema version with the given ID, if there is one.
+func (p *Peer) getSchemaVersion(
+	ctx context.Context,
+	txn datastore.Txn,
+	schemaVersionID string,
+) (client.SchemaDescription, bool, error) {
+	schema, err := p.db.WithTxn(txn).GetSchemaByVersionID(ctx, schemaVersionID)
+	if errors.Is(err, ds.ErrNotFound) {
+		return client.SchemaDescription{}, false, nil
+	}
+	if err != nil {
+		return client.SchemaDescription{}, false, err
+	}
+	return schema, true, nil
+}
+
+// getMigrationClock returns the clock of the migration from the given schema version.
+//
+// The zero clock is returned if the migration has never been replicated.
+func getMigrationClock(
+	ctx context.Context,
+	txn datastore.Txn,
+	sourceSchemaVersionID string,
+) (migrationClock, error) {
+	key := core.NewP2PMigrationClockKey(sourceSchemaVersionID)
+	data, err := txn.Systemstore().Get(ctx, key.ToDS())
+	if errors.Is(err, ds.ErrNotFound) {
+		return migrationClock{}, nil
+	}
+	if err != nil {
+		return migrationClock{}, err
+	}
+
+	var clock migrationClock
+	err = json.Unmarshal(data, &clock)
+	if err != nil {
+		return migrationClock{}, err
+	}
+	return clock, nil
+}
+
+// setMigrationClock sets the clock of the migration from the given schema version.
+func setMigrationClock(
+	ctx context.Context,
+	txn datastore.Txn,
+	sourceSchemaVersionID string,
+	clock migrationClock,
+) error {
+	data, err := json.Marshal(clock)
+	if err != nil {
+		return err
+	}
+	key := core.NewP2PMigrationClockKey(sourceSchemaVersionID)
+	return txn.Systemstore().Put(ctx, key.ToDS(), data)
+}
+
+// hashMigration returns the hash of the given migration configuration.
+func hashMigration(cfg client.LensConfig) ([]byte, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	return hash[:], nil
+}
@@ -0,0 +1,309 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lens-vm/lens/host-go/config/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/config"
+	"github.com/sourcenetwork/defradb/datastore/memory"
+	"github.com/sourcenetwork/defradb/db"
+	"github.com/sourcenetwork/defradb/lens"
+	netutils "github.com/sourcenetwork/defradb/net/utils"
+)
+
+func newMigrationReplicatingTestNode(
+	ctx context.Context,
+	t *testing.T,
+	trustedPeers ...string,
+) (client.DB, *Node) {
+	store := memory.NewDatastore(ctx)
+	db, err := db.NewDB(ctx, store, db.WithUpdateEvents())
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig()
+	cfg.Net.P2PAddress = randomMultiaddr
+	cfg.Net.MigrationReplicationEnabled = true
+	cfg.Net.MigrationTrustedPeers = strings.Join(trustedPeers, ",")
+
+	n, err := NewNode(
+		ctx,
+		db,
+		WithConfig(cfg),
+	)
+	require.NoError(t, err)
+
+	err = n.Start()
+	require.NoError(t, err)
+
+	return db, n
+}
+
+func TestMigrationReplication_WithNativeModules_ReplicatesToPeer(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newMigrationReplicatingTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newMigrationReplicatingTestNode(ctx, t, n1.PeerID().String())
+	defer n2.Close()
+
+	addrs, err := netutils.ParsePeers([]string{n1.host.Addrs()[0].String() + "/p2p/" + n1.PeerID().String()})
+	require.NoError(t, err)
+	n2.Bootstrap(addrs)
+
+	// Migrations published before the peers have joined each other's topic would not be received.
+	require.Eventually(t, func() bool {
+		return len(n1.ps.ListPeers(migrationsTopic)) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cfg := client.LensConfig{
+		SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+		DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+		Lens: model.Lens{
+			Lenses: []model.LensModule{
+				lens.NewRenameFieldModule("name", "fullName"),
+			},
+		},
+	}
+	err = db1.SetMigration(ctx, cfg)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		hasMigration, err := db2.LensRegistry().HasMigration(ctx, cfg.SourceSchemaVersionID)
+		require.NoError(t, err)
+		return hasMigration
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cfgs, err := db2.LensRegistry().Config(ctx)
+	require.NoError(t, err)
+	require.Len(t, cfgs, 1)
+	require.Equal(t, cfg.DestinationSchemaVersionID, cfgs[0].DestinationSchemaVersionID)
+	require.Equal(t, cfg.Lenses[0].Path, cfgs[0].Lenses[0].Path)
+	require.Equal(t, cfg.Lenses[0].Arguments, cfgs[0].Lenses[0].Arguments)
+}
+
+func TestMigrationReplication_WithReplicationDisabled_DoesNotReplicateToPeer(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newMigrationReplicatingTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+	err := n2.Start()
+	require.NoError(t, err)
+
+	addrs, err := netutils.ParsePeers([]string{n1.host.Addrs()[0].String() + "/p2p/" + n1.PeerID().String()})
+	require.NoError(t, err)
+	n2.Bootstrap(addrs)
+
+	err = db1.SetMigration(ctx, client.LensConfig{
+		SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+		DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+		Lens: model.Lens{
+			Lenses: []model.LensModule{
+				lens.NewRenameFieldModule("name", "fullName"),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Empty(t, n1.ps.ListPeers(migrationsTopic))
+	cfgs, err := db2.LensRegistry().Config(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cfgs)
+}
+
+func TestMigrationReplication_FromUntrustedPeer_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	_, n1 := newMigrationReplicatingTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newMigrationReplicatingTestNode(ctx, t)
+	defer n2.Close()
+
+	msg, err := n1.Peer.signMigration(migrationPayload{
+		Config: client.LensConfig{
+			SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+			DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+			Lens: model.Lens{
+				Lenses: []model.LensModule{
+					lens.NewRenameFieldModule("name", "fullName"),
+				},
+			},
+		},
+		Clock: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = n2.Peer.handleMigrationMessage(n1.PeerID(), migrationsTopic, msg)
+	require.ErrorContains(t, err, "migration received from untrusted peer")
+
+	cfgs, err := db2.LensRegistry().Config(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cfgs)
+}
+
+func TestMigrationReplication_WithInvalidSignature_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	_, n1 := newMigrationReplicatingTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newMigrationReplicatingTestNode(ctx, t, n1.PeerID().String())
+	defer n2.Close()
+
+	// Sign the migration with the key of n2, claiming that it was created by n1.
+	msg, err := n2.Peer.signMigration(migrationPayload{
+		Config: client.LensConfig{
+			SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+			DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+		},
+		Clock: 1,
+	})
+	require.NoError(t, err)
+	msg = []byte(strings.Replace(string(msg), n2.PeerID().String(), n1.PeerID().String(), 1))
+
+	_, err = n2.Peer.handleMigrationMessage(n1.PeerID(), migrationsTopic, msg)
+	require.ErrorContains(t, err, "invalid signature on migration from peer")
+
+	cfgs, err := db2.LensRegistry().Config(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cfgs)
+}
+
+func TestMigrationReplication_WithConcurrentMigrations_Converges(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newMigrationReplicatingTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newMigrationReplicatingTestNode(ctx, t, n1.PeerID().String())
+	defer n2.Close()
+	n1.Peer.migrationTrustedPeers[n2.PeerID()] = struct{}{}
+
+	sourceSchemaVersionID := "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq"
+	cfg1 := client.LensConfig{
+		SourceSchemaVersionID:      sourceSchemaVersionID,
+		DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+		Lens: model.Lens{
+			Lenses: []model.LensModule{
+				lens.NewRenameFieldModule("name", "fullName"),
+			},
+		},
+	}
+	cfg2 := client.LensConfig{
+		SourceSchemaVersionID:      sourceSchemaVersionID,
+		DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+		Lens: model.Lens{
+			Lenses: []model.LensModule{
+				lens.NewRenameFieldModule("name", "firstName"),
+			},
+		},
+	}
+
+	// The nodes are not connected, so the migrations are concurrent and neither is received
+	// by the other until the messages are handled below.
+	require.NoError(t, db1.SetMigration(ctx, cfg1))
+	require.NoError(t, db2.SetMigration(ctx, cfg2))
+	waitForMigrationClock(ctx, t, n1, sourceSchemaVersionID)
+	waitForMigrationClock(ctx, t, n2, sourceSchemaVersionID)
+
+	msg1, err := n1.Peer.signMigration(migrationPayload{Config: cfg1, Clock: 1})
+	require.NoError(t, err)
+	msg2, err := n2.Peer.signMigration(migrationPayload{Config: cfg2, Clock: 1})
+	require.NoError(t, err)
+
+	_, err = n2.Peer.handleMigrationMessage(n1.PeerID(), migrationsTopic, msg1)
+	require.NoError(t, err)
+	_, err = n1.Peer.handleMigrationMessage(n2.PeerID(), migrationsTopic, msg2)
+	require.NoError(t, err)
+
+	// Ties are broken by the ID of the creating peer.
+	expected := cfg1
+	if n1.PeerID().String() < n2.PeerID().String() {
+		expected = cfg2
+	}
+	for _, db := range []client.DB{db1, db2} {
+		cfgs, err := db.LensRegistry().Config(ctx)
+		require.NoError(t, err)
+		require.Len(t, cfgs, 1)
+		require.Equal(t, expected.Lenses[0].Arguments, cfgs[0].Lenses[0].Arguments)
+	}
+}
+
+func TestMigrationReplication_WithSchemaPatch_ReplicatesSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newMigrationReplicatingTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newMigrationReplicatingTestNode(ctx, t, n1.PeerID().String())
+	defer n2.Close()
+
+	for _, db := range []client.DB{db1, db2} {
+		_, err := db.AddSchema(ctx, `type User { name: String }`)
+		require.NoError(t, err)
+	}
+
+	addrs, err := netutils.ParsePeers([]string{n1.host.Addrs()[0].String() + "/p2p/" + n1.PeerID().String()})
+	require.NoError(t, err)
+	n2.Bootstrap(addrs)
+
+	require.Eventually(t, func() bool {
+		return len(n1.ps.ListPeers(migrationsTopic)) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	col1, err := db1.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	sourceSchemaVersionID := col1.Schema().VersionID
+
+	err = db1.PatchSchema(
+		ctx,
+		`[{ "op": "add", "path": "/User/Fields/-", "value": {"Name": "email", "Kind": "String"} }]`,
+		true,
+	)
+	require.NoError(t, err)
+
+	col1, err = db1.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	err = db1.SetMigration(ctx, client.LensConfig{
+		SourceSchemaVersionID:      sourceSchemaVersionID,
+		DestinationSchemaVersionID: col1.Schema().VersionID,
+		Lens: model.Lens{
+			Lenses: []model.LensModule{
+				lens.NewSetDefaultModule("email", "unknown"),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		col2, err := db2.GetCollectionByName(ctx, "User")
+		require.NoError(t, err)
+		return col2.Schema().VersionID == col1.Schema().VersionID
+	}, 10*time.Second, 100*time.Millisecond)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	require.Equal(t, col1.Schema(), col2.Schema())
+}
+
+// waitForMigrationClock waits until the given node has published the local migration from the
+// given schema version.
+func waitForMigrationClock(ctx context.Context, t *testing.T, n *Node, sourceSchemaVersionID string) {
+	require.Eventually(t, func() bool {
+		txn, err := n.db.NewTxn(ctx, true)
+		require.NoError(t, err)
+		defer txn.Discard(ctx)
+		clock, err := getMigrationClock(ctx, txn, sourceSchemaVersionID)
+		require.NoError(t, err)
+		return clock.Clock > 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
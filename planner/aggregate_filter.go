@@ -0,0 +1,111 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// aggregateFilterNode filters the documents (or groups) yielded by its source by the
+// values of their aggregates.
+//
+// It must be placed above the aggregate nodes, as the aggregates must have been computed
+// before they can be filtered.
+type aggregateFilterNode struct {
+	documentIterator
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	filter *mapper.Filter
+
+	execInfo aggregateFilterExecInfo
+}
+
+type aggregateFilterExecInfo struct {
+	// Total number of times aggregateFilterNode was executed.
+	iterations uint64
+
+	// Total number of times a document matched the filter.
+	filterMatches uint64
+}
+
+// AggregateFilter creates a new aggregateFilterNode from the aggregate filter of the given
+// select, returning nil if it has no aggregate filter.
+func (p *Planner) AggregateFilter(parsed *mapper.Select) (*aggregateFilterNode, error) {
+	if parsed.AggregateFilter == nil {
+		return nil, nil // nothing to do
+	}
+	return &aggregateFilterNode{
+		p:         p,
+		filter:    parsed.AggregateFilter,
+		docMapper: docMapper{parsed.DocumentMapping},
+	}, nil
+}
+
+func (n *aggregateFilterNode) Kind() string {
+	return "aggregateFilterNode"
+}
+
+func (n *aggregateFilterNode) Init() error { return n.plan.Init() }
+
+func (n *aggregateFilterNode) Start() error { return n.plan.Start() }
+
+func (n *aggregateFilterNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+
+func (n *aggregateFilterNode) Close() error { return n.plan.Close() }
+
+func (n *aggregateFilterNode) Source() planNode { return n.plan }
+
+func (n *aggregateFilterNode) Next() (bool, error) {
+	for {
+		n.execInfo.iterations++
+
+		hasNext, err := n.plan.Next()
+		if err != nil || !hasNext {
+			return hasNext, err
+		}
+
+		n.currentValue = n.plan.Value()
+		passes, err := mapper.RunFilter(n.currentValue, n.filter)
+		if err != nil {
+			return false, err
+		}
+
+		if passes {
+			n.execInfo.filterMatches++
+			return true, nil
+		}
+	}
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *aggregateFilterNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return map[string]any{
+			filterLabel: n.filter.ToMap(n.documentMapping),
+		}, nil
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations":    n.execInfo.iterations,
+			"filterMatches": n.execInfo.filterMatches,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
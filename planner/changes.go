@@ -0,0 +1,154 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// changeScanNode is the request plan graph node responsible for scanning through
+// the changes recorded in the change log of a collection.
+type changeScanNode struct {
+	documentIterator
+	docMapper
+
+	planner *Planner
+
+	changeSelect *mapper.ChangeSelect
+
+	changes []client.Change
+	index   int
+
+	execInfo changeScanExecInfo
+}
+
+type changeScanExecInfo struct {
+	// Total number of times change scan was issued.
+	iterations uint64
+}
+
+func (p *Planner) ChangeScan(changeSelect *mapper.ChangeSelect) *changeScanNode {
+	return &changeScanNode{
+		planner:      p,
+		changeSelect: changeSelect,
+		docMapper:    docMapper{changeSelect.DocumentMapping},
+	}
+}
+
+func (p *Planner) ChangeSelect(changeSelect *mapper.ChangeSelect) (planNode, error) {
+	changeScan := p.ChangeScan(changeSelect)
+	return p.SelectFromSource(&changeSelect.Select, changeScan, false, nil)
+}
+
+func (n *changeScanNode) Kind() string {
+	return "changeScanNode"
+}
+
+func (n *changeScanNode) Init() error {
+	col, err := n.planner.db.GetCollectionByName(n.planner.ctx, n.changeSelect.Collection)
+	if err != nil {
+		return err
+	}
+
+	var after, limit uint64
+	if n.changeSelect.After.HasValue() {
+		after = n.changeSelect.After.Value()
+	}
+	if n.changeSelect.Limit.HasValue() {
+		limit = n.changeSelect.Limit.Value()
+	}
+
+	changes, err := col.WithTxn(n.planner.txn).GetChanges(n.planner.ctx, after, limit)
+	if err != nil {
+		return err
+	}
+
+	n.changes = changes
+	n.index = 0
+	return nil
+}
+
+func (n *changeScanNode) Start() error {
+	return nil
+}
+
+// Spans is a no-op as the changes are scoped by the collection, sequence and
+// limit of the request.
+func (n *changeScanNode) Spans(spans core.Spans) {}
+
+func (n *changeScanNode) Close() error {
+	return nil
+}
+
+func (n *changeScanNode) Source() planNode { return nil }
+
+func (n *changeScanNode) simpleExplain() (map[string]any, error) {
+	simpleExplainMap := map[string]any{}
+
+	simpleExplainMap[request.Collection] = n.changeSelect.Collection
+
+	// Add the after attribute to the explanation if it exists.
+	if n.changeSelect.After.HasValue() {
+		simpleExplainMap[request.AfterClause] = n.changeSelect.After.Value()
+	} else {
+		simpleExplainMap[request.AfterClause] = nil
+	}
+
+	// Add the limit attribute to the explanation if it exists.
+	if n.changeSelect.Limit.HasValue() {
+		simpleExplainMap[request.LimitClause] = n.changeSelect.Limit.Value()
+	} else {
+		simpleExplainMap[request.LimitClause] = nil
+	}
+
+	return simpleExplainMap, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *changeScanNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (n *changeScanNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if n.index >= len(n.changes) {
+		return false, nil
+	}
+	change := n.changes[n.index]
+	n.index++
+
+	mapping := n.changeSelect.DocumentMapping
+	doc := mapping.NewDoc()
+	mapping.SetFirstOfName(&doc, request.SequenceFieldName, int64(change.Sequence))
+	mapping.SetFirstOfName(&doc, request.ChangeTypeFieldName, change.Type)
+	mapping.SetFirstOfName(&doc, request.DockeyFieldName, change.DocKey)
+	mapping.SetFirstOfName(&doc, request.CidFieldName, change.Cid)
+	mapping.SetFirstOfName(&doc, request.HeightFieldName, int64(change.Height))
+
+	n.currentValue = doc
+	return true, nil
+}
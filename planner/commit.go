@@ -336,9 +336,14 @@ func (n *dagScanNode) dagBlockToNodeDoc(block blocks.Block) (core.Doc, []*ipld.L
 			return core.Doc{}, nil, client.NewErrCollectionNotFoundForSchemaVersion(schemaVersionId)
 		}
 
-		// Because we only care about the schema, we can safely take the first - the schema is the same
-		// for all in the set.
-		field, ok := cols[0].Schema().GetField(fieldName.(string))
+		// The field must be found on the schema version of the commit, as it may have since been
+		// renamed or deleted.
+		schema, err := n.planner.db.GetSchemaByVersionID(n.planner.ctx, schemaVersionId)
+		if err != nil {
+			return core.Doc{}, nil, err
+		}
+
+		field, ok := schema.GetField(fieldName.(string))
 		if !ok {
 			return core.Doc{}, nil, client.NewErrFieldNotExist(fieldName.(string))
 		}
@@ -15,6 +15,7 @@ package planner
 // aggregates in.
 
 import (
+	"encoding/json"
 	"reflect"
 
 	"github.com/sourcenetwork/immutable"
@@ -34,6 +35,7 @@ type countNode struct {
 
 	virtualFieldIndex int
 	aggregateMapping  []mapper.AggregateTarget
+	distinct          bool
 
 	execInfo countExecInfo
 }
@@ -48,6 +50,7 @@ func (p *Planner) Count(field *mapper.Aggregate, host *mapper.Select) (*countNod
 		p:                 p,
 		virtualFieldIndex: field.Index,
 		aggregateMapping:  field.AggregateTargets,
+		distinct:          field.Distinct,
 		docMapper:         docMapper{field.DocumentMapping},
 	}, nil
 }
@@ -69,39 +72,9 @@ func (n *countNode) Close() error { return n.plan.Close() }
 func (n *countNode) Source() planNode { return n.plan }
 
 func (n *countNode) simpleExplain() (map[string]any, error) {
-	sourceExplanations := make([]map[string]any, len(n.aggregateMapping))
-
-	for i, source := range n.aggregateMapping {
-		simpleExplainMap := map[string]any{}
-
-		// Add the filter attribute if it exists.
-		if source.Filter == nil {
-			simpleExplainMap[filterLabel] = nil
-		} else {
-			// get the target aggregate document mapping. Since the filters
-			// are relative to the target aggregate collection (and doc mapper).
-			//
-			// We can determine if there is a child map if the index from the
-			// aggregate target is set (non nil) on the childMapping
-			var targetMap *core.DocumentMapping
-			if source.Index < len(n.documentMapping.ChildMappings) &&
-				n.documentMapping.ChildMappings[source.Index] != nil {
-				targetMap = n.documentMapping.ChildMappings[source.Index]
-			} else {
-				targetMap = n.documentMapping
-			}
-			simpleExplainMap[filterLabel] = source.Filter.ToMap(targetMap)
-		}
-
-		// Add the main field name.
-		simpleExplainMap[fieldNameLabel] = source.Field.Name
-
-		sourceExplanations[i] = simpleExplainMap
-	}
-
-	return map[string]any{
-		sourcesLabel: sourceExplanations,
-	}, nil
+	simpleExplainMap := explainAggregateSources(n.documentMapping, n.aggregateMapping)
+	simpleExplainMap[distinctLabel] = n.distinct
+	return simpleExplainMap, nil
 }
 
 // Explain method returns a map containing all attributes of this node that
@@ -130,6 +103,13 @@ func (n *countNode) Next() (bool, error) {
 	}
 
 	n.currentValue = n.plan.Value()
+
+	// The distinct values are shared across all sources, and are only tracked if requested.
+	var distinctValues map[any]struct{}
+	if n.distinct {
+		distinctValues = map[any]struct{}{}
+	}
+
 	// Can just scan for now, can be replaced later by something fancier if needed
 	var count int
 	for _, source := range n.aggregateMapping {
@@ -138,38 +118,42 @@ func (n *countNode) Next() (bool, error) {
 		switch v.Kind() {
 		// v.Len will panic if v is not one of these types, we don't want it to panic
 		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-			if source.Filter == nil && source.Limit == nil {
+			if source.Filter == nil && source.Limit == nil && !n.distinct && !source.ChildTarget.HasValue {
 				count = count + v.Len()
 			} else {
 				var arrayCount int
 				var err error
 				switch array := property.(type) {
 				case []core.Doc:
-					arrayCount = countDocs(array)
+					if source.ChildTarget.HasValue {
+						arrayCount, err = countDocValues(array, source.ChildTarget.Index, distinctValues)
+					} else {
+						arrayCount = countDocs(array)
+					}
 
 				case []bool:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []immutable.Option[bool]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []int64:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []immutable.Option[int64]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []float64:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []immutable.Option[float64]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []string:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 
 				case []immutable.Option[string]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source, distinctValues)
 				}
 				if err != nil {
 					return false, err
@@ -178,6 +162,7 @@ func (n *countNode) Next() (bool, error) {
 			}
 		}
 	}
+	count += len(distinctValues)
 
 	n.currentValue.Fields[n.virtualFieldIndex] = count
 	return true, nil
@@ -197,25 +182,98 @@ func countDocs(docs []core.Doc) int {
 	return count
 }
 
-func countItems[T any](source []T, filter *mapper.Filter, limit *mapper.Limit) (int, error) {
+// countDocValues counts the non-nil values of the field at the given index in a slice
+// of documents, skipping over hidden items.
+//
+// If distinctValues is not nil the values are added to it instead of being counted.
+func countDocValues(docs []core.Doc, fieldIndex int, distinctValues map[any]struct{}) (int, error) {
+	count := 0
+	for _, doc := range docs {
+		if doc.Hidden {
+			continue
+		}
+		value := doc.Fields[fieldIndex]
+		if value == nil {
+			continue
+		}
+		if distinctValues != nil {
+			key, err := distinctValueKey(value)
+			if err != nil {
+				return 0, err
+			}
+			distinctValues[key] = struct{}{}
+		} else {
+			count += 1
+		}
+	}
+
+	return count, nil
+}
+
+// encodedValue is the JSON encoding of a value that can not be used as a map key. It is a
+// distinct type so that it does not collide with string values holding the same text.
+type encodedValue string
+
+// distinctValueKey returns the key of the given value within a set of distinct values.
+//
+// Values that can not be used as map keys, such as JSON objects and arrays or Blob bytes,
+// are keyed by their JSON encoding instead, which is canonical as object keys are sorted.
+func distinctValueKey(value any) (any, error) {
+	if reflect.TypeOf(value).Comparable() {
+		return value, nil
+	}
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return encodedValue(buf), nil
+}
+
+// optionalValue is satisfied by immutable.Option, and allows items without a value to be
+// skipped when counting distinct values.
+type optionalValue interface {
+	HasValue() bool
+}
+
+func countItems[T any](
+	source []T,
+	aggregateTarget *mapper.AggregateTarget,
+	distinctValues map[any]struct{},
+) (int, error) {
 	items := enumerable.New(source)
-	if filter != nil {
+	if aggregateTarget.Filter != nil {
 		items = enumerable.Where(items, func(item T) (bool, error) {
-			return mapper.RunFilter(item, filter)
+			return mapper.RunFilter(item, aggregateTarget.Filter)
 		})
 	}
 
-	if limit != nil {
-		items = enumerable.Skip(items, limit.Offset)
-		items = enumerable.Take(items, limit.Limit)
+	if aggregateTarget.Limit != nil {
+		items = enumerable.Skip(items, aggregateTarget.Limit.Offset)
+		items = enumerable.Take(items, aggregateTarget.Limit.Limit)
 	}
 
 	count := 0
-	err := enumerable.OnEach(items, func() {
-		count += 1
+	var keyErr error
+	err := enumerable.ForEach(items, func(item T) {
+		if distinctValues == nil {
+			count += 1
+			return
+		}
+		if option, isOption := any(item).(optionalValue); isOption && !option.HasValue() {
+			return
+		}
+		key, err := distinctValueKey(item)
+		if err != nil {
+			keyErr = err
+			return
+		}
+		distinctValues[key] = struct{}{}
 	})
+	if err != nil {
+		return 0, err
+	}
 
-	return count, err
+	return count, keyErr
 }
 
 func (n *countNode) SetPlan(p planNode) { n.plan = p }
@@ -12,6 +12,7 @@ package planner
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
@@ -23,10 +24,12 @@ import (
 // createNode is used to construct and execute
 // an object create mutation.
 //
-// Create nodes are the simplest of the object mutations
-// Each Iteration of the plan, creates and returns one
-// document, until we've exhausted the payload. No filtering
-// or Select plans
+// Create nodes are the simplest of the object mutations.
+// On the first iteration of the plan all the documents
+// in the payload are created, each iteration then returns
+// one of the created documents, in the order they were given
+// in, until they are exhausted.
+// No filtering or Select plans
 type createNode struct {
 	documentIterator
 	docMapper
@@ -37,14 +40,22 @@ type createNode struct {
 	// collection name, meta-data, etc.
 	collection client.Collection
 
-	// newDoc is the JSON string of the new document, unparsed
-	newDocStr string
-	doc       *client.Document
+	// newDocStrs are the JSON strings of the new documents, unparsed
+	newDocStrs []string
+	docs       []*client.Document
+
+	// isBatch is true if the documents were provided as an input list
+	isBatch bool
 
 	err error
 
-	returned bool
+	created bool
+	// returned is the number of created documents that have been returned
+	returned int
 	results  planNode
+	// createdDocs are the created documents as selected by results, in the
+	// order they were given in
+	createdDocs []core.Doc
 
 	execInfo createExecInfo
 }
@@ -59,16 +70,20 @@ func (n *createNode) Kind() string { return "createNode" }
 func (n *createNode) Init() error { return nil }
 
 func (n *createNode) Start() error {
-	doc, err := client.NewDocFromJSON([]byte(n.newDocStr))
-	if err != nil {
-		n.err = err
-		return err
+	n.docs = make([]*client.Document, len(n.newDocStrs))
+	for i, newDocStr := range n.newDocStrs {
+		doc, err := client.NewDocFromJSON([]byte(newDocStr))
+		if err != nil {
+			n.err = err
+			return err
+		}
+		n.docs[i] = doc
 	}
-	n.doc = doc
 	return nil
 }
 
-// Next only returns once.
+// Next creates all the documents on the first call, and then returns
+// them one at a time.
 func (n *createNode) Next() (bool, error) {
 	n.execInfo.iterations++
 
@@ -76,55 +91,86 @@ func (n *createNode) Next() (bool, error) {
 		return false, n.err
 	}
 
-	if n.returned {
-		return false, nil
+	if !n.created {
+		err := n.create()
+		if err != nil {
+			return false, err
+		}
+		n.created = true
 	}
 
-	if err := n.collection.WithTxn(n.p.txn).Create(n.p.ctx, n.doc); err != nil {
-		return false, err
+	if n.returned == len(n.createdDocs) {
+		return false, nil
 	}
 
-	currentValue := n.documentMapping.NewDoc()
+	n.currentValue = n.createdDocs[n.returned]
+	n.returned++
+	return true, nil
+}
 
-	currentValue.SetKey(n.doc.Key().String())
-	for i, value := range n.doc.Values() {
-		if len(n.documentMapping.IndexesByName[i.Name()]) > 0 {
-			n.documentMapping.SetFirstOfName(&currentValue, i.Name(), value.Value())
-		} else if aliasName := i.Name() + request.RelatedObjectID; len(n.documentMapping.IndexesByName[aliasName]) > 0 {
-			n.documentMapping.SetFirstOfName(&currentValue, aliasName, value.Value())
-		} else {
-			return false, client.NewErrFieldNotExist(i.Name())
+func (n *createNode) create() error {
+	for _, doc := range n.docs {
+		for i := range doc.Values() {
+			if len(n.documentMapping.IndexesByName[i.Name()]) > 0 {
+				continue
+			}
+			if aliasName := i.Name() + request.RelatedObjectID; len(n.documentMapping.IndexesByName[aliasName]) > 0 {
+				continue
+			}
+			return client.NewErrFieldNotExist(i.Name())
 		}
 	}
 
-	n.returned = true
-	n.currentValue = currentValue
+	if err := n.collection.WithTxn(n.p.txn).CreateMany(n.p.ctx, n.docs); err != nil {
+		return err
+	}
+
+	// the spans of the point lookups have to be in ascending order, so the
+	// documents are selected in the order of their keys
+	docKeys := make([]string, len(n.docs))
+	for i, doc := range n.docs {
+		docKeys[i] = doc.Key().String()
+	}
+	sort.Strings(docKeys)
 
 	desc := n.collection.Description()
-	docKey := base.MakeDocKey(desc, currentValue.GetKey())
-	n.results.Spans(core.NewSpans(core.NewSpan(docKey, docKey.PrefixEnd())))
+	spans := make([]core.Span, len(docKeys))
+	for i, docKey := range docKeys {
+		dsKey := base.MakeDocKey(desc, docKey)
+		spans[i] = core.NewSpan(dsKey, dsKey.PrefixEnd())
+	}
+	n.results.Spans(core.NewSpans(spans...))
 
 	err := n.results.Init()
 	if err != nil {
-		return false, err
+		return err
 	}
-
 	err = n.results.Start()
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	// get the next result based on our point lookup
-	next, err := n.results.Next()
-	if err != nil {
-		return false, err
-	}
-	if !next {
-		return false, nil
+	// the selected documents are then returned in the order they were given in
+	docsByKey := make(map[string]core.Doc, len(n.docs))
+	for len(docsByKey) < len(n.docs) {
+		next, err := n.results.Next()
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+		doc := n.results.Value()
+		docsByKey[doc.GetKey()] = doc
 	}
 
-	n.currentValue = n.results.Value()
-	return true, nil
+	n.createdDocs = make([]core.Doc, 0, len(n.docs))
+	for _, doc := range n.docs {
+		if createdDoc, ok := docsByKey[doc.Key().String()]; ok {
+			n.createdDocs = append(n.createdDocs, createdDoc)
+		}
+	}
+	return nil
 }
 
 func (n *createNode) Spans(spans core.Spans) { /* no-op */ }
@@ -136,8 +182,24 @@ func (n *createNode) Close() error {
 func (n *createNode) Source() planNode { return n.results }
 
 func (n *createNode) simpleExplain() (map[string]any, error) {
+	if n.isBatch {
+		input := make([]map[string]any, len(n.newDocStrs))
+		for i, newDocStr := range n.newDocStrs {
+			data := map[string]any{}
+			err := json.Unmarshal([]byte(newDocStr), &data)
+			if err != nil {
+				return nil, err
+			}
+			input[i] = data
+		}
+
+		return map[string]any{
+			inputLabel: input,
+		}, nil
+	}
+
 	data := map[string]any{}
-	err := json.Unmarshal([]byte(n.newDocStr), &data)
+	err := json.Unmarshal([]byte(n.newDocStrs[0]), &data)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +227,10 @@ func (n *createNode) Explain(explainType request.ExplainType) (map[string]any, e
 }
 
 func (p *Planner) CreateDoc(parsed *mapper.Mutation) (planNode, error) {
+	if parsed.Data != "" && len(parsed.Input) > 0 {
+		return nil, ErrCreateWithDataAndInput
+	}
+
 	results, err := p.Select(&parsed.Select)
 	if err != nil {
 		return nil, err
@@ -173,10 +239,15 @@ func (p *Planner) CreateDoc(parsed *mapper.Mutation) (planNode, error) {
 	// create a mutation createNode.
 	create := &createNode{
 		p:         p,
-		newDocStr: parsed.Data,
 		results:   results,
 		docMapper: docMapper{parsed.DocumentMapping},
 	}
+	if len(parsed.Input) > 0 {
+		create.newDocStrs = parsed.Input
+		create.isBatch = true
+	} else {
+		create.newDocStrs = []string{parsed.Data}
+	}
 
 	// get collection
 	col, err := p.db.GetCollectionByName(p.ctx, parsed.Name)
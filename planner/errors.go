@@ -10,13 +10,17 @@
 
 package planner
 
-import "github.com/sourcenetwork/defradb/errors"
+import (
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+)
 
 const (
 	errUnknownDependency              string = "given field does not exist"
 	errFailedToClosePlan              string = "failed to close the plan"
 	errFailedToCollectExecExplainInfo string = "failed to collect execution explain information"
 	errSubTypeInit                    string = "sub-type initialization error at scan node reset"
+	errIncomparableAggregateTargets   string = "the values of the aggregate targets can not be compared"
 )
 
 var (
@@ -34,6 +38,9 @@ var (
 	ErrMissingChildValue                   = errors.New("expected child value, however none was yielded")
 	ErrUnknownRelationType                 = errors.New("failed sub selection, unknown relation type")
 	ErrUnknownExplainRequestType           = errors.New("can not explain request of unknown type")
+	ErrCreateWithDataAndInput              = errors.New("create may not be given both data and input")
+	ErrUpsertMissingFilter                 = errors.New("upsert requires a filter")
+	ErrUpsertMissingData                   = errors.New("upsert requires both create and update data")
 	ErrSubTypeInit                         = errors.New(errSubTypeInit)
 	ErrFailedToCollectExecExplainInfo      = errors.New(errFailedToCollectExecExplainInfo)
 	ErrUnknownDependency                   = errors.New(errUnknownDependency)
@@ -54,3 +61,12 @@ func NewErrFailedToCollectExecExplainInfo(inner error) error {
 func NewErrSubTypeInit(inner error) error {
 	return errors.Wrap(errSubTypeInit, inner)
 }
+
+func NewErrIncomparableAggregateTargets(name string, kind client.FieldKind, otherKind client.FieldKind) error {
+	return errors.New(
+		errIncomparableAggregateTargets,
+		errors.NewKV("Name", name),
+		errors.NewKV("Kind", kind),
+		errors.NewKV("OtherKind", otherKind),
+	)
+}
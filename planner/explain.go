@@ -32,13 +32,17 @@ type explainablePlanNode interface {
 
 // Compile time check for all planNodes that should be explainable (satisfy explainablePlanNode).
 var (
+	_ explainablePlanNode = (*aggregateFilterNode)(nil)
 	_ explainablePlanNode = (*averageNode)(nil)
+	_ explainablePlanNode = (*changeScanNode)(nil)
 	_ explainablePlanNode = (*countNode)(nil)
 	_ explainablePlanNode = (*createNode)(nil)
 	_ explainablePlanNode = (*dagScanNode)(nil)
 	_ explainablePlanNode = (*deleteNode)(nil)
 	_ explainablePlanNode = (*groupNode)(nil)
 	_ explainablePlanNode = (*limitNode)(nil)
+	_ explainablePlanNode = (*maxNode)(nil)
+	_ explainablePlanNode = (*minNode)(nil)
 	_ explainablePlanNode = (*orderNode)(nil)
 	_ explainablePlanNode = (*scanNode)(nil)
 	_ explainablePlanNode = (*selectNode)(nil)
@@ -47,16 +51,20 @@ var (
 	_ explainablePlanNode = (*topLevelNode)(nil)
 	_ explainablePlanNode = (*typeIndexJoin)(nil)
 	_ explainablePlanNode = (*updateNode)(nil)
+	_ explainablePlanNode = (*upsertNode)(nil)
 )
 
 const (
 	childFieldNameLabel = "childFieldName"
 	collectionIDLabel   = "collectionID"
 	collectionNameLabel = "collectionName"
+	createDataLabel     = "create"
 	dataLabel           = "data"
+	distinctLabel       = "distinct"
 	fieldNameLabel      = "fieldName"
 	filterLabel         = "filter"
 	idsLabel            = "ids"
+	inputLabel          = "input"
 	joinRootLabel       = "root"
 	joinSubTypeLabel    = "subType"
 	keysLabel           = "_keys"
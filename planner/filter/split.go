@@ -36,3 +36,40 @@ func SplitByField(filter *mapper.Filter, field mapper.Field) (*mapper.Filter, *m
 
 	return filter, splitF
 }
+
+// SplitByTopLevelFields splits the provided filter into 2 filters based on the given fields.
+// Unlike SplitByField, it only moves conditions that are on the top level of the filter,
+// conditions nested in compound operators (like _and or _or) are kept in the root filter.
+// Eg. (filter: {age: 10, name: "bob", _or: [{age: 20}, ...]})
+//
+// With the age field, the root filter would be {name: "bob", _or: [{age: 20}, ...]}
+// and the split filter would be {age: 10}.
+func SplitByTopLevelFields(filter *mapper.Filter, fields ...mapper.Field) (*mapper.Filter, *mapper.Filter) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	var splitF *mapper.Filter
+	for key, cond := range filter.Conditions {
+		propIndex, ok := key.(*mapper.PropertyIndex)
+		if !ok {
+			continue
+		}
+		for _, field := range fields {
+			if propIndex.Index == field.Index {
+				if splitF == nil {
+					splitF = mapper.NewFilter()
+				}
+				splitF.Conditions[key] = cond
+				delete(filter.Conditions, key)
+				break
+			}
+		}
+	}
+
+	if len(filter.Conditions) == 0 {
+		filter = nil
+	}
+
+	return filter, splitF
+}
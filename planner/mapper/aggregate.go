@@ -54,6 +54,9 @@ type Aggregate struct {
 	//
 	// For example, Average is dependent on a Sum and Count field.
 	Dependencies []*Aggregate
+
+	// If true, only distinct values will be aggregated.
+	Distinct bool
 }
 
 func (a *Aggregate) CloneTo(index int) Requestable {
@@ -65,5 +68,6 @@ func (a *Aggregate) cloneTo(index int) *Aggregate {
 		Field:            *a.Field.cloneTo(index),
 		DocumentMapping:  a.DocumentMapping,
 		AggregateTargets: a.AggregateTargets,
+		Distinct:         a.Distinct,
 	}
 }
@@ -0,0 +1,42 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package mapper
+
+import "github.com/sourcenetwork/immutable"
+
+// ChangeSelect represents a request for the changes recorded in the change log
+// of a collection.
+type ChangeSelect struct {
+	// The underlying Select, defining the information requested.
+	Select
+
+	// The name of the collection for which changes have been requested.
+	Collection string
+
+	// The sequence of the change after which changes have been requested.
+	After immutable.Option[uint64]
+
+	// The maximum number of changes to yield.
+	Limit immutable.Option[uint64]
+}
+
+func (s *ChangeSelect) CloneTo(index int) Requestable {
+	return s.cloneTo(index)
+}
+
+func (s *ChangeSelect) cloneTo(index int) *ChangeSelect {
+	return &ChangeSelect{
+		Select:     *s.Select.cloneTo(index),
+		Collection: s.Collection,
+		After:      s.After,
+		Limit:      s.Limit,
+	}
+}
@@ -13,7 +13,9 @@ package mapper
 import "github.com/sourcenetwork/defradb/errors"
 
 const (
-	errInvalidFieldToGroupBy string = "invalid field value to groupBy"
+	errInvalidFieldToGroupBy       string = "invalid field value to groupBy"
+	errAggregateFilterNotRequested string = "aggregate must be requested in order to be filtered"
+	errAmbiguousAggregateFilter    string = "aggregate must be requested only once in order to be filtered"
 )
 
 var (
@@ -22,8 +24,21 @@ var (
 	ErrFailedToFindHostField    = errors.New("failed to find host field")
 	ErrInvalidFieldIndex        = errors.New("given field doesn't have any indexes")
 	ErrMissingSelect            = errors.New("missing target select field")
+	ErrInvalidAggregateFilter   = errors.New(
+		"aggregates may only be filtered at the top level of a select filter, or within compound " +
+			"conditions that only have conditions on aggregates",
+	)
+	ErrAggregateFilterWithinGroup = errors.New("aggregates may not be filtered within _group")
 )
 
 func NewErrInvalidFieldToGroupBy(field string) error {
 	return errors.New(errInvalidFieldToGroupBy, errors.NewKV("Field", field))
 }
+
+func NewErrAggregateFilterNotRequested(name string) error {
+	return errors.New(errAggregateFilterNotRequested, errors.NewKV("Name", name))
+}
+
+func NewErrAmbiguousAggregateFilter(name string) error {
+	return errors.New(errAmbiguousAggregateFilter, errors.NewKV("Name", name))
+}
@@ -13,6 +13,7 @@ package mapper
 import (
 	"context"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/sourcenetwork/immutable"
@@ -62,9 +63,19 @@ func toSelect(
 		return nil, err
 	}
 
+	// Conditions on aggregates can only be checked once the aggregates have been computed,
+	// and so they are split from the filter applied to the documents.
+	filter, aggregateFilter, err := splitAggregateFilter(selectRequest.Filter, mapping)
+	if err != nil {
+		return nil, err
+	}
+	if selectRequest.Name == request.GroupFieldName && len(aggregateFilter.Conditions) > 0 {
+		return nil, ErrAggregateFilterWithinGroup
+	}
+
 	// Needs to be done before resolving aggregates, else filter conversion may fail there
 	filterDependencies, err := resolveFilterDependencies(
-		ctx, store, collectionName, selectRequest.Filter, mapping, fields)
+		ctx, store, collectionName, filter, mapping, fields)
 	if err != nil {
 		return nil, err
 	}
@@ -129,14 +140,149 @@ func toSelect(
 	}
 
 	return &Select{
-		Targetable:      toTargetable(thisIndex, selectRequest, mapping),
+		Targetable:      toTargetable(thisIndex, selectRequest, filter, mapping),
 		DocumentMapping: mapping,
 		Cid:             selectRequest.CID,
 		CollectionName:  collectionName,
 		Fields:          fields,
+		AggregateFilter: ToFilter(aggregateFilter, mapping),
 	}, nil
 }
 
+// splitAggregateFilter splits the conditions on aggregates from the given filter, returning
+// the remaining filter and the aggregate conditions.
+//
+// Compound conditions (e.g. `_or`) are only split if they consist solely of conditions on
+// aggregates. The aggregates must have been requested, and conditions on them are not
+// permitted anywhere else within the filter.
+//
+// Conditions are keyed by the aggregate name (e.g. `_count`), so an aggregate that has been
+// requested more than once, even if under different aliases, cannot be filtered on as it is
+// not known which of them is meant.
+func splitAggregateFilter(
+	source immutable.Option[request.Filter],
+	mapping *core.DocumentMapping,
+) (immutable.Option[request.Filter], request.Filter, error) {
+	if !source.HasValue() {
+		return source, request.Filter{}, nil
+	}
+
+	conditions := map[string]any{}
+	aggregateConditions := map[string]any{}
+	for key, clause := range source.Value().Conditions {
+		if isAggregateCondition(key, clause) {
+			aggregateConditions[key] = clause
+		} else {
+			conditions[key] = clause
+		}
+	}
+
+	if hasAggregateCondition(conditions) {
+		return immutable.None[request.Filter](), request.Filter{}, ErrInvalidAggregateFilter
+	}
+
+	for _, name := range aggregateConditionNames(aggregateConditions) {
+		if len(mapping.IndexesByName[name]) == 0 {
+			return immutable.None[request.Filter](), request.Filter{}, NewErrAggregateFilterNotRequested(name)
+		}
+		if len(mapping.IndexesByName[name]) > 1 {
+			return immutable.None[request.Filter](), request.Filter{}, NewErrAmbiguousAggregateFilter(name)
+		}
+	}
+
+	if len(aggregateConditions) == 0 {
+		return source, request.Filter{}, nil
+	}
+	if len(conditions) == 0 {
+		return immutable.None[request.Filter](), request.Filter{Conditions: aggregateConditions}, nil
+	}
+	return immutable.Some(request.Filter{Conditions: conditions}), request.Filter{Conditions: aggregateConditions}, nil
+}
+
+// isAggregateCondition returns true if the given filter condition only has conditions on aggregates.
+func isAggregateCondition(key string, clause any) bool {
+	switch key {
+	case request.FilterOpAnd, request.FilterOpOr:
+		items, isArray := clause.([]any)
+		if !isArray || len(items) == 0 {
+			return false
+		}
+		for _, item := range items {
+			itemConditions, isMap := item.(map[string]any)
+			if !isMap || !isAggregateConditionMap(itemConditions) {
+				return false
+			}
+		}
+		return true
+
+	case request.FilterOpNot:
+		itemConditions, isMap := clause.(map[string]any)
+		return isMap && isAggregateConditionMap(itemConditions)
+
+	default:
+		_, isAggregate := request.Aggregates[key]
+		return isAggregate
+	}
+}
+
+func isAggregateConditionMap(conditions map[string]any) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+	for key, clause := range conditions {
+		if !isAggregateCondition(key, clause) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAggregateCondition returns true if any of the given conditions, or any of the
+// conditions nested within them, are on an aggregate.
+func hasAggregateCondition(conditions map[string]any) bool {
+	for key, clause := range conditions {
+		if _, isAggregate := request.Aggregates[key]; isAggregate {
+			return true
+		}
+		switch typedClause := clause.(type) {
+		case map[string]any:
+			if hasAggregateCondition(typedClause) {
+				return true
+			}
+		case []any:
+			for _, item := range typedClause {
+				if itemConditions, isMap := item.(map[string]any); isMap && hasAggregateCondition(itemConditions) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// aggregateConditionNames returns the names of the aggregates that the given aggregate
+// conditions are on.
+func aggregateConditionNames(conditions map[string]any) []string {
+	names := []string{}
+	for key, clause := range conditions {
+		if _, isAggregate := request.Aggregates[key]; isAggregate {
+			names = append(names, key)
+			continue
+		}
+		switch typedClause := clause.(type) {
+		case map[string]any:
+			names = append(names, aggregateConditionNames(typedClause)...)
+		case []any:
+			for _, item := range typedClause {
+				if itemConditions, isMap := item.(map[string]any); isMap {
+					names = append(names, aggregateConditionNames(itemConditions)...)
+				}
+			}
+		}
+	}
+	return names
+}
+
 // resolveOrderDependencies will map fields that were missed due to them not being requested.
 // Modifies the consumed existingFields and mapping accordingly.
 func resolveOrderDependencies(
@@ -449,6 +595,7 @@ func resolveAggregates(
 			Field:            aggregate.field,
 			DocumentMapping:  mapping,
 			AggregateTargets: aggregateTargets,
+			Distinct:         aggregate.distinct,
 		}
 		fields = append(fields, &newAggregate)
 		dependenciesByParentId[aggregate.field.Index] = aggregate.dependencyIndexes
@@ -692,7 +839,8 @@ func getAggregateRequests(index int, aggregate *request.Aggregate) (aggregateReq
 			Index: index,
 			Name:  aggregate.Name,
 		},
-		targets: aggregateTargets,
+		targets:  aggregateTargets,
+		distinct: aggregate.Distinct,
 	}, nil
 }
 
@@ -711,7 +859,7 @@ func getCollectionName(
 
 	if selectRequest.Name == request.GroupFieldName {
 		return parentCollectionName, nil
-	} else if selectRequest.Root == request.CommitSelection {
+	} else if selectRequest.Root == request.CommitSelection || selectRequest.Root == request.ChangeSelection {
 		return parentCollectionName, nil
 	}
 
@@ -774,6 +922,18 @@ func getTopLevelInfo(
 		return mapping, collection, nil
 	}
 
+	if selectRequest.Root == request.ChangeSelection {
+		for i, f := range request.ChangeFields {
+			mapping.Add(i, f)
+		}
+
+		// Setting the type name must be done after adding the fields, as
+		// the typeName index is dynamic, but the field indexes are not
+		mapping.SetTypeName(request.ChangeTypeName)
+
+		return mapping, nil, nil
+	}
+
 	if selectRequest.Name == request.LinksFieldName {
 		for i, f := range request.LinksFields {
 			mapping.Add(i, f)
@@ -1089,6 +1249,27 @@ func ToCommitSelect(
 	}, nil
 }
 
+// ToChangeSelect converts the given [request.ChangeSelect] into a [ChangeSelect].
+//
+// In the process of doing so it will construct the document map required to access the data
+// yielded by the [Select] embedded in the [ChangeSelect].
+func ToChangeSelect(
+	ctx context.Context,
+	store client.Store,
+	selectRequest *request.ChangeSelect,
+) (*ChangeSelect, error) {
+	underlyingSelect, err := ToSelect(ctx, store, selectRequest.ToSelect())
+	if err != nil {
+		return nil, err
+	}
+	return &ChangeSelect{
+		Select:     *underlyingSelect,
+		Collection: selectRequest.Collection,
+		After:      selectRequest.After,
+		Limit:      selectRequest.Limit,
+	}, nil
+}
+
 // ToMutation converts the given [request.Mutation] into a [Mutation].
 //
 // In the process of doing so it will construct the document map required to access the data
@@ -1100,17 +1281,24 @@ func ToMutation(ctx context.Context, store client.Store, mutationRequest *reques
 	}
 
 	return &Mutation{
-		Select: *underlyingSelect,
-		Type:   MutationType(mutationRequest.Type),
-		Data:   mutationRequest.Data,
+		Select:     *underlyingSelect,
+		Type:       MutationType(mutationRequest.Type),
+		Data:       mutationRequest.Data,
+		Input:      mutationRequest.Input,
+		CreateData: mutationRequest.CreateData,
 	}, nil
 }
 
-func toTargetable(index int, selectRequest *request.Select, docMap *core.DocumentMapping) Targetable {
+func toTargetable(
+	index int,
+	selectRequest *request.Select,
+	filter immutable.Option[request.Filter],
+	docMap *core.DocumentMapping,
+) Targetable {
 	return Targetable{
 		Field:       toField(index, selectRequest),
 		DocKeys:     selectRequest.DocKeys,
-		Filter:      ToFilter(selectRequest.Filter.Value(), docMap),
+		Filter:      ToFilter(filter.Value(), docMap),
 		Limit:       toLimit(selectRequest.Limit, selectRequest.Offset),
 		GroupBy:     toGroupBy(selectRequest.GroupBy, docMap),
 		OrderBy:     toOrderBy(selectRequest.OrderBy, docMap),
@@ -1154,7 +1342,9 @@ func toFilterMap(
 	sourceClause any,
 	mapping *core.DocumentMapping,
 ) (connor.FilterKey, any) {
-	if strings.HasPrefix(sourceKey, "_") && sourceKey != request.KeyFieldName {
+	// Aggregates are filtered by their value, like any other property.
+	_, isAggregate := request.Aggregates[sourceKey]
+	if strings.HasPrefix(sourceKey, "_") && sourceKey != request.KeyFieldName && !isAggregate {
 		key := &Operator{
 			Operation: sourceKey,
 		}
@@ -1186,7 +1376,7 @@ func toFilterMap(
 			}
 			return key, innerMapClause
 		default:
-			return key, typedClause
+			return key, toOperatorClause(sourceKey, typedClause)
 		}
 	} else {
 		// If there are multiple properties of the same name we can just take the first as
@@ -1197,6 +1387,11 @@ func toFilterMap(
 		key := &PropertyIndex{
 			Index: index,
 		}
+		if childMapping, _ := tryGetChildMapping(mapping, index); childMapping == nil {
+			// Properties that are not relations hold the value of the field, which in
+			// case of a JSON field may be an object filtered by the paths to its properties.
+			return key, toObjectFilterMap(sourceClause)
+		}
 		switch typedClause := sourceClause.(type) {
 		case map[string]any:
 			returnClause := map[connor.FilterKey]any{}
@@ -1221,6 +1416,57 @@ func toFilterMap(
 	}
 }
 
+// toObjectFilterMap converts a consumer-defined filter clause on the value of a field
+// into a filter clause keyed by operators and, for JSON objects, property names.
+//
+// Keys that start with an underscore are treated as operators.
+func toObjectFilterMap(sourceClause any) any {
+	switch typedClause := sourceClause.(type) {
+	case map[string]any:
+		returnClause := make(map[connor.FilterKey]any, len(typedClause))
+		for sourceKey, sourceValue := range typedClause {
+			var key connor.FilterKey
+			clause := toObjectFilterMap(sourceValue)
+			if strings.HasPrefix(sourceKey, "_") {
+				key = &Operator{Operation: sourceKey}
+				clause = toOperatorClause(sourceKey, clause)
+			} else {
+				key = &ObjectProperty{Name: sourceKey}
+			}
+			returnClause[key] = clause
+		}
+		return returnClause
+	case []any:
+		returnClauses := make([]any, len(typedClause))
+		for i, innerSourceClause := range typedClause {
+			returnClauses[i] = toObjectFilterMap(innerSourceClause)
+		}
+		return returnClauses
+	default:
+		return sourceClause
+	}
+}
+
+// toOperatorClause returns the clause of the given operator as it is run by the filter.
+//
+// The pattern of a `_regex` condition is compiled here, so that it is compiled once per
+// request rather than once per value it is matched against. Invalid patterns are kept
+// as they are and return an error when the filter is run.
+func toOperatorClause(operation string, clause any) any {
+	if operation != request.FilterOpRegex {
+		return clause
+	}
+	pattern, ok := clause.(string)
+	if !ok {
+		return clause
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return clause
+	}
+	return re
+}
+
 func toLimit(limit immutable.Option[uint64], offset immutable.Option[uint64]) *Limit {
 	var limitValue uint64
 	var offsetValue uint64
@@ -1443,6 +1689,9 @@ type aggregateRequest struct {
 	// The targets of this aggregate, as defined by the consumer.
 	targets           []*aggregateRequestTarget
 	dependencyIndexes []int
+
+	// If true, only distinct values will be aggregated.
+	distinct bool
 }
 
 // aggregateRequestTarget contains the user defined information for an aggregate
@@ -1476,6 +1725,9 @@ func getAggregateSources(field *request.Aggregate) ([]*aggregateRequestTarget, e
 	targets := make([]*aggregateRequestTarget, len(field.Targets))
 
 	for i, target := range field.Targets {
+		if target.Filter.HasValue() && hasAggregateCondition(target.Filter.Value().Conditions) {
+			return nil, ErrInvalidAggregateFilter
+		}
 		targets[i] = &aggregateRequestTarget{
 			hostExternalName:  target.HostName,
 			childExternalName: target.ChildName.Value(),
@@ -1501,6 +1753,10 @@ collectionLoop:
 		if aggregate.field.Name != name {
 			continue
 		}
+		// Aggregates are only ever matched as dependencies, which aggregate all values.
+		if aggregate.distinct {
+			continue
+		}
 		if len(aggregate.targets) != len(targets) {
 			continue
 		}
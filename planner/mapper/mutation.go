@@ -17,6 +17,7 @@ const (
 	CreateObjects
 	UpdateObjects
 	DeleteObjects
+	UpsertObjects
 )
 
 // Mutation represents a request to mutate data stored in Defra.
@@ -30,6 +31,14 @@ type Mutation struct {
 	// The data to be used for the mutation.  For example, during a create this
 	// will be the json representation of the object to be inserted.
 	Data string
+
+	// The documents to be created by a batch create, each one being the json
+	// representation of the object to be inserted.
+	Input []string
+
+	// The data to be used to create a document if an upsert does not match
+	// any existing documents.
+	CreateData string
 }
 
 func (m *Mutation) CloneTo(index int) Requestable {
@@ -38,8 +47,10 @@ func (m *Mutation) CloneTo(index int) Requestable {
 
 func (m *Mutation) cloneTo(index int) *Mutation {
 	return &Mutation{
-		Select: *m.Select.cloneTo(index),
-		Type:   m.Type,
-		Data:   m.Data,
+		Select:     *m.Select.cloneTo(index),
+		Type:       m.Type,
+		Data:       m.Data,
+		Input:      m.Input,
+		CreateData: m.CreateData,
 	}
 }
@@ -42,6 +42,7 @@ type Requestable interface {
 
 var (
 	_ Requestable = (*Aggregate)(nil)
+	_ Requestable = (*ChangeSelect)(nil)
 	_ Requestable = (*CommitSelect)(nil)
 	_ Requestable = (*Field)(nil)
 	_ Requestable = (*Mutation)(nil)
@@ -38,6 +38,12 @@ type Select struct {
 	// These can include stuff such as version information, aggregates, and other
 	// Selects.
 	Fields []Requestable
+
+	// An optional filter on the aggregates of this select, that restricts results to
+	// documents (or groups) whose aggregates satisfy all of its conditions.
+	//
+	// It can only be checked once the aggregates have been computed.
+	AggregateFilter *Filter
 }
 
 func (s *Select) AsTargetable() (*Targetable, bool) {
@@ -59,6 +65,7 @@ func (s *Select) cloneTo(index int) *Select {
 		Cid:             s.Cid,
 		CollectionName:  s.CollectionName,
 		Fields:          s.Fields,
+		AggregateFilter: s.AggregateFilter,
 	}
 }
 
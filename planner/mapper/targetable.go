@@ -11,6 +11,8 @@
 package mapper
 
 import (
+	"regexp"
+
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client/request"
@@ -21,6 +23,7 @@ import (
 var (
 	_ connor.FilterKey = (*PropertyIndex)(nil)
 	_ connor.FilterKey = (*Operator)(nil)
+	_ connor.FilterKey = (*ObjectProperty)(nil)
 )
 
 // PropertyIndex is a FilterKey that represents a property in a document.
@@ -71,6 +74,32 @@ func (k *Operator) Equal(other connor.FilterKey) bool {
 	return false
 }
 
+// ObjectProperty is a FilterKey that represents a property of an object held
+// by a JSON field.
+type ObjectProperty struct {
+	// The name of the property within its parent object.
+	Name string
+}
+
+func (k *ObjectProperty) GetProp(data any) any {
+	object, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+	return object[k.Name]
+}
+
+func (k *ObjectProperty) GetOperatorOrDefault(defaultOp string) string {
+	return defaultOp
+}
+
+func (k *ObjectProperty) Equal(other connor.FilterKey) bool {
+	if otherKey, isOk := other.(*ObjectProperty); isOk && *k == *otherKey {
+		return true
+	}
+	return false
+}
+
 // Filter represents a series of conditions that may reduce the number of
 // records that a request returns.
 type Filter struct {
@@ -119,6 +148,13 @@ func filterObjectToMap(mapping *core.DocumentMapping, obj map[connor.FilterKey]a
 				outmap[outkey] = filterObjectToMap(mapping, subObj)
 			}
 
+		case *ObjectProperty:
+			if subObj, ok := v.(map[connor.FilterKey]any); ok {
+				outmap[keyType.Name] = filterObjectToMap(mapping, subObj)
+			} else {
+				outmap[keyType.Name] = v
+			}
+
 		case *Operator:
 			switch keyType.Operation {
 			case request.FilterOpAnd, request.FilterOpOr:
@@ -133,7 +169,11 @@ func filterObjectToMap(mapping *core.DocumentMapping, obj map[connor.FilterKey]a
 				itemMap := v.(map[connor.FilterKey]any)
 				outmap[keyType.Operation] = filterObjectToMap(mapping, itemMap)
 			default:
-				outmap[keyType.Operation] = v
+				if re, ok := v.(*regexp.Regexp); ok {
+					outmap[keyType.Operation] = re.String()
+				} else {
+					outmap[keyType.Operation] = v
+				}
 			}
 		}
 	}
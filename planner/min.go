@@ -0,0 +1,474 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/sourcenetwork/immutable/enumerable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+type minNode struct {
+	documentIterator
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	kind              client.FieldKind
+	virtualFieldIndex int
+	aggregateMapping  []mapper.AggregateTarget
+
+	execInfo minExecInfo
+}
+
+type minExecInfo struct {
+	// Total number of times minNode was executed.
+	iterations uint64
+}
+
+func (p *Planner) Min(
+	field *mapper.Aggregate,
+	parent *mapper.Select,
+) (*minNode, error) {
+	kind, err := p.comparableAggregateKind(field, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &minNode{
+		p:                 p,
+		kind:              kind,
+		aggregateMapping:  field.AggregateTargets,
+		virtualFieldIndex: field.Index,
+		docMapper:         docMapper{field.DocumentMapping},
+	}, nil
+}
+
+func (n *minNode) Kind() string {
+	return "minNode"
+}
+
+func (n *minNode) Init() error {
+	return n.plan.Init()
+}
+
+func (n *minNode) Start() error { return n.plan.Start() }
+
+func (n *minNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+
+func (n *minNode) Close() error { return n.plan.Close() }
+
+func (n *minNode) Source() planNode { return n.plan }
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *minNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return explainAggregateSources(n.documentMapping, n.aggregateMapping), nil
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (n *minNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	hasNext, err := n.plan.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	n.currentValue = n.plan.Value()
+
+	min, err := aggregateExtreme(n.currentValue, n.aggregateMapping, n.kind, func(comparison int) bool {
+		return comparison < 0
+	})
+	if err != nil {
+		return false, err
+	}
+	n.currentValue.Fields[n.virtualFieldIndex] = min
+
+	return true, nil
+}
+
+func (n *minNode) SetPlan(p planNode) { n.plan = p }
+
+// explainAggregateSources returns the simple explanation of the given aggregate targets.
+func explainAggregateSources(
+	documentMapping *core.DocumentMapping,
+	aggregateMapping []mapper.AggregateTarget,
+) map[string]any {
+	sourceExplanations := make([]map[string]any, len(aggregateMapping))
+
+	for i, source := range aggregateMapping {
+		simpleExplainMap := map[string]any{}
+
+		// Add the filter attribute if it exists.
+		if source.Filter == nil {
+			simpleExplainMap[filterLabel] = nil
+		} else {
+			// get the target aggregate document mapping. Since the filters
+			// are relative to the target aggregate collection (and doc mapper).
+			var targetMap *core.DocumentMapping
+			if source.Index < len(documentMapping.ChildMappings) &&
+				documentMapping.ChildMappings[source.Index] != nil {
+				targetMap = documentMapping.ChildMappings[source.Index]
+			} else {
+				targetMap = documentMapping
+			}
+			simpleExplainMap[filterLabel] = source.Filter.ToMap(targetMap)
+		}
+
+		// Add the main field name.
+		simpleExplainMap[fieldNameLabel] = source.Field.Name
+
+		// Add the child field name if it exists.
+		if source.ChildTarget.HasValue {
+			simpleExplainMap[childFieldNameLabel] = source.ChildTarget.Name
+		} else {
+			simpleExplainMap[childFieldNameLabel] = nil
+		}
+
+		sourceExplanations[i] = simpleExplainMap
+	}
+
+	return map[string]any{
+		sourcesLabel: sourceExplanations,
+	}
+}
+
+// comparableAggregateKind returns the kind of the values compared by the given min or max aggregate.
+//
+// Int values are compared with float values as floats, any other mix of kinds cannot be compared.
+func (p *Planner) comparableAggregateKind(
+	field *mapper.Aggregate,
+	parent *mapper.Select,
+) (client.FieldKind, error) {
+	kind := client.FieldKind_None
+	for _, target := range field.AggregateTargets {
+		targetKind, err := p.comparableValueKind(parent, &target)
+		if err != nil {
+			return client.FieldKind_None, err
+		}
+
+		switch {
+		case kind == client.FieldKind_None || kind == targetKind:
+			kind = targetKind
+		case isNumericKind(kind) && isNumericKind(targetKind):
+			kind = client.FieldKind_FLOAT
+		default:
+			return client.FieldKind_None, NewErrIncomparableAggregateTargets(field.Name, kind, targetKind)
+		}
+	}
+	return kind, nil
+}
+
+// comparableValueKind returns the kind of the values of the given aggregate target.
+func (p *Planner) comparableValueKind(
+	parent *mapper.Select,
+	source *mapper.AggregateTarget,
+) (client.FieldKind, error) {
+	if !source.ChildTarget.HasValue {
+		parentCol, err := p.db.GetCollectionByName(p.ctx, parent.CollectionName)
+		if err != nil {
+			return client.FieldKind_None, err
+		}
+
+		fieldDescription, fieldDescriptionFound := parentCol.Schema().GetField(source.Name)
+		if !fieldDescriptionFound {
+			return client.FieldKind_None, client.NewErrFieldNotExist(source.Name)
+		}
+		return comparableKind(fieldDescription.Kind), nil
+	}
+
+	switch source.ChildTarget.Name {
+	case request.CountFieldName:
+		return client.FieldKind_INT, nil
+
+	case request.SumFieldName, request.AverageFieldName:
+		isFloat, err := p.isValueFloat(parent, source)
+		if err != nil {
+			return client.FieldKind_None, err
+		}
+		if isFloat {
+			return client.FieldKind_FLOAT, nil
+		}
+		return client.FieldKind_INT, nil
+	}
+
+	child, isChildSelect := parent.FieldAt(source.Index).AsSelect()
+	if !isChildSelect {
+		return client.FieldKind_None, ErrMissingChildSelect
+	}
+
+	if _, isAggregate := request.Aggregates[source.ChildTarget.Name]; isAggregate {
+		// The min or max of a min or max has the kind of the values of the inner aggregate,
+		// which may itself be an aggregate.
+		sourceField := child.FieldAt(source.ChildTarget.Index).(*mapper.Aggregate)
+		return p.comparableAggregateKind(sourceField, child)
+	}
+
+	childCol, err := p.db.GetCollectionByName(p.ctx, child.CollectionName)
+	if err != nil {
+		return client.FieldKind_None, err
+	}
+
+	fieldDescription, fieldDescriptionFound := childCol.Schema().GetField(source.ChildTarget.Name)
+	if !fieldDescriptionFound {
+		return client.FieldKind_None, client.NewErrFieldNotExist(source.ChildTarget.Name)
+	}
+	return comparableKind(fieldDescription.Kind), nil
+}
+
+// comparableKind returns the kind of the values of a field of the given kind, that is the
+// kind of its elements if it is an array.
+func comparableKind(kind client.FieldKind) client.FieldKind {
+	if elementKind, _ := kind.ElementKind(); elementKind != client.FieldKind_None {
+		return elementKind
+	}
+	return kind
+}
+
+func isNumericKind(kind client.FieldKind) bool {
+	return kind == client.FieldKind_INT || kind == client.FieldKind_FLOAT
+}
+
+// aggregateExtreme returns the value of the given targets that is preferred over all the
+// others, or nil if none of the targets have a value.
+//
+// Values are compared according to the given kind, values that are not of that kind are
+// skipped.  The preference is given the comparison of a value to the current preferred value,
+// so min prefers values that compare as less and max those that compare as greater.
+func aggregateExtreme(
+	doc core.Doc,
+	aggregateMapping []mapper.AggregateTarget,
+	kind client.FieldKind,
+	isPreferred func(comparison int) bool,
+) (any, error) {
+	var result any
+	var resultKey any
+	var visitErr error
+	visit := func(value any) {
+		key, isComparable, err := toComparableKey(value, kind)
+		if err != nil {
+			visitErr = err
+			return
+		}
+		if !isComparable {
+			// nil values and values of other kinds cannot be compared and are skipped
+			return
+		}
+		if resultKey == nil || isPreferred(compareKeys(key, resultKey)) {
+			resultKey = key
+			result = value
+			if isNumericKind(kind) {
+				result = key
+			}
+		}
+	}
+
+	for _, source := range aggregateMapping {
+		var err error
+		switch childCollection := doc.Fields[source.Index].(type) {
+		case []core.Doc:
+			for _, childItem := range childCollection {
+				// Hidden items are a grouping mechanic, and must be skipped to avoid
+				// applying offsets twice.
+				if !childItem.Hidden {
+					visit(childItem.Fields[source.ChildTarget.Index])
+				}
+			}
+
+		case []int64:
+			err = enumerable.ForEach(
+				aggregateItems(childCollection, &source, lessN[int64]),
+				func(item int64) { visit(item) },
+			)
+
+		case []immutable.Option[int64]:
+			err = enumerable.ForEach(
+				aggregateItems(childCollection, &source, lessO[int64]),
+				func(item immutable.Option[int64]) {
+					if item.HasValue() {
+						visit(item.Value())
+					}
+				},
+			)
+
+		case []float64:
+			err = enumerable.ForEach(
+				aggregateItems(childCollection, &source, lessN[float64]),
+				func(item float64) { visit(item) },
+			)
+
+		case []immutable.Option[float64]:
+			err = enumerable.ForEach(
+				aggregateItems(childCollection, &source, lessO[float64]),
+				func(item immutable.Option[float64]) {
+					if item.HasValue() {
+						visit(item.Value())
+					}
+				},
+			)
+
+		case []string:
+			err = enumerable.ForEach(
+				aggregateItems(childCollection, &source, lessN[string]),
+				func(item string) { visit(item) },
+			)
+
+		case []immutable.Option[string]:
+			err = enumerable.ForEach(
+				aggregateItems(childCollection, &source, lessO[string]),
+				func(item immutable.Option[string]) {
+					if item.HasValue() {
+						visit(item.Value())
+					}
+				},
+			)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if visitErr != nil {
+			return nil, visitErr
+		}
+	}
+
+	if intResult, isInt := result.(int64); isInt && kind == client.FieldKind_FLOAT {
+		return float64(intResult), nil
+	}
+	return result, nil
+}
+
+// toComparableKey returns the given value in the form in which values of the given kind are
+// compared, and true if the value is of that kind.
+//
+// Numbers are returned as an int64 or float64, strings as they are and date times as a [time.Time].
+func toComparableKey(value any, kind client.FieldKind) (any, bool, error) {
+	switch kind {
+	case client.FieldKind_INT, client.FieldKind_FLOAT:
+		number, isNumber := toAggregateNumber(value)
+		return number, isNumber, nil
+
+	case client.FieldKind_STRING:
+		str, isString := value.(string)
+		return str, isString, nil
+
+	case client.FieldKind_DATETIME:
+		switch v := value.(type) {
+		case time.Time:
+			return v, true, nil
+		case string:
+			// Date times are persisted as RFC3339 strings
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, false, err
+			}
+			return t, true, nil
+		default:
+			return nil, false, nil
+		}
+
+	default:
+		return nil, false, nil
+	}
+}
+
+// compareKeys returns -1 if a is less than b, 1 if a is greater than b, and 0 otherwise.
+//
+// The given values must both have been returned by [toComparableKey] for the same kind.
+func compareKeys(a any, b any) int {
+	switch aValue := a.(type) {
+	case string:
+		return strings.Compare(aValue, b.(string))
+	case time.Time:
+		return aValue.Compare(b.(time.Time))
+	default:
+		return compareNumbers(a, b)
+	}
+}
+
+// toAggregateNumber returns the given value as an int64 or float64, and true if it is a number.
+func toAggregateNumber(value any) (any, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		if v > math.MaxInt64 {
+			return float64(v), true
+		}
+		return int64(v), true
+	case float64:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// compareNumbers returns -1 if a is less than b, 1 if a is greater than b, and 0 otherwise.
+//
+// The given values must be an int64 or float64. Ints are compared as ints so that no
+// precision is lost.
+func compareNumbers(a any, b any) int {
+	aInt, isAInt := a.(int64)
+	bInt, isBInt := b.(int64)
+	if isAInt && isBInt {
+		switch {
+		case aInt < bInt:
+			return -1
+		case aInt > bInt:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	aFloat := toFloat(a)
+	bFloat := toFloat(b)
+	switch {
+	case aFloat < bFloat:
+		return -1
+	case aFloat > bFloat:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toFloat(value any) float64 {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return 0
+	}
+}
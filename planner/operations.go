@@ -11,13 +11,17 @@
 package planner
 
 var (
+	_ planNode = (*aggregateFilterNode)(nil)
 	_ planNode = (*averageNode)(nil)
+	_ planNode = (*changeScanNode)(nil)
 	_ planNode = (*countNode)(nil)
 	_ planNode = (*createNode)(nil)
 	_ planNode = (*dagScanNode)(nil)
 	_ planNode = (*deleteNode)(nil)
 	_ planNode = (*groupNode)(nil)
 	_ planNode = (*limitNode)(nil)
+	_ planNode = (*maxNode)(nil)
+	_ planNode = (*minNode)(nil)
 	_ planNode = (*multiScanNode)(nil)
 	_ planNode = (*orderNode)(nil)
 	_ planNode = (*parallelNode)(nil)
@@ -31,6 +35,7 @@ var (
 	_ planNode = (*typeJoinMany)(nil)
 	_ planNode = (*typeJoinOne)(nil)
 	_ planNode = (*updateNode)(nil)
+	_ planNode = (*upsertNode)(nil)
 	_ planNode = (*valuesNode)(nil)
 
 	_ MultiNode = (*parallelNode)(nil)
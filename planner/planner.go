@@ -135,6 +135,13 @@ func (p *Planner) newPlan(stmt any) (planNode, error) {
 		}
 		return p.CommitSelect(m)
 
+	case *request.ChangeSelect:
+		m, err := mapper.ToChangeSelect(p.ctx, p.db, n)
+		if err != nil {
+			return nil, err
+		}
+		return p.ChangeSelect(m)
+
 	case *request.ObjectMutation:
 		m, err := mapper.ToMutation(p.ctx, p.db, n)
 		if err != nil {
@@ -157,6 +164,9 @@ func (p *Planner) newObjectMutationPlan(stmt *mapper.Mutation) (planNode, error)
 	case mapper.DeleteObjects:
 		return p.DeleteDocs(stmt)
 
+	case mapper.UpsertObjects:
+		return p.UpsertDocs(stmt)
+
 	default:
 		return nil, client.NewErrUnhandledType("mutation", stmt.Type)
 	}
@@ -237,6 +247,12 @@ func (p *Planner) expandPlan(planNode planNode, parentPlan *selectTopNode) error
 	case *deleteNode:
 		return p.expandPlan(n.source, parentPlan)
 
+	case *upsertNode:
+		if err := p.expandPlan(n.source, parentPlan); err != nil {
+			return err
+		}
+		return p.expandPlan(n.results, parentPlan)
+
 	default:
 		return nil
 	}
@@ -261,8 +277,14 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 
 	p.expandAggregatePlans(plan)
 
-	// if order
-	if plan.order != nil {
+	// if aggregate filter, which must be checked after the aggregates have been computed
+	if plan.aggregateFilter != nil {
+		plan.aggregateFilter.plan = plan.planNode
+		plan.planNode = plan.aggregateFilter
+	}
+
+	// if order, unless the documents are already read in order from an index
+	if plan.order != nil && !plan.selectNode.isOrderedByIndex() {
 		plan.order.plan = plan.planNode
 		plan.planNode = plan.order
 	}
@@ -338,19 +360,17 @@ func (p *Planner) tryOptimizeJoinDirection(node *invertibleTypeJoin, parentPlan
 		node.documentMapping,
 	)
 	slct := node.subType.(*selectTopNode).selectNode
-	desc := slct.collection.Description()
-	schema := slct.collection.Schema()
-	indexedFields := desc.CollectIndexedFields(&schema)
-	for _, indField := range indexedFields {
-		if ind, ok := filteredSubFields[indField.Name]; ok {
+	for _, index := range slct.collection.Description().Indexes {
+		indFieldName := index.Fields[0].Name
+		if ind, ok := filteredSubFields[indFieldName]; ok {
 			subInd := node.documentMapping.FirstIndexOfName(node.subTypeName)
 			relatedField := mapper.Field{Name: node.subTypeName, Index: subInd}
 			fieldFilter := filter.UnwrapRelation(filter.CopyField(
 				parentPlan.selectNode.filter,
 				relatedField,
-				mapper.Field{Name: indField.Name, Index: ind},
+				mapper.Field{Name: indFieldName, Index: ind},
 			), relatedField)
-			err := node.invertJoinDirectionWithIndex(fieldFilter, indField)
+			err := node.invertJoinDirectionWithIndex(fieldFilter, index)
 			if err != nil {
 				return err
 			}
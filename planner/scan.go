@@ -48,6 +48,17 @@ type scanNode struct {
 	spans   core.Spans
 	reverse bool
 
+	// isOrderedByIndex is true if the documents are read from an index in the
+	// order requested by the select.
+	isOrderedByIndex bool
+
+	// isJoinedByIndex is true if the documents of a type join are looked up through
+	// an index on the relation field of the join.
+	isJoinedByIndex bool
+
+	// index is the index the documents are fetched with, if any.
+	index immutable.Option[client.IndexDescription]
+
 	filter *mapper.Filter
 	slct   *mapper.Select
 
@@ -138,7 +149,7 @@ func (n *scanNode) tryAddField(fieldName string) bool {
 
 func (scan *scanNode) initFetcher(
 	cid immutable.Option[string],
-	indexedField immutable.Option[client.FieldDescription],
+	index immutable.Option[client.IndexDescription],
 ) {
 	var f fetcher.Fetcher
 	if cid.HasValue() {
@@ -146,14 +157,17 @@ func (scan *scanNode) initFetcher(
 	} else {
 		f = new(fetcher.DocumentFetcher)
 
-		if indexedField.HasValue() {
-			typeIndex := scan.documentMapping.FirstIndexOfName(indexedField.Value().Name)
-			field := mapper.Field{Index: typeIndex, Name: indexedField.Value().Name}
+		if index.HasValue() {
+			fields := make([]mapper.Field, 0, len(index.Value().Fields))
+			for _, field := range index.Value().Fields {
+				typeIndex := scan.documentMapping.FirstIndexOfName(field.Name)
+				fields = append(fields, mapper.Field{Index: typeIndex, Name: field.Name})
+			}
 			var indexFilter *mapper.Filter
-			scan.filter, indexFilter = filter.SplitByField(scan.filter, field)
-			if indexFilter != nil {
-				fieldDesc, _ := scan.col.Schema().GetField(indexedField.Value().Name)
-				f = fetcher.NewIndexFetcher(f, fieldDesc, indexFilter)
+			scan.filter, indexFilter = filter.SplitByTopLevelFields(scan.filter, fields...)
+			if indexFilter != nil || scan.isOrderedByIndex || scan.isJoinedByIndex {
+				f = fetcher.NewIndexFetcher(f, index.Value(), indexFilter)
+				scan.index = index
 			}
 		}
 
@@ -16,9 +16,11 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/planner/filter"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 )
 
@@ -49,10 +51,11 @@ SELECT * From TableA as A JOIN TableB as B ON a.id = b.friend_id
 type selectTopNode struct {
 	docMapper
 
-	group      *groupNode
-	order      *orderNode
-	limit      *limitNode
-	aggregates []aggregateNode
+	group           *groupNode
+	order           *orderNode
+	limit           *limitNode
+	aggregates      []aggregateNode
+	aggregateFilter *aggregateFilterNode
 
 	// selectNode is used pre-wiring of the plan (before expansion and all).
 	selectNode *selectNode
@@ -290,26 +293,169 @@ func (n *selectNode) initSource() ([]aggregateNode, error) {
 	}
 
 	if isScanNode {
-		origScan.initFetcher(n.selectReq.Cid, findFilteredByIndexedField(origScan))
+		index := findIndexByFilteredField(origScan)
+		if n.canOrderByIndex() {
+			orderIndex, reverse := findIndexByOrder(origScan, n.selectReq.OrderBy)
+			if orderIndex.HasValue() {
+				index = orderIndex
+				origScan.reverse = reverse
+				origScan.isOrderedByIndex = true
+			}
+		}
+		origScan.initFetcher(n.selectReq.Cid, index)
 	}
 
 	return aggregates, nil
 }
 
-func findFilteredByIndexedField(scanNode *scanNode) immutable.Option[client.FieldDescription] {
-	if scanNode.filter != nil {
-		schema := scanNode.col.Schema()
-		indexedFields := scanNode.col.Description().CollectIndexedFields(&schema)
-		for i := range indexedFields {
-			typeIndex := scanNode.documentMapping.FirstIndexOfName(indexedFields[i].Name)
-			if scanNode.filter.HasIndex(typeIndex) {
-				// we return the first found indexed field to keep it simple for now
-				// more sophisticated optimization logic can be added later
-				return immutable.Some(indexedFields[i])
+// canOrderByIndex returns true if the documents of the select can be read in the
+// requested order straight from an index.
+//
+// Requests that are grouped, versioned, target specific documents or include deleted
+// documents are ordered after they have been fetched. So are requests filtered by
+// related objects, as the join direction may be inverted for them.
+func (n *selectNode) canOrderByIndex() bool {
+	if n.selectReq.OrderBy == nil || n.selectReq.GroupBy != nil || n.selectReq.ShowDeleted ||
+		n.selectReq.Cid.HasValue() || n.selectReq.DocKeys.HasValue() {
+		return false
+	}
+	if n.selectReq.Filter == nil {
+		return true
+	}
+	for _, prop := range filter.ExtractProperties(n.selectReq.Filter.Conditions) {
+		if prop.IsRelation() {
+			return false
+		}
+	}
+	return true
+}
+
+// isOrderedByIndex returns true if the source of the select returns the documents
+// in the requested order, so that they don't have to be sorted.
+func (n *selectNode) isOrderedByIndex() bool {
+	scan, isScanNode := n.origSource.(*scanNode)
+	return isScanNode && scan.isOrderedByIndex
+}
+
+// findIndexByFilteredField returns the index that can be used to fetch the documents
+// matching the filter of the given scan node.
+//
+// An index can be used if its leading field is filtered on the top level of the filter.
+// If several indexes can be used, the one with the most leading fields filtered is chosen.
+func findIndexByFilteredField(scanNode *scanNode) immutable.Option[client.IndexDescription] {
+	if scanNode.filter == nil {
+		return immutable.None[client.IndexDescription]()
+	}
+	var result immutable.Option[client.IndexDescription]
+	bestMatchedFields := 0
+	for _, index := range scanNode.col.Description().Indexes {
+		matchedFields := 0
+		for _, field := range index.Fields {
+			typeIndex := scanNode.documentMapping.FirstIndexOfName(field.Name)
+			if !scanNode.filter.HasIndex(typeIndex) {
+				break
+			}
+			matchedFields++
+		}
+		if matchedFields > bestMatchedFields {
+			bestMatchedFields = matchedFields
+			result = immutable.Some(index)
+		}
+	}
+	return result
+}
+
+// findIndexByOrder returns the index that returns the documents of the given scan
+// node in the requested order. The second return value is true if the index has to
+// be read in reverse.
+//
+// The ordered fields have to be the index fields that directly follow the leading
+// fields filtered with _eq on the top level of the filter. Ordering by a field that
+// is filtered with _eq has no effect, so such fields are ignored. The directions of
+// all ordered fields must either match the directions of the index fields or all be
+// opposite to them.
+// If several indexes can be used, the one with the most leading fields filtered is chosen.
+func findIndexByOrder(
+	scanNode *scanNode,
+	orderBy *mapper.OrderBy,
+) (immutable.Option[client.IndexDescription], bool) {
+	conditions := make([]mapper.OrderCondition, 0, len(orderBy.Conditions))
+	for _, cond := range orderBy.Conditions {
+		if len(cond.FieldIndexes) != 1 {
+			// ordering by related objects
+			return immutable.None[client.IndexDescription](), false
+		}
+		if !hasTopLevelEqCondition(scanNode.filter, cond.FieldIndexes[0]) {
+			conditions = append(conditions, cond)
+		}
+	}
+	if len(conditions) == 0 {
+		return immutable.None[client.IndexDescription](), false
+	}
+
+	var result immutable.Option[client.IndexDescription]
+	var resultReverse bool
+	bestMatchedFields := -1
+	for _, index := range scanNode.col.Description().Indexes {
+		eqFields := 0
+		for _, field := range index.Fields {
+			typeIndex := scanNode.documentMapping.FirstIndexOfName(field.Name)
+			if !hasTopLevelEqCondition(scanNode.filter, typeIndex) {
+				break
+			}
+			eqFields++
+		}
+		if eqFields+len(conditions) > len(index.Fields) || eqFields <= bestMatchedFields {
+			continue
+		}
+
+		isOrdered := true
+		reverse := false
+		for i, cond := range conditions {
+			field := index.Fields[eqFields+i]
+			if scanNode.documentMapping.FirstIndexOfName(field.Name) != cond.FieldIndexes[0] {
+				isOrdered = false
+				break
+			}
+			isOpposite := (cond.Direction == mapper.DESC) != (field.Direction == client.Descending)
+			if i == 0 {
+				reverse = isOpposite
+			} else if isOpposite != reverse {
+				isOrdered = false
+				break
+			}
+		}
+		if isOrdered {
+			bestMatchedFields = eqFields
+			result = immutable.Some(index)
+			resultReverse = reverse
+		}
+	}
+	return result, resultReverse
+}
+
+// hasTopLevelEqCondition returns true if the filter has an _eq condition on the
+// field with the given index on its top level.
+func hasTopLevelEqCondition(f *mapper.Filter, fieldIndex int) bool {
+	if f == nil {
+		return false
+	}
+	for key, cond := range f.Conditions {
+		propIndex, ok := key.(*mapper.PropertyIndex)
+		if !ok || propIndex.Index != fieldIndex {
+			continue
+		}
+		condMap, ok := cond.(map[connor.FilterKey]any)
+		if !ok {
+			continue
+		}
+		for opKey := range condMap {
+			if op, ok := opKey.(*mapper.Operator); ok && op.Operation == mapper.FilterEqOp.Operation {
+				return true
 			}
 		}
 	}
-	return immutable.None[client.FieldDescription]()
+	return false
 }
 
 func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, error) {
@@ -329,6 +475,10 @@ func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, erro
 				plan, aggregateError = n.planner.Sum(f, selectReq)
 			case request.AverageFieldName:
 				plan, aggregateError = n.planner.Average(f)
+			case request.MinFieldName:
+				plan, aggregateError = n.planner.Min(f, selectReq)
+			case request.MaxFieldName:
+				plan, aggregateError = n.planner.Max(f, selectReq)
 			}
 
 			if aggregateError != nil {
@@ -451,13 +601,19 @@ func (p *Planner) SelectFromSource(
 		return nil, err
 	}
 
+	aggregateFilterPlan, err := p.AggregateFilter(selectReq)
+	if err != nil {
+		return nil, err
+	}
+
 	top := &selectTopNode{
-		selectNode: s,
-		limit:      limitPlan,
-		order:      orderPlan,
-		group:      groupPlan,
-		aggregates: aggregates,
-		docMapper:  docMapper{selectReq.DocumentMapping},
+		selectNode:      s,
+		limit:           limitPlan,
+		order:           orderPlan,
+		group:           groupPlan,
+		aggregates:      aggregates,
+		aggregateFilter: aggregateFilterPlan,
+		docMapper:       docMapper{selectReq.DocumentMapping},
 	}
 	return top, nil
 }
@@ -495,13 +651,19 @@ func (p *Planner) Select(selectReq *mapper.Select) (planNode, error) {
 		return nil, err
 	}
 
+	aggregateFilterPlan, err := p.AggregateFilter(selectReq)
+	if err != nil {
+		return nil, err
+	}
+
 	top := &selectTopNode{
-		selectNode: s,
-		limit:      limitPlan,
-		order:      orderPlan,
-		group:      groupPlan,
-		aggregates: aggregates,
-		docMapper:  docMapper{selectReq.DocumentMapping},
+		selectNode:      s,
+		limit:           limitPlan,
+		order:           orderPlan,
+		group:           groupPlan,
+		aggregates:      aggregates,
+		aggregateFilter: aggregateFilterPlan,
+		docMapper:       docMapper{selectReq.DocumentMapping},
 	}
 	return top, nil
 }
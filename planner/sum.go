@@ -43,17 +43,9 @@ func (p *Planner) Sum(
 	field *mapper.Aggregate,
 	parent *mapper.Select,
 ) (*sumNode, error) {
-	isFloat := false
-	for _, target := range field.AggregateTargets {
-		isTargetFloat, err := p.isValueFloat(parent, &target)
-		if err != nil {
-			return nil, err
-		}
-		// If one source property is a float, the result will be a float - no need to check the rest
-		if isTargetFloat {
-			isFloat = true
-			break
-		}
+	isFloat, err := p.isAggregateFloat(field, parent)
+	if err != nil {
+		return nil, err
 	}
 
 	return &sumNode{
@@ -65,6 +57,24 @@ func (p *Planner) Sum(
 	}, nil
 }
 
+// Returns true if the result of the given aggregate is a float, otherwise false.
+func (p *Planner) isAggregateFloat(
+	field *mapper.Aggregate,
+	parent *mapper.Select,
+) (bool, error) {
+	for _, target := range field.AggregateTargets {
+		isTargetFloat, err := p.isValueFloat(parent, &target)
+		if err != nil {
+			return false, err
+		}
+		// If one source property is a float, the result will be a float - no need to check the rest
+		if isTargetFloat {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Returns true if the value to be summed is a float, otherwise false.
 func (p *Planner) isValueFloat(
 	parent *mapper.Select,
@@ -330,6 +340,23 @@ func sumItems[T any](
 	less func(T, T) bool,
 	toFloat func(T) float64,
 ) (float64, error) {
+	items := aggregateItems(source, aggregateTarget, less)
+
+	var sum float64 = 0
+	err := enumerable.ForEach(items, func(item T) {
+		sum += toFloat(item)
+	})
+
+	return sum, err
+}
+
+// aggregateItems returns the items of the given inline array that are to be aggregated,
+// applying the filter, order and limit of the given target.
+func aggregateItems[T any](
+	source []T,
+	aggregateTarget *mapper.AggregateTarget,
+	less func(T, T) bool,
+) enumerable.Enumerable[T] {
 	items := enumerable.New(source)
 	if aggregateTarget.Filter != nil {
 		items = enumerable.Where(items, func(item T) (bool, error) {
@@ -350,12 +377,7 @@ func sumItems[T any](
 		items = enumerable.Take(items, aggregateTarget.Limit.Limit)
 	}
 
-	var sum float64 = 0
-	err := enumerable.ForEach(items, func(item T) {
-		sum += toFloat(item)
-	})
-
-	return sum, err
+	return items
 }
 
 func (n *sumNode) SetPlan(p planNode) { n.plan = p }
@@ -364,11 +386,15 @@ type number interface {
 	int64 | float64
 }
 
-func lessN[T number](a T, b T) bool {
+type ordered interface {
+	number | string
+}
+
+func lessN[T ordered](a T, b T) bool {
 	return a < b
 }
 
-func lessO[T number](a immutable.Option[T], b immutable.Option[T]) bool {
+func lessO[T ordered](a immutable.Option[T], b immutable.Option[T]) bool {
 	if !a.HasValue() {
 		return true
 	}
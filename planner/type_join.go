@@ -284,6 +284,10 @@ func (p *Planner) makeTypeJoinOne(
 		primaryField:   subTypeFieldDesc.Name + request.RelatedObjectID,
 	}
 
+	if !isPrimary {
+		useIndexForJoinField(selectPlan, dir.secondaryField)
+	}
+
 	return &typeJoinOne{
 		invertibleTypeJoin: invertibleTypeJoin{
 			docMapper:           docMapper{parent.documentMapping},
@@ -412,6 +416,8 @@ func (p *Planner) makeTypeJoinMany(
 		primaryField:   subTypeFieldDesc.Name + request.RelatedObjectID,
 	}
 
+	useIndexForJoinField(selectPlan, dir.secondaryField)
+
 	return &typeJoinMany{
 		invertibleTypeJoin: invertibleTypeJoin{
 			docMapper:           docMapper{parent.documentMapping},
@@ -606,18 +612,35 @@ func (join *invertibleTypeJoin) Next() (bool, error) {
 
 func (join *invertibleTypeJoin) invertJoinDirectionWithIndex(
 	fieldFilter *mapper.Filter,
-	field client.FieldDescription,
+	index client.IndexDescription,
 ) error {
 	subScan := getScanNode(join.subType)
 	subScan.tryAddField(join.rootName + request.RelatedObjectID)
 	subScan.filter = fieldFilter
-	subScan.initFetcher(immutable.Option[string]{}, immutable.Some(field))
+	subScan.initFetcher(immutable.Option[string]{}, immutable.Some(index))
 
 	join.invert()
 
 	return nil
 }
 
+// useIndexForJoinField makes the scan of the given plan look up the joined documents
+// through an index led by the given relation field, unless the scan already reads
+// from an index.
+func useIndexForJoinField(plan planNode, fieldName string) {
+	scan := getScanNode(plan)
+	if scan == nil || scan.index.HasValue() || scan.slct.Cid.HasValue() {
+		return
+	}
+	for _, index := range scan.col.Description().Indexes {
+		if index.Fields[0].Name == fieldName {
+			scan.isJoinedByIndex = true
+			scan.initFetcher(immutable.None[string](), immutable.Some(index))
+			return
+		}
+	}
+}
+
 func setSubTypeFilterToScanNode(plan planNode, propIndex int, val any) {
 	scan := getScanNode(plan)
 	if scan == nil {
@@ -0,0 +1,257 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// upsertNode is used to construct and execute an object upsert mutation.
+//
+// All documents yielded by the source plan are updated with the given patch. If
+// the source plan yields no documents the new document is created instead, if it
+// already exists but does not match the filter an error is returned. The documents that
+// were updated or created are then yielded by the results plan.
+//
+// The upsert happens within the transaction of the request. Concurrent upserts that
+// create the same document will conflict on commit, the transaction that is committed
+// last must then be retried, upon which it will update the created document if it
+// matches the filter.
+type upsertNode struct {
+	documentIterator
+	docMapper
+
+	p *Planner
+
+	collection client.Collection
+
+	filter *mapper.Filter
+
+	patch     string
+	newDocStr string
+
+	isUpserting bool
+
+	// source yields the documents matching the filter
+	source planNode
+	// results yields the documents that were updated or created
+	results planNode
+
+	execInfo upsertExecInfo
+}
+
+type upsertExecInfo struct {
+	// Total number of times upsertNode was executed.
+	iterations uint64
+
+	// Total number of successful updates.
+	updates uint64
+
+	// Total number of successful creates.
+	creates uint64
+}
+
+// Next performs the upsert on the first call, and then yields the affected documents.
+func (n *upsertNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if n.isUpserting {
+		err := n.upsert()
+		if err != nil {
+			return false, err
+		}
+		n.isUpserting = false
+	}
+
+	next, err := n.results.Next()
+	if err != nil {
+		return false, err
+	}
+	if !next {
+		return false, nil
+	}
+
+	n.currentValue = n.results.Value()
+	return true, nil
+}
+
+func (n *upsertNode) upsert() error {
+	docKeys := []string{}
+	for {
+		next, err := n.source.Next()
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+
+		n.currentValue = n.source.Value()
+		key, err := client.NewDocKeyFromString(n.currentValue.GetKey())
+		if err != nil {
+			return err
+		}
+		_, err = n.collection.UpdateWithKey(n.p.ctx, key, n.patch)
+		if err != nil {
+			return err
+		}
+
+		docKeys = append(docKeys, key.String())
+		n.execInfo.updates++
+	}
+
+	if len(docKeys) == 0 {
+		doc, err := client.NewDocFromJSON([]byte(n.newDocStr))
+		if err != nil {
+			return err
+		}
+		err = n.collection.Create(n.p.ctx, doc)
+		if err != nil {
+			return err
+		}
+
+		docKeys = append(docKeys, doc.Key().String())
+		n.execInfo.creates++
+	}
+
+	// The results are scoped to the affected documents, so that they are yielded
+	// with their new values regardless of whether they still match the filter.
+	sort.Strings(docKeys)
+	desc := n.collection.Description()
+	spans := make([]core.Span, len(docKeys))
+	for i, docKey := range docKeys {
+		dsKey := base.MakeDocKey(desc, docKey)
+		spans[i] = core.NewSpan(dsKey, dsKey.PrefixEnd())
+	}
+	n.results.Spans(core.NewSpans(spans...))
+
+	err := n.results.Init()
+	if err != nil {
+		return err
+	}
+	return n.results.Start()
+}
+
+func (n *upsertNode) Kind() string { return "upsertNode" }
+
+func (n *upsertNode) Spans(spans core.Spans) { n.source.Spans(spans) }
+
+func (n *upsertNode) Init() error { return n.source.Init() }
+
+func (n *upsertNode) Start() error { return n.source.Start() }
+
+func (n *upsertNode) Close() error {
+	if err := n.source.Close(); err != nil {
+		return err
+	}
+	return n.results.Close()
+}
+
+func (n *upsertNode) Source() planNode { return n.source }
+
+func (n *upsertNode) simpleExplain() (map[string]any, error) {
+	simpleExplainMap := map[string]any{}
+
+	// Add the filter attribute if it exists, otherwise have it nil.
+	if n.filter == nil {
+		simpleExplainMap[filterLabel] = nil
+	} else {
+		simpleExplainMap[filterLabel] = n.filter.ToMap(n.documentMapping)
+	}
+
+	// Add the attribute that represents the patch to update with.
+	data := map[string]any{}
+	err := json.Unmarshal([]byte(n.patch), &data)
+	if err != nil {
+		return nil, err
+	}
+	simpleExplainMap[dataLabel] = data
+
+	// Add the attribute that represents the document to create.
+	createData := map[string]any{}
+	err = json.Unmarshal([]byte(n.newDocStr), &createData)
+	if err != nil {
+		return nil, err
+	}
+	simpleExplainMap[createDataLabel] = createData
+
+	return simpleExplainMap, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *upsertNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+			"updates":    n.execInfo.updates,
+			"creates":    n.execInfo.creates,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (p *Planner) UpsertDocs(parsed *mapper.Mutation) (planNode, error) {
+	if parsed.Filter == nil {
+		return nil, ErrUpsertMissingFilter
+	}
+	if parsed.Data == "" || parsed.CreateData == "" {
+		return nil, ErrUpsertMissingData
+	}
+
+	upsert := &upsertNode{
+		p:           p,
+		filter:      parsed.Filter,
+		isUpserting: true,
+		patch:       parsed.Data,
+		newDocStr:   parsed.CreateData,
+		docMapper:   docMapper{parsed.DocumentMapping},
+	}
+
+	// get collection
+	col, err := p.db.GetCollectionByName(p.ctx, parsed.Name)
+	if err != nil {
+		return nil, err
+	}
+	upsert.collection = col.WithTxn(p.txn)
+
+	// create the source Select node, yielding the documents matching the filter
+	sourceNode, err := p.Select(&parsed.Select)
+	if err != nil {
+		return nil, err
+	}
+	upsert.source = sourceNode
+
+	// create the results Select node, this is not filtered as it is scoped to the
+	// affected documents once the upsert has been performed
+	resultsSelect := parsed.Select
+	resultsSelect.Filter = nil
+	resultsNode, err := p.Select(&resultsSelect)
+	if err != nil {
+		return nil, err
+	}
+	upsert.results = resultsNode
+
+	return upsert, nil
+}
@@ -103,11 +103,16 @@ func (p *parser) Parse(ast *ast.Document) (*request.Request, []error) {
 	return query, nil
 }
 
-func (p *parser) ParseSDL(ctx context.Context, schemaString string) (
+func (p *parser) ParseSDL(
+	ctx context.Context,
+	schemaString string,
+	existingDefinitions []client.CollectionDefinition,
+) (
 	[]client.CollectionDefinition,
+	[]client.SchemaDescription,
 	error,
 ) {
-	return schema.FromString(ctx, schemaString)
+	return schema.FromString(ctx, schemaString, existingDefinitions)
 }
 
 func (p *parser) SetSchema(ctx context.Context, txn datastore.Txn, collections []client.CollectionDefinition) error {
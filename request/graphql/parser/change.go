@@ -0,0 +1,68 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser
+
+import (
+	"strconv"
+
+	gql "github.com/sourcenetwork/graphql-go"
+	"github.com/sourcenetwork/graphql-go/language/ast"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+func parseChangeSelect(schema gql.Schema, parent *gql.Object, field *ast.Field) (*request.ChangeSelect, error) {
+	change := &request.ChangeSelect{
+		Field: request.Field{
+			Name:  field.Name.Value,
+			Alias: getFieldAlias(field),
+		},
+	}
+
+	for _, argument := range field.Arguments {
+		prop := argument.Name.Value
+		if prop == request.Collection {
+			raw := argument.Value.(*ast.StringValue)
+			change.Collection = raw.Value
+		} else if prop == request.AfterClause {
+			val := argument.Value.(*ast.IntValue)
+			after, err := strconv.ParseUint(val.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			change.After = immutable.Some(after)
+		} else if prop == request.LimitClause {
+			val := argument.Value.(*ast.IntValue)
+			limit, err := strconv.ParseUint(val.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			change.Limit = immutable.Some(limit)
+		}
+	}
+
+	// no sub fields (unlikely)
+	if field.SelectionSet == nil {
+		return change, nil
+	}
+
+	fieldDef := gql.GetFieldDef(schema, parent, field.Name.Value)
+
+	fieldObject, err := typeFromFieldDef(fieldDef)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Fields, err = parseSelectFields(schema, request.ChangeSelection, fieldObject, field.SelectionSet)
+
+	return change, err
+}
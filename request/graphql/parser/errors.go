@@ -12,16 +12,33 @@ package parser
 
 import "github.com/sourcenetwork/defradb/errors"
 
+const (
+	errUnknownSubscriptionEvent      string = "unknown subscription event type"
+	errUnknownSubscriptionEventField string = "unknown subscription event field"
+)
+
 var (
-	ErrFilterMissingArgumentType      = errors.New("couldn't find filter argument type")
-	ErrInvalidOrderDirection          = errors.New("invalid order direction string")
-	ErrFailedToParseConditionsFromAST = errors.New("couldn't parse conditions value from AST")
-	ErrFailedToParseConditionValue    = errors.New("failed to parse condition value from query filter statement")
-	ErrEmptyDataPayload               = errors.New("given data payload is empty")
-	ErrUnknownMutationName            = errors.New("unknown mutation name")
-	ErrInvalidExplainTypeArg          = errors.New("invalid explain request type argument")
-	ErrInvalidNumberOfExplainArgs     = errors.New("invalid number of arguments to an explain request")
-	ErrUnknownExplainType             = errors.New("invalid / unknown explain type")
-	ErrUnknownGQLOperation            = errors.New("unknown GraphQL operation type")
-	ErrInvalidFilterConditions        = errors.New("invalid filter condition type, expected map")
+	ErrFilterMissingArgumentType         = errors.New("couldn't find filter argument type")
+	ErrInvalidOrderDirection             = errors.New("invalid order direction string")
+	ErrFailedToParseConditionsFromAST    = errors.New("couldn't parse conditions value from AST")
+	ErrFailedToParseConditionValue       = errors.New("failed to parse condition value from query filter statement")
+	ErrEmptyDataPayload                  = errors.New("given data payload is empty")
+	ErrUnknownMutationName               = errors.New("unknown mutation name")
+	ErrInvalidExplainTypeArg             = errors.New("invalid explain request type argument")
+	ErrInvalidNumberOfExplainArgs        = errors.New("invalid number of arguments to an explain request")
+	ErrUnknownExplainType                = errors.New("invalid / unknown explain type")
+	ErrUnknownGQLOperation               = errors.New("unknown GraphQL operation type")
+	ErrInvalidFilterConditions           = errors.New("invalid filter condition type, expected map")
+	ErrSubscriptionEventMissingSelection = errors.New("subscription event field requires a selection")
+	ErrUnknownSubscriptionEvent          = errors.New(errUnknownSubscriptionEvent)
+	ErrUnknownSubscriptionEventField     = errors.New(errUnknownSubscriptionEventField)
+	ErrInvalidResumeToken                = errors.New("subscription resume token must be a string")
 )
+
+func NewErrUnknownSubscriptionEvent(name string) error {
+	return errors.New(errUnknownSubscriptionEvent, errors.NewKV("Event", name))
+}
+
+func NewErrUnknownSubscriptionEventField(name string) error {
+	return errors.New(errUnknownSubscriptionEventField, errors.NewKV("Field", name))
+}
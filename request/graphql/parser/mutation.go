@@ -26,6 +26,7 @@ var (
 		"create": request.CreateObjects,
 		"update": request.UpdateObjects,
 		"delete": request.DeleteObjects,
+		"upsert": request.UpsertObjects,
 	}
 )
 
@@ -73,7 +74,7 @@ func parseMutation(schema gql.Schema, parent *gql.Object, field *ast.Field) (*re
 	// parse the mutation type
 	// mutation names are either generated from a type
 	// which means they are in the form name_type, where
-	// the name is the object mutation name (ie: create, update, delete)
+	// the name is the object mutation name (ie: create, update, delete, upsert)
 	// or its an general API mutation, which is in the form
 	// name (camelCase).
 	// This means we can split on the "_" character, and always
@@ -105,6 +106,35 @@ func parseMutation(schema gql.Schema, parent *gql.Object, field *ast.Field) (*re
 				return nil, ErrEmptyDataPayload
 			}
 			mut.Data = raw.Value
+		} else if prop == request.Input { // parse batch create payload
+			raw := argument.Value.(*ast.ListValue)
+			if len(raw.Values) == 0 {
+				return nil, ErrEmptyDataPayload
+			}
+			input := make([]string, len(raw.Values))
+			for i, val := range raw.Values {
+				doc, ok := val.(*ast.StringValue)
+				if !ok {
+					return nil, client.NewErrUnexpectedType[*ast.StringValue]("input argument", val)
+				}
+				if doc.Value == "" {
+					return nil, ErrEmptyDataPayload
+				}
+				input[i] = doc.Value
+			}
+			mut.Input = input
+		} else if prop == request.UpsertCreate { // parse upsert create payload
+			raw := argument.Value.(*ast.StringValue)
+			if raw.Value == "" {
+				return nil, ErrEmptyDataPayload
+			}
+			mut.CreateData = raw.Value
+		} else if prop == request.UpsertUpdate { // parse upsert update payload
+			raw := argument.Value.(*ast.StringValue)
+			if raw.Value == "" {
+				return nil, ErrEmptyDataPayload
+			}
+			mut.Data = raw.Value
 		} else if prop == request.FilterClause { // parse filter
 			obj := argument.Value.(*ast.ObjectValue)
 			filterType, ok := getArgumentType(fieldDef, request.FilterClause)
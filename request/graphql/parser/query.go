@@ -42,6 +42,13 @@ func parseQueryOperationDefinition(
 					return nil, []error{err}
 				}
 
+				parsedSelection = parsed
+			} else if node.Name.Value == request.ChangesName {
+				parsed, err := parseChangeSelect(schema, schema.QueryType(), node)
+				if err != nil {
+					return nil, []error{err}
+				}
+
 				parsedSelection = parsed
 			} else if _, isAggregate := request.Aggregates[node.Name.Value]; isAggregate {
 				parsed, err := parseAggregate(schema, schema.QueryType(), node, i)
@@ -200,14 +207,22 @@ func parseSelect(
 }
 
 func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, index int) (*request.Aggregate, error) {
-	targets := make([]*request.AggregateTarget, len(field.Arguments))
+	targets := make([]*request.AggregateTarget, 0, len(field.Arguments))
+	var distinct bool
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value == request.Distinct {
+			if distinctValue, isBool := argument.Value.GetValue().(bool); isBool {
+				distinct = distinctValue
+			}
+			continue
+		}
 
-	for i, argument := range field.Arguments {
 		switch argumentValue := argument.Value.GetValue().(type) {
 		case string:
-			targets[i] = &request.AggregateTarget{
+			targets = append(targets, &request.AggregateTarget{
 				HostName: argumentValue,
-			}
+			})
 		case []*ast.ObjectField:
 			hostName := argument.Name.Value
 			var childName string
@@ -303,14 +318,14 @@ func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, ind
 				}
 			}
 
-			targets[i] = &request.AggregateTarget{
+			targets = append(targets, &request.AggregateTarget{
 				HostName:  hostName,
 				ChildName: immutable.Some(childName),
 				Filter:    filter,
 				Limit:     limit,
 				Offset:    offset,
 				OrderBy:   order,
-			}
+			})
 		}
 	}
 
@@ -319,6 +334,7 @@ func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, ind
 			Name:  field.Name.Value,
 			Alias: getFieldAlias(field),
 		},
-		Targets: targets,
+		Targets:  targets,
+		Distinct: distinct,
 	}, nil
 }
@@ -13,7 +13,9 @@ package parser
 import (
 	gql "github.com/sourcenetwork/graphql-go"
 	"github.com/sourcenetwork/graphql-go/language/ast"
+	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 )
 
@@ -70,6 +72,19 @@ func parseSubscription(schema gql.Schema, field *ast.Field) (*request.ObjectSubs
 			}
 
 			sub.Filter = filter
+		} else if prop == request.EventsClause {
+			events, err := parseSubscriptionEvents(argument.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			sub.Events = events
+		} else if prop == request.AfterClause {
+			raw, ok := argument.Value.(*ast.StringValue)
+			if !ok {
+				return nil, ErrInvalidResumeToken
+			}
+			sub.ResumeToken = immutable.Some(raw.Value)
 		}
 	}
 
@@ -79,6 +94,109 @@ func parseSubscription(schema gql.Schema, field *ast.Field) (*request.ObjectSubs
 		return nil, err
 	}
 
-	sub.Fields, err = parseSelectFields(schema, request.ObjectSelection, fieldObject, field.SelectionSet)
+	// The `_event` metadata field is not part of the object type, so it is parsed
+	// separately from the rest of the selections.
+	selectionSet := &ast.SelectionSet{
+		Kind: field.SelectionSet.Kind,
+		Loc:  field.SelectionSet.Loc,
+	}
+	for _, selection := range field.SelectionSet.Selections {
+		node, ok := selection.(*ast.Field)
+		if !ok || node.Name.Value != request.EventFieldName {
+			selectionSet.Selections = append(selectionSet.Selections, selection)
+			continue
+		}
+
+		eventField, err := parseSubscriptionEventField(schema, fieldObject, node)
+		if err != nil {
+			return nil, err
+		}
+		sub.EventField = immutable.Some(eventField)
+	}
+
+	sub.Fields, err = parseSelectFields(schema, request.ObjectSelection, fieldObject, selectionSet)
 	return sub, err
 }
+
+// parseSubscriptionEvents parses the event types given to the
+// events argument of a subscription.
+func parseSubscriptionEvents(value ast.Value) ([]request.SubscriptionEventType, error) {
+	var values []ast.Value
+	switch v := value.(type) {
+	case *ast.ListValue:
+		values = v.Values
+	default:
+		// GraphQL allows a single value to be given for a list argument
+		values = []ast.Value{v}
+	}
+
+	events := make([]request.SubscriptionEventType, len(values))
+	for i, val := range values {
+		var name string
+		switch v := val.(type) {
+		case *ast.EnumValue:
+			name = v.Value
+		case *ast.StringValue:
+			name = v.Value
+		default:
+			return nil, client.NewErrUnexpectedType[*ast.EnumValue]("events argument", val)
+		}
+
+		event := request.SubscriptionEventType(name)
+		switch event {
+		case request.CreateEvent, request.UpdateEvent, request.DeleteEvent:
+			events[i] = event
+		default:
+			return nil, NewErrUnknownSubscriptionEvent(name)
+		}
+	}
+	return events, nil
+}
+
+// parseSubscriptionEventField parses the `_event` metadata
+// field of a subscription.
+func parseSubscriptionEventField(
+	schema gql.Schema,
+	parent *gql.Object,
+	field *ast.Field,
+) (request.SubscriptionEventField, error) {
+	eventField := request.SubscriptionEventField{
+		Field: request.Field{
+			Name:  field.Name.Value,
+			Alias: getFieldAlias(field),
+		},
+	}
+	if field.SelectionSet == nil {
+		return request.SubscriptionEventField{}, ErrSubscriptionEventMissingSelection
+	}
+
+	for _, selection := range field.SelectionSet.Selections {
+		node, ok := selection.(*ast.Field)
+		if !ok {
+			continue
+		}
+
+		switch node.Name.Value {
+		case request.EventTypeFieldName:
+			eventField.Type = immutable.Some(*parseField(node))
+
+		case request.EventPreviousFieldName:
+			if node.SelectionSet == nil {
+				return request.SubscriptionEventField{}, ErrSubscriptionEventMissingSelection
+			}
+			fields, err := parseSelectFields(schema, request.ObjectSelection, parent, node.SelectionSet)
+			if err != nil {
+				return request.SubscriptionEventField{}, err
+			}
+			eventField.Previous = immutable.Some(request.Select{
+				Field:  *parseField(node),
+				Fields: fields,
+			})
+
+		default:
+			return request.SubscriptionEventField{}, NewErrUnknownSubscriptionEventField(node.Name.Value)
+		}
+	}
+
+	return eventField, nil
+}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
@@ -24,9 +25,18 @@ import (
 	"github.com/sourcenetwork/graphql-go/language/source"
 )
 
-// FromString parses a GQL SDL string into a set of collection descriptions.
-func FromString(ctx context.Context, schemaString string) (
+// FromString parses a GQL SDL string into a set of collection descriptions, and a set of schema
+// descriptions containing the fields that any type extensions add to the types that they extend.
+//
+// Relations from the new types to the given existing types, whose other side is not declared by a
+// type extension, have their inverse field generated on the existing type.
+func FromString(
+	ctx context.Context,
+	schemaString string,
+	existingDefinitions []client.CollectionDefinition,
+) (
 	[]client.CollectionDefinition,
+	[]client.SchemaDescription,
 	error,
 ) {
 	source := source.NewSource(&source.Source{
@@ -39,45 +49,70 @@ func FromString(ctx context.Context, schemaString string) (
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return fromAst(ctx, doc)
+	return fromAst(ctx, doc, existingDefinitions)
 }
 
-// fromAst parses a GQL AST into a set of collection descriptions.
-func fromAst(ctx context.Context, doc *ast.Document) (
+// fromAst parses a GQL AST into a set of collection descriptions and type extensions.
+func fromAst(
+	ctx context.Context,
+	doc *ast.Document,
+	existingDefinitions []client.CollectionDefinition,
+) (
 	[]client.CollectionDefinition,
+	[]client.SchemaDescription,
 	error,
 ) {
 	relationManager := NewRelationManager()
 	definitions := []client.CollectionDefinition{}
+	extensions := []client.SchemaDescription{}
 
 	for _, def := range doc.Definitions {
 		switch defType := def.(type) {
 		case *ast.ObjectDefinition:
 			description, err := fromAstDefinition(ctx, relationManager, defType)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			definitions = append(definitions, description)
 
+		case *ast.TypeExtensionDefinition:
+			extension, err := fromAstExtension(relationManager, defType.Definition)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			extensions = append(extensions, extension)
+
 		default:
 			// Do nothing, ignore it and continue
 			continue
 		}
 	}
 
+	extensions, err := addInverseRelationFields(relationManager, definitions, extensions, existingDefinitions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schemas := make([]client.SchemaDescription, 0, len(definitions)+len(extensions))
+	for _, definition := range definitions {
+		schemas = append(schemas, definition.Schema)
+	}
+	schemas = append(schemas, extensions...)
+
 	// The details on the relations between objects depend on both sides
 	// of the relationship.  The relation manager handles this, and must be applied
 	// after all the collections have been processed.
-	err := finalizeRelations(relationManager, definitions)
+	err = finalizeRelations(relationManager, schemas)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return definitions, nil
+	return definitions, extensions, nil
 }
 
 // fromAstDefinition parses a AST object definition into a set of collection descriptions.
@@ -147,6 +182,156 @@ func fromAstDefinition(
 	}, nil
 }
 
+// fromAstExtension parses a GQL type extension into a schema description containing only the
+// fields that the extension adds to the extended type.
+func fromAstExtension(
+	relationManager *RelationManager,
+	def *ast.ObjectDefinition,
+) (client.SchemaDescription, error) {
+	for _, directive := range def.Directives {
+		if directive.Name.Value == types.IndexDirectiveLabel {
+			return client.SchemaDescription{}, NewErrIndexWithinTypeExtension(def.Name.Value)
+		}
+	}
+
+	fieldDescriptions := []client.FieldDescription{}
+	for _, field := range def.Fields {
+		if _, hasIndex := findDirective(field, types.IndexDirectiveLabel); hasIndex {
+			return client.SchemaDescription{}, NewErrIndexWithinTypeExtension(def.Name.Value)
+		}
+
+		tmpFieldsDescriptions, err := fieldsFromAST(field, relationManager, def)
+		if err != nil {
+			return client.SchemaDescription{}, err
+		}
+
+		for _, fieldDescription := range tmpFieldsDescriptions {
+			// Relation id fields are added by the database when the extension is applied
+			// to the extended type.
+			if fieldDescription.RelationType.IsSet(client.Relation_Type_INTERNAL_ID) {
+				continue
+			}
+			fieldDescriptions = append(fieldDescriptions, fieldDescription)
+		}
+	}
+
+	return client.SchemaDescription{
+		Name:   def.Name.Value,
+		Fields: fieldDescriptions,
+	}, nil
+}
+
+// addInverseRelationFields generates the inverse field of the relations from the new types to existing
+// types that have not been declared by a type extension, adding it to the extension of the existing type.
+//
+// The inverse of a one-sided field is a list named after the new type in the plural (`comments` for a
+// `Comment` type, see [pluralize]), and the inverse of a list field is named after the new type
+// (`comment`).  If a different name or a one-to-one relation is needed, the inverse field must be
+// declared by extending the existing type.
+func addInverseRelationFields(
+	relationManager *RelationManager,
+	definitions []client.CollectionDefinition,
+	extensions []client.SchemaDescription,
+	existingDefinitions []client.CollectionDefinition,
+) ([]client.SchemaDescription, error) {
+	newSchemas := make(map[string]struct{}, len(definitions))
+	for _, definition := range definitions {
+		newSchemas[definition.Schema.Name] = struct{}{}
+	}
+	existingSchemas := make(map[string]client.SchemaDescription, len(existingDefinitions))
+	for _, definition := range existingDefinitions {
+		existingSchemas[definition.Schema.Name] = definition.Schema
+	}
+
+	for _, definition := range definitions {
+		for _, field := range definition.Schema.Fields {
+			if field.RelationType == 0 || field.RelationType.IsSet(client.Relation_Type_INTERNAL_ID) {
+				continue
+			}
+			if _, isNew := newSchemas[field.Schema]; isNew {
+				continue
+			}
+			existingSchema, exists := existingSchemas[field.Schema]
+			if !exists {
+				continue
+			}
+
+			rel, err := relationManager.GetRelation(field.RelationName)
+			if err != nil {
+				return nil, err
+			}
+			if rel.finalized {
+				// The inverse field has been declared by a type extension.
+				continue
+			}
+
+			inverseName := strings.ToLower(definition.Schema.Name[:1]) + definition.Schema.Name[1:]
+			inverse := client.FieldDescription{
+				Name:         inverseName,
+				Kind:         client.FieldKind_FOREIGN_OBJECT,
+				Schema:       definition.Schema.Name,
+				RelationName: field.RelationName,
+				RelationType: client.Relation_Type_ONE,
+			}
+			if field.Kind == client.FieldKind_FOREIGN_OBJECT {
+				inverse.Name = pluralize(inverseName)
+				inverse.Kind = client.FieldKind_FOREIGN_OBJECT_ARRAY
+				inverse.RelationType = client.Relation_Type_MANY
+			}
+			inverse.Typ = defaultCRDTForFieldKind[inverse.Kind]
+
+			if _, exists := existingSchema.GetField(inverse.Name); exists {
+				return nil, NewErrInverseRelationFieldExists(existingSchema.Name, inverse.Name)
+			}
+
+			extensionIndex := -1
+			for i, extension := range extensions {
+				if extension.Name == existingSchema.Name {
+					extensionIndex = i
+					break
+				}
+			}
+			if extensionIndex == -1 {
+				extensions = append(extensions, client.SchemaDescription{Name: existingSchema.Name})
+				extensionIndex = len(extensions) - 1
+			}
+			if _, exists := extensions[extensionIndex].GetField(inverse.Name); exists {
+				return nil, NewErrInverseRelationFieldExists(existingSchema.Name, inverse.Name)
+			}
+
+			_, err = relationManager.RegisterSingle(
+				inverse.RelationName,
+				inverse.Schema,
+				inverse.Name,
+				inverse.RelationType,
+			)
+			if err != nil {
+				return nil, err
+			}
+			extensions[extensionIndex].Fields = append(extensions[extensionIndex].Fields, inverse)
+		}
+	}
+
+	return extensions, nil
+}
+
+// pluralize returns the plural of the given name following the regular rules of English: `categories`
+// for `category`, `boxes` for `box`, and `comments` for `comment`.
+//
+// Irregular plurals are not known, `person` becomes `persons`.
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
 // IsValidIndexName returns true if the name is a valid index name.
 // Valid index names must start with a letter or underscore, and can
 // contain letters, numbers, and underscores.
@@ -186,6 +371,12 @@ func fieldIndexFromAST(field *ast.FieldDefinition, directive *ast.Directive) (cl
 			if !IsValidIndexName(desc.Name) {
 				return client.IndexDescription{}, NewErrIndexWithInvalidName(desc.Name)
 			}
+		case types.IndexDirectivePropUnique:
+			boolVal, ok := arg.Value.(*ast.BooleanValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			desc.Unique = boolVal.Value
 		default:
 			return client.IndexDescription{}, ErrIndexWithUnknownArg
 		}
@@ -227,6 +418,12 @@ func indexFromAST(directive *ast.Directive) (client.IndexDescription, error) {
 			if !ok {
 				return client.IndexDescription{}, ErrIndexWithInvalidArg
 			}
+		case types.IndexDirectivePropUnique:
+			boolVal, ok := arg.Value.(*ast.BooleanValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			desc.Unique = boolVal.Value
 		default:
 			return client.IndexDescription{}, ErrIndexWithUnknownArg
 		}
@@ -310,10 +507,15 @@ func fieldsFromAST(field *ast.FieldDefinition,
 		}
 	}
 
+	crdtType, err := crdtTypeFromAST(field, kind)
+	if err != nil {
+		return nil, err
+	}
+
 	fieldDescription := client.FieldDescription{
 		Name:         field.Name.Value,
 		Kind:         kind,
-		Typ:          defaultCRDTForFieldKind[kind],
+		Typ:          crdtType,
 		Schema:       schema,
 		RelationName: relationName,
 		RelationType: relationType,
@@ -331,6 +533,8 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 		typeFloat    string = "Float"
 		typeDateTime string = "DateTime"
 		typeString   string = "String"
+		typeJSON     string = "JSON"
+		typeBlob     string = "Blob"
 	)
 
 	switch astTypeVal := t.(type) {
@@ -379,6 +583,10 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 			return client.FieldKind_DATETIME, nil
 		case typeString:
 			return client.FieldKind_STRING, nil
+		case typeJSON:
+			return client.FieldKind_JSON, nil
+		case typeBlob:
+			return client.FieldKind_BLOB, nil
 		default:
 			return client.FieldKind_FOREIGN_OBJECT, nil
 		}
@@ -391,6 +599,43 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 	}
 }
 
+// crdtTypeFromAST returns the CRDT type selected by the @crdt directive of the given field,
+// or the default CRDT type of its kind if it has none.
+func crdtTypeFromAST(field *ast.FieldDefinition, kind client.FieldKind) (client.CType, error) {
+	directive, exists := findDirective(field, types.CRDTDirectiveLabel)
+	if !exists {
+		return defaultCRDTForFieldKind[kind], nil
+	}
+
+	crdtType := defaultCRDTForFieldKind[kind]
+	for _, arg := range directive.Arguments {
+		if arg.Name.Value != types.CRDTDirectivePropType {
+			return 0, NewErrCRDTWithUnknownArg(arg.Name.Value)
+		}
+		typeName, isString := arg.Value.GetValue().(string)
+		if !isString {
+			return 0, client.NewErrUnexpectedType[string]("CRDT type", arg.Value.GetValue())
+		}
+		switch typeName {
+		case types.CRDTDirectiveTypeLWW:
+			crdtType = client.LWW_REGISTER
+		case types.CRDTDirectiveTypePNCounter:
+			crdtType = client.PN_COUNTER
+		case types.CRDTDirectiveTypeORSet:
+			crdtType = client.OR_SET
+		case types.CRDTDirectiveTypeRGA:
+			crdtType = client.RGA
+		default:
+			return 0, NewErrCRDTTypeNotFound(typeName)
+		}
+	}
+
+	if !kind.SupportsCRDT(crdtType) {
+		return 0, client.NewErrCRDTKindMismatch(field.Name.Value, crdtType, kind)
+	}
+	return crdtType, nil
+}
+
 func findDirective(field *ast.FieldDefinition, directiveName string) (*ast.Directive, bool) {
 	for _, directive := range field.Directives {
 		if directive.Name.Value == directiveName {
@@ -426,9 +671,9 @@ func getRelationshipName(
 	return genRelationName(hostName, targetName)
 }
 
-func finalizeRelations(relationManager *RelationManager, definitions []client.CollectionDefinition) error {
-	for _, definition := range definitions {
-		for i, field := range definition.Schema.Fields {
+func finalizeRelations(relationManager *RelationManager, schemas []client.SchemaDescription) error {
+	for _, schema := range schemas {
+		for i, field := range schema.Fields {
 			if field.RelationType == 0 || field.RelationType&client.Relation_Type_INTERNAL_ID != 0 {
 				continue
 			}
@@ -449,7 +694,7 @@ func finalizeRelations(relationManager *RelationManager, definitions []client.Co
 			}
 
 			field.RelationType = rel.Kind() | fieldRelationType
-			definition.Schema.Fields[i] = field
+			schema.Fields[i] = field
 		}
 	}
 
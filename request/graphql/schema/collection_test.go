@@ -0,0 +1,29 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralize(t *testing.T) {
+	assert.Equal(t, "comments", pluralize("comment"))
+	assert.Equal(t, "categories", pluralize("category"))
+	assert.Equal(t, "keys", pluralize("key"))
+	assert.Equal(t, "addresses", pluralize("address"))
+	assert.Equal(t, "boxes", pluralize("box"))
+	assert.Equal(t, "waltzes", pluralize("waltz"))
+	assert.Equal(t, "branches", pluralize("branch"))
+	assert.Equal(t, "wishes", pluralize("wish"))
+	assert.Equal(t, "bookEntries", pluralize("bookEntry"))
+}
@@ -14,6 +14,7 @@ import (
 	gql "github.com/sourcenetwork/graphql-go"
 
 	"github.com/sourcenetwork/defradb/client"
+	schemaTypes "github.com/sourcenetwork/defradb/request/graphql/schema/types"
 )
 
 var (
@@ -32,8 +33,6 @@ var (
 		&gql.Object{}: client.FieldKind_FOREIGN_OBJECT,
 		&gql.List{}:   client.FieldKind_FOREIGN_OBJECT_ARRAY,
 		// More custom ones to come
-		// - JSON
-		// - ByteArray
 		// - Counters
 	}
 
@@ -52,6 +51,8 @@ var (
 		client.FieldKind_STRING:                gql.String,
 		client.FieldKind_STRING_ARRAY:          gql.NewList(gql.NewNonNull(gql.String)),
 		client.FieldKind_NILLABLE_STRING_ARRAY: gql.NewList(gql.String),
+		client.FieldKind_JSON:                  schemaTypes.JSONScalarType,
+		client.FieldKind_BLOB:                  schemaTypes.BlobScalarType,
 	}
 
 	// This map is fine to use
@@ -70,6 +71,8 @@ var (
 		client.FieldKind_STRING:                client.LWW_REGISTER,
 		client.FieldKind_STRING_ARRAY:          client.LWW_REGISTER,
 		client.FieldKind_NILLABLE_STRING_ARRAY: client.LWW_REGISTER,
+		client.FieldKind_JSON:                  client.LWW_REGISTER,
+		client.FieldKind_BLOB:                  client.LWW_REGISTER,
 		client.FieldKind_FOREIGN_OBJECT:        client.NONE_CRDT,
 		client.FieldKind_FOREIGN_OBJECT_ARRAY:  client.NONE_CRDT,
 	}
@@ -117,10 +120,17 @@ An optional value that specifies as to whether deleted documents may be
  returned. This argument will propagate down through any child selects/joins.
 `
 	createDocumentDescription string = `
-Creates a single document of this type using the data provided.
+Creates a single document of this type using the data provided, or many documents
+ within a single transaction if an input list is provided.
 `
 	createDataArgDescription string = `
-The json representation of the document you wish to create. Required.
+The json representation of the document you wish to create. Required unless an
+ input list is provided.
+`
+	createInputArgDescription string = `
+A list of json representations of the documents you wish to create. All documents
+ are created within a single transaction, and are returned in the order they are
+ given in. Required unless data is provided.
 `
 	updateDocumentsDescription string = `
 Updates documents in this collection using the data provided. Only documents
@@ -165,6 +175,23 @@ An optional set of dockey values that will limit the delete to documents with
 An optional filter for this delete that will limit the delete to documents
  matching the given criteria. If no matching documents are found, the operation
  will succeed, but no documents will be deleted.
+`
+	upsertDocumentsDescription string = `
+Updates the documents in this collection matching the given filter, or creates
+ a new document if no documents match. The update and the create happen within
+ a single transaction. Unless the request is made within an explicit transaction,
+ it is retried if a concurrent upsert creates the same document first.
+`
+	upsertFilterArgDescription string = `
+The filter used to find the documents to update. Required.
+`
+	upsertCreateArgDescription string = `
+The json representation of the document to create if no documents match the
+ filter. Required.
+`
+	upsertUpdateArgDescription string = `
+The json representation of the fields to update and their new values if any
+ documents match the filter. Required.
 `
 	keyFieldDescription string = `
 The immutable primary key (dockey) value for this document.
@@ -26,6 +26,10 @@ const (
 	errIndexUnknownArgument       string = "index with unknown argument"
 	errIndexInvalidArgument       string = "index with invalid argument"
 	errIndexInvalidName           string = "index with invalid name"
+	errCRDTUnknownArgument        string = "crdt directive with unknown argument"
+	errCRDTTypeNotFound           string = "no CRDT type found for given name"
+	errIndexWithinTypeExtension   string = "indexes may not be declared within a type extension"
+	errInverseRelationFieldExists string = "the inverse relation field already exists, declare it by extending the type"
 )
 
 var (
@@ -58,6 +62,26 @@ func NewErrDuplicateField(objectName, fieldName string) error {
 	)
 }
 
+func NewErrCRDTWithUnknownArg(name string) error {
+	return errors.New(errCRDTUnknownArgument, errors.NewKV("Name", name))
+}
+
+func NewErrCRDTTypeNotFound(name string) error {
+	return errors.New(errCRDTTypeNotFound, errors.NewKV("Type", name))
+}
+
+func NewErrIndexWithinTypeExtension(typeName string) error {
+	return errors.New(errIndexWithinTypeExtension, errors.NewKV("Type", typeName))
+}
+
+func NewErrInverseRelationFieldExists(typeName string, fieldName string) error {
+	return errors.New(
+		errInverseRelationFieldExists,
+		errors.NewKV("Type", typeName),
+		errors.NewKV("Field", fieldName),
+	)
+}
+
 func NewErrIndexWithInvalidName(name string) error {
 	return errors.New(errIndexInvalidName, errors.NewKV("Name", name))
 }
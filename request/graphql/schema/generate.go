@@ -256,6 +256,10 @@ func (g *Generator) createExpandedFieldAggregate(
 ) error {
 	for _, aggregateTarget := range f.Args {
 		target := aggregateTarget.Name()
+		if target == request.Distinct {
+			// distinct is an option of the aggregate, not a target
+			continue
+		}
 		var filterTypeName string
 		if target == request.GroupFieldName {
 			filterTypeName = obj.Name() + "FilterArg"
@@ -463,6 +467,7 @@ func (g *Generator) buildTypes(
 func (g *Generator) genAggregateFields(ctx context.Context) error {
 	topLevelCountInputs := map[string]*gql.InputObject{}
 	topLevelNumericAggInputs := map[string]*gql.InputObject{}
+	topLevelComparableAggInputs := map[string]*gql.InputObject{}
 
 	for _, t := range g.typeDefs {
 		numArg := g.genNumericAggregateBaseArgInputs(t)
@@ -481,6 +486,21 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 			}
 		}
 
+		comparableArg := g.genComparableAggregateBaseArgInputs(t)
+		topLevelComparableAggInputs[t.Name()] = comparableArg
+		err = g.appendIfNotExists(comparableArg)
+		if err != nil {
+			return err
+		}
+
+		comparableInlineArrayInputs := g.genComparableInlineArraySelectorObject(t)
+		for _, obj := range comparableInlineArrayInputs {
+			err = g.appendIfNotExists(obj)
+			if err != nil {
+				return err
+			}
+		}
+
 		obj := g.genCountBaseArgInputs(t)
 		topLevelCountInputs[t.Name()] = obj
 		err = g.appendIfNotExists(obj)
@@ -504,17 +524,35 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 		}
 		t.AddFieldConfig(countField.Name, &countField)
 
-		sumField, err := g.genSumFieldConfig(t)
-		if err != nil {
-			return err
+		numericAggregates := []struct {
+			name        string
+			description string
+		}{
+			{request.SumFieldName, schemaTypes.SumFieldDescription},
+			{request.AverageFieldName, schemaTypes.AverageFieldDescription},
+		}
+		for _, aggregate := range numericAggregates {
+			field, err := g.genNumericAggregateFieldConfig(t, aggregate.name, aggregate.description)
+			if err != nil {
+				return err
+			}
+			t.AddFieldConfig(field.Name, &field)
 		}
-		t.AddFieldConfig(sumField.Name, &sumField)
 
-		averageField, err := g.genAverageFieldConfig(t)
-		if err != nil {
-			return err
+		comparableAggregates := []struct {
+			name        string
+			description string
+		}{
+			{request.MinFieldName, schemaTypes.MinFieldDescription},
+			{request.MaxFieldName, schemaTypes.MaxFieldDescription},
+		}
+		for _, aggregate := range comparableAggregates {
+			field, err := g.genComparableAggregateFieldConfig(t, aggregate.name, aggregate.description)
+			if err != nil {
+				return err
+			}
+			t.AddFieldConfig(field.Name, &field)
 		}
-		t.AddFieldConfig(averageField.Name, &averageField)
 	}
 
 	queryType := g.manager.schema.QueryType()
@@ -526,6 +564,10 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 		queryType.AddFieldConfig(topLevelAgg.Name, topLevelAgg)
 	}
 
+	for _, topLevelAgg := range genTopLevelComparableAggregates(topLevelComparableAggInputs) {
+		queryType.AddFieldConfig(topLevelAgg.Name, topLevelAgg)
+	}
+
 	return nil
 }
 
@@ -534,7 +576,9 @@ func genTopLevelCount(topLevelCountInputs map[string]*gql.InputObject) *gql.Fiel
 		Name:        request.CountFieldName,
 		Description: schemaTypes.CountFieldDescription,
 		Type:        gql.Int,
-		Args:        gql.FieldConfigArgument{},
+		Args: gql.FieldConfigArgument{
+			request.Distinct: schemaTypes.NewArgConfig(gql.Boolean, schemaTypes.CountDistinctArgDescription),
+		},
 	}
 
 	for name, inputObject := range topLevelCountInputs {
@@ -567,6 +611,29 @@ func genTopLevelNumericAggregates(topLevelNumericAggInputs map[string]*gql.Input
 	return []*gql.Field{&topLevelSumField, &topLevelAverageField}
 }
 
+func genTopLevelComparableAggregates(topLevelComparableAggInputs map[string]*gql.InputObject) []*gql.Field {
+	topLevelMinField := gql.Field{
+		Name:        request.MinFieldName,
+		Description: schemaTypes.MinFieldDescription,
+		Type:        schemaTypes.ComparableScalarType,
+		Args:        gql.FieldConfigArgument{},
+	}
+
+	topLevelMaxField := gql.Field{
+		Name:        request.MaxFieldName,
+		Description: schemaTypes.MaxFieldDescription,
+		Type:        schemaTypes.ComparableScalarType,
+		Args:        gql.FieldConfigArgument{},
+	}
+
+	for name, inputObject := range topLevelComparableAggInputs {
+		topLevelMinField.Args[name] = schemaTypes.NewArgConfig(inputObject, inputObject.Description())
+		topLevelMaxField.Args[name] = schemaTypes.NewArgConfig(inputObject, inputObject.Description())
+	}
+
+	return []*gql.Field{&topLevelMinField, &topLevelMaxField}
+}
+
 func (g *Generator) genCountFieldConfig(obj *gql.Object) (gql.Field, error) {
 	childTypesByFieldName := map[string]gql.Type{}
 
@@ -594,7 +661,9 @@ func (g *Generator) genCountFieldConfig(obj *gql.Object) (gql.Field, error) {
 		Name:        request.CountFieldName,
 		Description: schemaTypes.CountFieldDescription,
 		Type:        gql.Int,
-		Args:        gql.FieldConfigArgument{},
+		Args: gql.FieldConfigArgument{
+			request.Distinct: schemaTypes.NewArgConfig(gql.Boolean, schemaTypes.CountDistinctArgDescription),
+		},
 	}
 
 	for name, inputObject := range childTypesByFieldName {
@@ -604,11 +673,17 @@ func (g *Generator) genCountFieldConfig(obj *gql.Object) (gql.Field, error) {
 	return field, nil
 }
 
-func (g *Generator) genSumFieldConfig(obj *gql.Object) (gql.Field, error) {
+// genNumericAggregateFieldConfig returns the config of the numeric aggregate field with the given
+// name (e.g. sum or average) for the given object.
+func (g *Generator) genNumericAggregateFieldConfig(
+	obj *gql.Object,
+	name string,
+	description string,
+) (gql.Field, error) {
 	childTypesByFieldName := map[string]gql.Type{}
 
 	for _, field := range obj.Fields() {
-		// we can only sum list items
+		// we can only aggregate list items
 		listType, isList := field.Type.(*gql.List)
 		if !isList {
 			continue
@@ -621,18 +696,18 @@ func (g *Generator) genSumFieldConfig(obj *gql.Object) (gql.Field, error) {
 			inputObjectName = genNumericObjectSelectorName(listType.OfType.Name())
 		}
 
-		subSumType, isSubTypeSumable := g.manager.schema.TypeMap()[inputObjectName]
-		// If the item is not in the type map, it must contain no summable
+		subAggregateType, isSubTypeAggregatable := g.manager.schema.TypeMap()[inputObjectName]
+		// If the item is not in the type map, it must contain no aggregatable
 		//  fields (e.g. no Int/Floats)
-		if !isSubTypeSumable {
+		if !isSubTypeAggregatable {
 			continue
 		}
-		childTypesByFieldName[field.Name] = subSumType
+		childTypesByFieldName[field.Name] = subAggregateType
 	}
 
 	field := gql.Field{
-		Name:        request.SumFieldName,
-		Description: schemaTypes.SumFieldDescription,
+		Name:        name,
+		Description: description,
 		Type:        gql.Float,
 		Args:        gql.FieldConfigArgument{},
 	}
@@ -644,36 +719,77 @@ func (g *Generator) genSumFieldConfig(obj *gql.Object) (gql.Field, error) {
 	return field, nil
 }
 
-func (g *Generator) genAverageFieldConfig(obj *gql.Object) (gql.Field, error) {
+func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
+	objects := []*gql.InputObject{}
+	for _, field := range obj.Fields() {
+		// we can only act on list items
+		listType, isList := field.Type.(*gql.List)
+		if !isList {
+			continue
+		}
+
+		if isNumericArray(listType) {
+			// If it is an inline scalar array then we require an empty
+			//  object as an argument due to the lack of union input types
+			selectorObject := gql.NewInputObject(gql.InputObjectConfig{
+				Name: genNumericInlineArraySelectorName(obj.Name(), field.Name),
+				Fields: gql.InputObjectConfigFieldMap{
+					request.LimitClause: &gql.InputObjectFieldConfig{
+						Type:        gql.Int,
+						Description: schemaTypes.LimitArgDescription,
+					},
+					request.OffsetClause: &gql.InputObjectFieldConfig{
+						Type:        gql.Int,
+						Description: schemaTypes.OffsetArgDescription,
+					},
+					request.OrderClause: &gql.InputObjectFieldConfig{
+						Type:        g.manager.schema.TypeMap()["Ordering"],
+						Description: schemaTypes.OrderArgDescription,
+					},
+				},
+			})
+
+			objects = append(objects, selectorObject)
+		}
+	}
+	return objects
+}
+
+// genComparableAggregateFieldConfig returns the config of the comparable aggregate field with the
+// given name (e.g. min or max) for the given object.
+func (g *Generator) genComparableAggregateFieldConfig(
+	obj *gql.Object,
+	name string,
+	description string,
+) (gql.Field, error) {
 	childTypesByFieldName := map[string]gql.Type{}
 
 	for _, field := range obj.Fields() {
-		// we can only sum list items
+		// we can only aggregate list items
 		listType, isList := field.Type.(*gql.List)
 		if !isList {
 			continue
 		}
 
 		var inputObjectName string
-		if isNumericArray(listType) {
-			inputObjectName = genNumericInlineArraySelectorName(obj.Name(), field.Name)
+		if isComparableArray(listType) {
+			inputObjectName = genComparableInlineArraySelectorName(obj.Name(), field.Name)
 		} else {
-			inputObjectName = genNumericObjectSelectorName(listType.OfType.Name())
+			inputObjectName = genComparableObjectSelectorName(listType.OfType.Name())
 		}
 
-		subAverageType, isSubTypeAveragable := g.manager.schema.TypeMap()[inputObjectName]
-		// If the item is not in the type map, it must contain no averagable
-		//  fields (e.g. no Int/Floats)
-		if !isSubTypeAveragable {
+		subAggregateType, isSubTypeAggregatable := g.manager.schema.TypeMap()[inputObjectName]
+		// If the item is not in the type map, it must contain no comparable fields
+		if !isSubTypeAggregatable {
 			continue
 		}
-		childTypesByFieldName[field.Name] = subAverageType
+		childTypesByFieldName[field.Name] = subAggregateType
 	}
 
 	field := gql.Field{
-		Name:        request.AverageFieldName,
-		Description: schemaTypes.AverageFieldDescription,
-		Type:        gql.Float,
+		Name:        name,
+		Description: description,
+		Type:        schemaTypes.ComparableScalarType,
 		Args:        gql.FieldConfigArgument{},
 	}
 
@@ -684,7 +800,7 @@ func (g *Generator) genAverageFieldConfig(obj *gql.Object) (gql.Field, error) {
 	return field, nil
 }
 
-func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
+func (g *Generator) genComparableInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
 	objects := []*gql.InputObject{}
 	for _, field := range obj.Fields() {
 		// we can only act on list items
@@ -693,11 +809,11 @@ func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.
 			continue
 		}
 
-		if isNumericArray(listType) {
+		if isComparableArray(listType) {
 			// If it is an inline scalar array then we require an empty
 			//  object as an argument due to the lack of union input types
 			selectorObject := gql.NewInputObject(gql.InputObjectConfig{
-				Name: genNumericInlineArraySelectorName(obj.Name(), field.Name),
+				Name: genComparableInlineArraySelectorName(obj.Name(), field.Name),
 				Fields: gql.InputObjectConfigFieldMap{
 					request.LimitClause: &gql.InputObjectFieldConfig{
 						Type:        gql.Int,
@@ -720,6 +836,14 @@ func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.
 	return objects
 }
 
+func genComparableObjectSelectorName(hostName string) string {
+	return fmt.Sprintf("%s__%s", hostName, "ComparableSelector")
+}
+
+func genComparableInlineArraySelectorName(hostName string, fieldName string) string {
+	return fmt.Sprintf("%s__%s__%s", hostName, fieldName, "ComparableSelector")
+}
+
 func genNumericObjectSelectorName(hostName string) string {
 	return fmt.Sprintf("%s__%s", hostName, "NumericSelector")
 }
@@ -729,9 +853,8 @@ func genNumericInlineArraySelectorName(hostName string, fieldName string) string
 }
 
 func (g *Generator) genCountBaseArgInputs(obj *gql.Object) *gql.InputObject {
-	countableObject := gql.NewInputObject(gql.InputObjectConfig{
-		Name: genObjectCountName(obj.Name()),
-		Fields: gql.InputObjectConfigFieldMap{
+	var fieldThunk gql.InputObjectConfigFieldMapThunk = func() (gql.InputObjectConfigFieldMap, error) {
+		fields := gql.InputObjectConfigFieldMap{
 			request.LimitClause: &gql.InputObjectFieldConfig{
 				Type:        gql.Int,
 				Description: schemaTypes.LimitArgDescription,
@@ -740,10 +863,50 @@ func (g *Generator) genCountBaseArgInputs(obj *gql.Object) *gql.InputObject {
 				Type:        gql.Int,
 				Description: schemaTypes.OffsetArgDescription,
 			},
-		},
-	})
+		}
 
-	return countableObject
+		fieldsEnum, enumExists := g.manager.schema.TypeMap()[genTypeName(obj, "ScalarFieldsArg")]
+		if !enumExists {
+			fieldsEnumCfg := gql.EnumConfig{
+				Name:   genTypeName(obj, "ScalarFieldsArg"),
+				Values: gql.EnumValueConfigMap{},
+			}
+
+			for _, field := range obj.Fields() {
+				if _, isAggregate := request.Aggregates[field.Name]; isAggregate {
+					continue
+				}
+				if _, isList := field.Type.(*gql.List); isList || !gql.IsLeafType(field.Type) {
+					continue
+				}
+				fieldsEnumCfg.Values[field.Name] = &gql.EnumValueConfig{Value: field.Name}
+			}
+
+			if len(fieldsEnumCfg.Values) == 0 {
+				return fields, nil
+			}
+
+			fieldsEnum = gql.NewEnum(fieldsEnumCfg)
+
+			err := g.manager.schema.AppendType(fieldsEnum)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// The values of a field may be counted instead of the objects themselves, for
+		// example to count the distinct values of the field.
+		fields[request.FieldName] = &gql.InputObjectFieldConfig{
+			Type:        fieldsEnum,
+			Description: schemaTypes.CountFieldArgDescription,
+		}
+		return fields, nil
+	}
+
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:   genObjectCountName(obj.Name()),
+		Fields: fieldThunk,
+	})
 }
 
 func (g *Generator) genCountInlineArrayInputs(obj *gql.Object) []*gql.InputObject {
@@ -817,6 +980,8 @@ func (g *Generator) genNumericAggregateBaseArgInputs(obj *gql.Object) *gql.Input
 			// A child aggregate will always be aggregatable, as it can be present via an inner grouping
 			fieldsEnumCfg.Values[request.SumFieldName] = &gql.EnumValueConfig{Value: request.SumFieldName}
 			fieldsEnumCfg.Values[request.AverageFieldName] = &gql.EnumValueConfig{Value: request.AverageFieldName}
+			fieldsEnumCfg.Values[request.MinFieldName] = &gql.EnumValueConfig{Value: request.MinFieldName}
+			fieldsEnumCfg.Values[request.MaxFieldName] = &gql.EnumValueConfig{Value: request.MaxFieldName}
 
 			if !hasSumableFields {
 				return nil, nil
@@ -855,6 +1020,77 @@ func (g *Generator) genNumericAggregateBaseArgInputs(obj *gql.Object) *gql.Input
 	})
 }
 
+func (g *Generator) genComparableAggregateBaseArgInputs(obj *gql.Object) *gql.InputObject {
+	var fieldThunk gql.InputObjectConfigFieldMapThunk = func() (gql.InputObjectConfigFieldMap, error) {
+		fieldsEnum, enumExists := g.manager.schema.TypeMap()[genTypeName(obj, "ComparableFieldsArg")]
+		if !enumExists {
+			fieldsEnumCfg := gql.EnumConfig{
+				Name:   genTypeName(obj, "ComparableFieldsArg"),
+				Values: gql.EnumValueConfigMap{},
+			}
+
+			hasComparableFields := false
+			for _, field := range obj.Fields() {
+				if field.Type == gql.Float || field.Type == gql.Int ||
+					field.Type == gql.String || field.Type == gql.DateTime {
+					hasComparableFields = true
+					fieldsEnumCfg.Values[field.Name] = &gql.EnumValueConfig{Value: field.Name}
+					continue
+				}
+
+				if list, isList := field.Type.(*gql.List); isList {
+					hasComparableFields = true
+					if isComparableArray(list) {
+						fieldsEnumCfg.Values[field.Name] = &gql.EnumValueConfig{Value: field.Name}
+					} else {
+						// If it is a related list, we need to add count in here so that we can compare it
+						fieldsEnumCfg.Values[request.CountFieldName] = &gql.EnumValueConfig{Value: request.CountFieldName}
+					}
+				}
+			}
+			// A child aggregate will always be comparable, as it can be present via an inner grouping
+			fieldsEnumCfg.Values[request.SumFieldName] = &gql.EnumValueConfig{Value: request.SumFieldName}
+			fieldsEnumCfg.Values[request.AverageFieldName] = &gql.EnumValueConfig{Value: request.AverageFieldName}
+			fieldsEnumCfg.Values[request.MinFieldName] = &gql.EnumValueConfig{Value: request.MinFieldName}
+			fieldsEnumCfg.Values[request.MaxFieldName] = &gql.EnumValueConfig{Value: request.MaxFieldName}
+
+			if !hasComparableFields {
+				return nil, nil
+			}
+
+			fieldsEnum = gql.NewEnum(fieldsEnumCfg)
+
+			err := g.manager.schema.AppendType(fieldsEnum)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return gql.InputObjectConfigFieldMap{
+			"field": &gql.InputObjectFieldConfig{
+				Type: gql.NewNonNull(fieldsEnum),
+			},
+			request.LimitClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: schemaTypes.LimitArgDescription,
+			},
+			request.OffsetClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: schemaTypes.OffsetArgDescription,
+			},
+			request.OrderClause: &gql.InputObjectFieldConfig{
+				Type:        g.manager.schema.TypeMap()[genTypeName(obj, "OrderArg")],
+				Description: schemaTypes.OrderArgDescription,
+			},
+		}, nil
+	}
+
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:   genComparableObjectSelectorName(obj.Name()),
+		Fields: fieldThunk,
+	})
+}
+
 func appendCommitChildGroupField() {
 	schemaTypes.CommitObject.Fields()[request.GroupFieldName] = &gql.FieldDefinition{
 		Name:        request.GroupFieldName,
@@ -918,16 +1154,21 @@ func (g *Generator) genTypeMutationFields(
 	if err != nil {
 		return nil, err
 	}
-	return []*gql.Field{create, update, delete}, nil
+	upsert, err := g.genTypeMutationUpsertField(obj, filterInput)
+	if err != nil {
+		return nil, err
+	}
+	return []*gql.Field{create, update, delete, upsert}, nil
 }
 
 func (g *Generator) genTypeMutationCreateField(obj *gql.Object) (*gql.Field, error) {
 	field := &gql.Field{
 		Name:        "create_" + obj.Name(),
 		Description: createDocumentDescription,
-		Type:        obj,
+		Type:        gql.NewList(obj),
 		Args: gql.FieldConfigArgument{
-			"data": schemaTypes.NewArgConfig(gql.String, createDataArgDescription),
+			"data":  schemaTypes.NewArgConfig(gql.String, createDataArgDescription),
+			"input": schemaTypes.NewArgConfig(gql.NewList(gql.NewNonNull(gql.String)), createInputArgDescription),
 		},
 	}
 	return field, nil
@@ -968,6 +1209,23 @@ func (g *Generator) genTypeMutationDeleteField(
 	return field, nil
 }
 
+func (g *Generator) genTypeMutationUpsertField(
+	obj *gql.Object,
+	filter *gql.InputObject,
+) (*gql.Field, error) {
+	field := &gql.Field{
+		Name:        "upsert_" + obj.Name(),
+		Description: upsertDocumentsDescription,
+		Type:        gql.NewList(obj),
+		Args: gql.FieldConfigArgument{
+			"filter": schemaTypes.NewArgConfig(filter, upsertFilterArgDescription),
+			"create": schemaTypes.NewArgConfig(gql.String, upsertCreateArgDescription),
+			"update": schemaTypes.NewArgConfig(gql.String, upsertUpdateArgDescription),
+		},
+	}
+	return field, nil
+}
+
 func (g *Generator) genTypeFieldsEnum(obj *gql.Object) *gql.Enum {
 	enumFieldsCfg := gql.EnumConfig{
 		Name:   genTypeName(obj, "Fields"),
@@ -1008,16 +1266,31 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 			// generate basic filter operator blocks
 			// @todo: Extract object field loop into its own utility func
 			for f, field := range obj.Fields() {
-				if _, ok := request.ReservedFields[f]; ok && f != request.KeyFieldName {
+				// Aggregates may be filtered by their values, once they have been computed
+				_, isAggregate := request.Aggregates[f]
+				if _, ok := request.ReservedFields[f]; ok && f != request.KeyFieldName && !isAggregate {
+					continue
+				}
+				// JSON values are filtered by the paths to their properties,
+				// which can not be described by an input object
+				if field.Type == schemaTypes.JSONScalarType {
+					fields[field.Name] = &gql.InputObjectFieldConfig{
+						Type: schemaTypes.JSONScalarType,
+					}
 					continue
 				}
 				// scalars (leafs)
 				if gql.IsLeafType(field.Type) {
-					if _, isList := field.Type.(*gql.List); isList {
-						// Filtering by inline array value is currently not supported
-						continue
+					operatorBlockName := field.Type.Name() + "OperatorBlock"
+					if list, isList := field.Type.(*gql.List); isList {
+						// Inline arrays are filtered by the values of their items
+						if notNull, isNotNull := list.OfType.(*gql.NonNull); isNotNull {
+							operatorBlockName = fmt.Sprintf("NotNull%sListOperatorBlock", notNull.OfType.Name())
+						} else {
+							operatorBlockName = list.OfType.Name() + "ListOperatorBlock"
+						}
 					}
-					operatorType, isFilterable := g.manager.schema.TypeMap()[field.Type.Name()+"OperatorBlock"]
+					operatorType, isFilterable := g.manager.schema.TypeMap()[operatorBlockName]
 					if !isFilterable {
 						continue
 					}
@@ -1117,6 +1390,10 @@ func (g *Generator) genTypeOrderArgInput(obj *gql.Object) *gql.InputObject {
 				}
 				typeMap := g.manager.schema.TypeMap()
 				configType, isOrderable := typeMap[genTypeName(field.Type, "OrderArg")]
+				if field.Type == schemaTypes.JSONScalarType {
+					// JSON values have no order
+					continue
+				}
 				if gql.IsLeafType(field.Type) { // only Scalars, and enums
 					fields[field.Name] = &gql.InputObjectFieldConfig{
 						Type: typeMap["Ordering"],
@@ -1208,6 +1485,14 @@ func isNumericArray(list *gql.List) bool {
 		list.OfType == gql.Float
 }
 
+// isComparableArray returns true if the items of the given inline array can be compared
+// by the min and max aggregates.
+func isComparableArray(list *gql.List) bool {
+	return isNumericArray(list) ||
+		list.OfType.Name() == gql.NewNonNull(gql.String).Name() ||
+		list.OfType == gql.String
+}
+
 /* Example
 
 typeDefs := ` ... `
@@ -89,6 +89,30 @@ func TestStructIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "Unique index",
+			sdl:         `type user @index(fields: ["name"], unique: true) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Unique: true,
+				},
+			},
+		},
+		{
+			description: "Index explicitly not unique",
+			sdl:         `type user @index(fields: ["name"], unique: false) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Unique: false,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -168,6 +192,11 @@ func TestInvalidStructIndex(t *testing.T) {
 			sdl:         `type user @index(fields: ["name"], directions: [ASC, DESC]) {}`,
 			expectedErr: errIndexInvalidArgument,
 		},
+		{
+			description: "invalid 'unique' value type",
+			sdl:         `type user @index(fields: ["name"], unique: "true") {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
 	}
 
 	for _, test := range cases {
@@ -205,6 +234,20 @@ func TestFieldIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "unique field index",
+			sdl: `type user {
+				name: String @index(unique: true)
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Unique: true,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -273,7 +316,7 @@ func TestInvalidFieldIndex(t *testing.T) {
 func parseIndexAndTest(t *testing.T, testCase indexTestCase) {
 	ctx := context.Background()
 
-	cols, err := FromString(ctx, testCase.sdl)
+	cols, _, err := FromString(ctx, testCase.sdl, nil)
 	assert.NoError(t, err, testCase.description)
 	assert.Equal(t, len(cols), 1, testCase.description)
 	assert.Equal(t, len(cols[0].Description.Indexes), len(testCase.targetDescriptions), testCase.description)
@@ -286,7 +329,7 @@ func parseIndexAndTest(t *testing.T, testCase indexTestCase) {
 func parseInvalidIndexAndTest(t *testing.T, testCase invalidIndexTestCase) {
 	ctx := context.Background()
 
-	_, err := FromString(ctx, testCase.sdl)
+	_, _, err := FromString(ctx, testCase.sdl, nil)
 	assert.ErrorContains(t, err, testCase.expectedErr, testCase.description)
 }
 
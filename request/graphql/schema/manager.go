@@ -92,6 +92,7 @@ func defaultQueryType() *gql.Object {
 			// database API queries
 			schemaTypes.QueryCommits.Name:       schemaTypes.QueryCommits,
 			schemaTypes.QueryLatestCommits.Name: schemaTypes.QueryLatestCommits,
+			schemaTypes.QueryChanges.Name:       schemaTypes.QueryChanges,
 		},
 	})
 }
@@ -114,6 +115,7 @@ func defaultDirectivesType() []*gql.Directive {
 		schemaTypes.ExplainDirective,
 		schemaTypes.IndexDirective,
 		schemaTypes.IndexFieldDirective,
+		schemaTypes.CRDTFieldDirective,
 	}
 }
 
@@ -140,6 +142,9 @@ func defaultTypes() []gql.Type {
 		gql.ID,
 		gql.Int,
 		gql.String,
+		schemaTypes.JSONScalarType,
+		schemaTypes.BlobScalarType,
+		schemaTypes.ComparableScalarType,
 
 		// Base Query types
 
@@ -147,7 +152,9 @@ func defaultTypes() []gql.Type {
 		schemaTypes.OrderingEnum,
 
 		// Filter scalar blocks
+		schemaTypes.BlobOperatorBlock,
 		schemaTypes.BooleanOperatorBlock,
+		schemaTypes.ComparableOperatorBlock,
 		schemaTypes.NotNullBooleanOperatorBlock,
 		schemaTypes.DateTimeOperatorBlock,
 		schemaTypes.FloatOperatorBlock,
@@ -158,10 +165,22 @@ func defaultTypes() []gql.Type {
 		schemaTypes.StringOperatorBlock,
 		schemaTypes.NotNullstringOperatorBlock,
 
+		// Filter inline array blocks
+		schemaTypes.BooleanListOperatorBlock,
+		schemaTypes.NotNullBooleanListOperatorBlock,
+		schemaTypes.FloatListOperatorBlock,
+		schemaTypes.NotNullFloatListOperatorBlock,
+		schemaTypes.IntListOperatorBlock,
+		schemaTypes.NotNullIntListOperatorBlock,
+		schemaTypes.StringListOperatorBlock,
+		schemaTypes.NotNullStringListOperatorBlock,
+
 		schemaTypes.CommitsOrderArg,
 		schemaTypes.CommitLinkObject,
 		schemaTypes.CommitObject,
+		schemaTypes.ChangeObject,
 
 		schemaTypes.ExplainEnum,
+		schemaTypes.CRDTEnum,
 	}
 }
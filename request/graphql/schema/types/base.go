@@ -14,6 +14,30 @@ import (
 	gql "github.com/sourcenetwork/graphql-go"
 )
 
+// BlobOperatorBlock filter block for Blob types.
+var BlobOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
+	Name:        "BlobOperatorBlock",
+	Description: blobOperatorBlockDescription,
+	Fields: gql.InputObjectConfigFieldMap{
+		"_eq": &gql.InputObjectFieldConfig{
+			Description: eqOperatorDescription,
+			Type:        BlobScalarType,
+		},
+		"_ne": &gql.InputObjectFieldConfig{
+			Description: neOperatorDescription,
+			Type:        BlobScalarType,
+		},
+		"_in": &gql.InputObjectFieldConfig{
+			Description: inOperatorDescription,
+			Type:        gql.NewList(BlobScalarType),
+		},
+		"_nin": &gql.InputObjectFieldConfig{
+			Description: ninOperatorDescription,
+			Type:        gql.NewList(BlobScalarType),
+		},
+	},
+})
+
 // BooleanOperatorBlock filter block for boolean types.
 var BooleanOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 	Name:        "BooleanOperatorBlock",
@@ -102,6 +126,46 @@ var DateTimeOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 	},
 })
 
+// ComparableOperatorBlock filter block for Comparable types.
+var ComparableOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
+	Name:        "ComparableOperatorBlock",
+	Description: comparableOperatorBlockDescription,
+	Fields: gql.InputObjectConfigFieldMap{
+		"_eq": &gql.InputObjectFieldConfig{
+			Description: eqOperatorDescription,
+			Type:        ComparableScalarType,
+		},
+		"_ne": &gql.InputObjectFieldConfig{
+			Description: neOperatorDescription,
+			Type:        ComparableScalarType,
+		},
+		"_gt": &gql.InputObjectFieldConfig{
+			Description: gtOperatorDescription,
+			Type:        ComparableScalarType,
+		},
+		"_ge": &gql.InputObjectFieldConfig{
+			Description: geOperatorDescription,
+			Type:        ComparableScalarType,
+		},
+		"_lt": &gql.InputObjectFieldConfig{
+			Description: ltOperatorDescription,
+			Type:        ComparableScalarType,
+		},
+		"_le": &gql.InputObjectFieldConfig{
+			Description: leOperatorDescription,
+			Type:        ComparableScalarType,
+		},
+		"_in": &gql.InputObjectFieldConfig{
+			Description: inOperatorDescription,
+			Type:        gql.NewList(ComparableScalarType),
+		},
+		"_nin": &gql.InputObjectFieldConfig{
+			Description: ninOperatorDescription,
+			Type:        gql.NewList(ComparableScalarType),
+		},
+	},
+})
+
 // FloatOperatorBlock filter block for Float types.
 var FloatOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 	Name:        "FloatOperatorBlock",
@@ -291,6 +355,18 @@ var StringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 			Description: nlikeStringOperatorDescription,
 			Type:        gql.String,
 		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_regex": &gql.InputObjectFieldConfig{
+			Description: regexStringOperatorDescription,
+			Type:        gql.String,
+		},
 	},
 })
 
@@ -323,6 +399,18 @@ var NotNullstringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 			Description: nlikeStringOperatorDescription,
 			Type:        gql.String,
 		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_regex": &gql.InputObjectFieldConfig{
+			Description: regexStringOperatorDescription,
+			Type:        gql.String,
+		},
 	},
 })
 
@@ -349,3 +437,95 @@ var IdOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 		},
 	},
 })
+
+// newListOperatorBlock returns the filter block for inline arrays of the given item type,
+// the items are filtered with the given item operator block.
+func newListOperatorBlock(
+	name string,
+	description string,
+	itemType gql.Input,
+	itemOperatorBlock *gql.InputObject,
+) *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:        name,
+		Description: description,
+		Fields: gql.InputObjectConfigFieldMap{
+			"_contains": &gql.InputObjectFieldConfig{
+				Description: containsOperatorDescription,
+				Type:        itemType,
+			},
+			"_any": &gql.InputObjectFieldConfig{
+				Description: anyOperatorDescription,
+				Type:        itemOperatorBlock,
+			},
+			"_all": &gql.InputObjectFieldConfig{
+				Description: allOperatorDescription,
+				Type:        itemOperatorBlock,
+			},
+		},
+	})
+}
+
+// BooleanListOperatorBlock filter block for [Boolean] types.
+var BooleanListOperatorBlock = newListOperatorBlock(
+	"BooleanListOperatorBlock",
+	booleanListOperatorBlockDescription,
+	gql.Boolean,
+	BooleanOperatorBlock,
+)
+
+// NotNullBooleanListOperatorBlock filter block for [Boolean!] types.
+var NotNullBooleanListOperatorBlock = newListOperatorBlock(
+	"NotNullBooleanListOperatorBlock",
+	notNullBooleanListOperatorBlockDescription,
+	gql.Boolean,
+	NotNullBooleanOperatorBlock,
+)
+
+// FloatListOperatorBlock filter block for [Float] types.
+var FloatListOperatorBlock = newListOperatorBlock(
+	"FloatListOperatorBlock",
+	floatListOperatorBlockDescription,
+	gql.Float,
+	FloatOperatorBlock,
+)
+
+// NotNullFloatListOperatorBlock filter block for [Float!] types.
+var NotNullFloatListOperatorBlock = newListOperatorBlock(
+	"NotNullFloatListOperatorBlock",
+	notNullFloatListOperatorBlockDescription,
+	gql.Float,
+	NotNullFloatOperatorBlock,
+)
+
+// IntListOperatorBlock filter block for [Int] types.
+var IntListOperatorBlock = newListOperatorBlock(
+	"IntListOperatorBlock",
+	intListOperatorBlockDescription,
+	gql.Int,
+	IntOperatorBlock,
+)
+
+// NotNullIntListOperatorBlock filter block for [Int!] types.
+var NotNullIntListOperatorBlock = newListOperatorBlock(
+	"NotNullIntListOperatorBlock",
+	notNullIntListOperatorBlockDescription,
+	gql.Int,
+	NotNullIntOperatorBlock,
+)
+
+// StringListOperatorBlock filter block for [String] types.
+var StringListOperatorBlock = newListOperatorBlock(
+	"StringListOperatorBlock",
+	stringListOperatorBlockDescription,
+	gql.String,
+	StringOperatorBlock,
+)
+
+// NotNullStringListOperatorBlock filter block for [String!] types.
+var NotNullStringListOperatorBlock = newListOperatorBlock(
+	"NotNullStringListOperatorBlock",
+	notNullStringListOperatorBlockDescription,
+	gql.String,
+	NotNullstringOperatorBlock,
+)
@@ -0,0 +1,65 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	gql "github.com/sourcenetwork/graphql-go"
+
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+var (
+	// Change represents a change recorded in the change log of a collection
+	// type Change {
+	// 	Sequence: Int
+	// 	Type: String
+	// 	Dockey: String
+	// 	CID: String
+	// 	Height: Int
+	// }
+	ChangeObject = gql.NewObject(gql.ObjectConfig{
+		Name:        request.ChangeTypeName,
+		Description: changeDescription,
+		Fields: gql.Fields{
+			request.SequenceFieldName: &gql.Field{
+				Description: changeSequenceFieldDescription,
+				Type:        gql.Int,
+			},
+			request.ChangeTypeFieldName: &gql.Field{
+				Description: changeTypeFieldDescription,
+				Type:        gql.String,
+			},
+			request.DockeyFieldName: &gql.Field{
+				Description: changeDockeyFieldDescription,
+				Type:        gql.String,
+			},
+			request.CidFieldName: &gql.Field{
+				Description: changeCIDFieldDescription,
+				Type:        gql.String,
+			},
+			request.HeightFieldName: &gql.Field{
+				Description: changeHeightFieldDescription,
+				Type:        gql.Int,
+			},
+		},
+	})
+
+	QueryChanges = &gql.Field{
+		Name:        request.ChangesName,
+		Description: changesQueryDescription,
+		Type:        gql.NewList(ChangeObject),
+		Args: gql.FieldConfigArgument{
+			request.Collection:  NewArgConfig(gql.NewNonNull(gql.String), changeCollectionArgDescription),
+			request.AfterClause: NewArgConfig(gql.Int, changeAfterArgDescription),
+			request.LimitClause: NewArgConfig(gql.Int, LimitArgDescription),
+		},
+	}
+)
@@ -110,6 +110,39 @@ Returns a set of head commits matching any provided criteria. If no arguments ar
  provided all head commits in the system will be returned. If no 'field' argument
  is provided only composite commits will be returned. This is equivalent to
  a 'commits' query with Depth: 1, and a differing 'field' default value.
+`
+	changesQueryDescription string = `
+Returns the changes recorded in the change log of the given collection, ordered by
+ their sequence. Each create, update and delete of a document in the collection is
+ recorded as a single change.
+`
+	changeDescription string = `
+Change represents a create, update or delete of a document, as recorded in the change
+ log of its collection.
+`
+	changeCollectionArgDescription string = `
+The name of the collection to return the changes of.
+`
+	changeAfterArgDescription string = `
+An optional sequence, only the changes recorded after the change with this sequence
+ will be returned. Commonly used alongside the 'limit' argument to page through the
+ change log.
+`
+	changeSequenceFieldDescription string = `
+The position of the change within the change log of its collection. Sequences are
+ assigned locally, in the order that changes are committed, and increase monotonically.
+`
+	changeTypeFieldDescription string = `
+The type of the change, either CREATE, UPDATE or DELETE.
+`
+	changeDockeyFieldDescription string = `
+The dockey of the document that was changed.
+`
+	changeCIDFieldDescription string = `
+The CID of the composite commit that contains the change.
+`
+	changeHeightFieldDescription string = `
+The height of the composite commit that contains the change.
 `
 	CountFieldDescription string = `
 Returns the total number of items within the specified child sets. If multiple child
@@ -124,6 +157,27 @@ Returns the total sum of the specified field values within the specified child s
 Returns the average of the specified field values within the specified child sets. If
  multiple fields/sets are specified, the combined average of all items within each set
  (true average, not an average of averages) will be returned as a single value.
+`
+	MinFieldDescription string = `
+Returns the minimum of the specified field values within the specified child sets. If
+ multiple fields/sets are specified, the minimum of all of them will be returned as a
+ single value.
+`
+	MaxFieldDescription string = `
+Returns the maximum of the specified field values within the specified child sets. If
+ multiple fields/sets are specified, the maximum of all of them will be returned as a
+ single value.
+`
+	CountDistinctArgDescription string = `
+If true, only distinct values will be counted. Null values are not counted, and a field
+ must be specified for child sets of objects, as the objects themselves are always distinct.
+`
+	CountFieldArgDescription string = `
+The field whose non-null values are to be counted instead of the objects of the set.
+`
+	blobOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on Blob
+ values.
 `
 	booleanOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on Boolean
@@ -136,6 +190,10 @@ These are the set of filter operators available for use when filtering on Boolea
 	dateTimeOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on DateTime
  values.
+`
+	comparableOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on the results
+ of the _min and _max aggregates.
 `
 	floatOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on Float
@@ -160,6 +218,38 @@ These are the set of filter operators available for use when filtering on String
 	notNullStringOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on String!
  values.
+`
+	booleanListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [Boolean]
+ values.
+`
+	notNullBooleanListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [Boolean!]
+ values.
+`
+	floatListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [Float]
+ values.
+`
+	notNullFloatListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [Float!]
+ values.
+`
+	intListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [Int]
+ values.
+`
+	notNullIntListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [Int!]
+ values.
+`
+	stringListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [String]
+ values.
+`
+	notNullStringListOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on [String!]
+ values.
 `
 	idOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on ID
@@ -202,6 +292,31 @@ The like operator - if the target value contains the given sub-string the check
 The not-like operator - if the target value does not contain the given sub-string the check will
  pass. '%' characters may be used as wildcards, for example '_nlike: "%Ritchie"' would match on
  the string 'Quentin Tarantino'.
+`
+	ilikeStringOperatorDescription string = `
+The case insensitive like operator - if the target value contains the given sub-string, ignoring
+ case, the check will pass. '%' characters may be used as wildcards, for example
+ '_ilike: "%ritchie"' would match on strings ending in 'Ritchie'.
+`
+	nilikeStringOperatorDescription string = `
+The case insensitive not-like operator - if the target value does not contain the given
+ sub-string, ignoring case, the check will pass. '%' characters may be used as wildcards.
+`
+	regexStringOperatorDescription string = `
+The regular expression operator - if the target value matches the given regular expression the
+ check will pass. The expression is not anchored, for example '_regex: "^Rob"' would match on
+ strings starting with 'Rob'.
+`
+	containsOperatorDescription string = `
+The contains operator - if the target array contains the given value the check will pass.
+`
+	anyOperatorDescription string = `
+The any operator - if at least one item of the target array passes the given checks the check
+ will pass.
+`
+	allOperatorDescription string = `
+The all operator - if all items of the target array pass the given checks the check will pass.
+ Empty arrays always pass.
 `
 	AndOperatorDescription string = `
 The and operator - all checks within this clause must pass in order for this check to pass.
@@ -227,5 +342,40 @@ Allows the explicit definition of relationship attributes instead of using the s
 `
 	relationDirectiveNameArgDescription string = `
 Explicitly define the name of the relationship instead of using the system generated defaults.
+`
+	crdtDirectiveDescription string = `
+@crdt is a directive that can be used to select the CRDT type the values of a field
+ are replicated with. Fields use the LWW register by default.
+`
+	crdtTypeDescription string = `
+The CRDT types fields can be replicated with.
+`
+	lwwCRDTTypeDescription string = `
+Last writer wins register, concurrent changes are resolved by keeping the value of
+ the latest one.
+`
+	pnCounterCRDTTypeDescription string = `
+Positive-negative counter, concurrent changes are all applied to the value. Only
+ available for Int and Float fields.
+`
+	orSetCRDTTypeDescription string = `
+Observed-remove set, concurrently added and removed elements are all applied to the
+ value. Only available for array fields.
+`
+	rgaCRDTTypeDescription string = `
+Replicated growable array, concurrent edits of different parts of the text are all
+ applied to the value. Only available for String fields.
+`
+	blobScalarDescription string = `
+The Blob scalar type represents binary data. Its values are base64 encoded strings.
+`
+	comparableScalarDescription string = `
+The Comparable scalar type represents the result of a _min or _max aggregate. Its values
+ have the type of the aggregated values, which may be an Int, Float, String or DateTime.
+`
+	jsonScalarDescription string = `
+The JSON scalar type represents a schemaless JSON value. It may hold objects and arrays
+ of any depth. When used in a filter, object properties are matched by name and may be
+ nested down to the operators to apply to the value at that path.
 `
 )
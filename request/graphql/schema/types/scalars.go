@@ -0,0 +1,146 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	gql "github.com/sourcenetwork/graphql-go"
+	"github.com/sourcenetwork/graphql-go/language/ast"
+)
+
+// BlobScalarType is the scalar type of Blob fields.
+//
+// Its values are represented as base64 encoded strings outside of the database.
+var BlobScalarType = gql.NewScalar(gql.ScalarConfig{
+	Name:        "Blob",
+	Description: blobScalarDescription,
+	Serialize: func(value any) any {
+		switch value := value.(type) {
+		case []byte:
+			return base64.StdEncoding.EncodeToString(value)
+		case string:
+			return value
+		default:
+			return nil
+		}
+	},
+	ParseValue:   parseBlobValue,
+	ParseLiteral: parseBlobLiteral,
+})
+
+// parseBlobValue decodes the given base64 encoded string, returning nil if it is
+// not a valid blob value.
+func parseBlobValue(value any) any {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// parseBlobLiteral converts the given GQL literal to the binary data it represents.
+func parseBlobLiteral(valueAST ast.Value) any {
+	if valueAST, ok := valueAST.(*ast.StringValue); ok {
+		return parseBlobValue(valueAST.Value)
+	}
+	return nil
+}
+
+// JSONScalarType is the scalar type of JSON fields.
+//
+// Its values are passed through as they are, objects are represented as maps
+// and arrays as slices.
+var JSONScalarType = gql.NewScalar(gql.ScalarConfig{
+	Name:        "JSON",
+	Description: jsonScalarDescription,
+	Serialize: func(value any) any {
+		return value
+	},
+	ParseValue: func(value any) any {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+// parseJSONLiteral converts the given GQL literal to the JSON value it represents.
+func parseJSONLiteral(valueAST ast.Value) any {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		result := make(map[string]any, len(valueAST.Fields))
+		for _, field := range valueAST.Fields {
+			result[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return result
+	case *ast.ListValue:
+		result := make([]any, len(valueAST.Values))
+		for i, item := range valueAST.Values {
+			result[i] = parseJSONLiteral(item)
+		}
+		return result
+	case *ast.IntValue:
+		if val, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+			return val
+		}
+		return nil
+	case *ast.FloatValue:
+		if val, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return val
+		}
+		return nil
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	default:
+		return nil
+	}
+}
+
+// ComparableScalarType is the scalar type of the results of the min and max aggregates.
+//
+// Its values have the kind of the aggregated values, which may be an Int, Float, String
+// or DateTime.
+var ComparableScalarType = gql.NewScalar(gql.ScalarConfig{
+	Name:        "Comparable",
+	Description: comparableScalarDescription,
+	Serialize: func(value any) any {
+		switch value := value.(type) {
+		case time.Time:
+			return value.Format(time.RFC3339Nano)
+		default:
+			return value
+		}
+	},
+	ParseValue: func(value any) any {
+		return value
+	},
+	ParseLiteral: parseComparableLiteral,
+})
+
+// parseComparableLiteral converts the given GQL literal to the comparable value it represents,
+// returning nil if it is not a comparable value.
+func parseComparableLiteral(valueAST ast.Value) any {
+	switch valueAST.(type) {
+	case *ast.IntValue, *ast.FloatValue, *ast.StringValue:
+		return parseJSONLiteral(valueAST)
+	default:
+		return nil
+	}
+}
@@ -28,6 +28,14 @@ const (
 	IndexDirectivePropName       = "name"
 	IndexDirectivePropFields     = "fields"
 	IndexDirectivePropDirections = "directions"
+	IndexDirectivePropUnique     = "unique"
+
+	CRDTDirectiveLabel         = "crdt"
+	CRDTDirectivePropType      = "type"
+	CRDTDirectiveTypeLWW       = "lww"
+	CRDTDirectiveTypePNCounter = "pncounter"
+	CRDTDirectiveTypeORSet     = "orset"
+	CRDTDirectiveTypeRGA       = "rga"
 )
 
 var (
@@ -97,6 +105,9 @@ var (
 			IndexDirectivePropDirections: &gql.ArgumentConfig{
 				Type: gql.NewList(OrderingEnum),
 			},
+			IndexDirectivePropUnique: &gql.ArgumentConfig{
+				Type: gql.Boolean,
+			},
 		},
 		Locations: []string{
 			gql.DirectiveLocationObject,
@@ -110,12 +121,54 @@ var (
 			IndexDirectivePropName: &gql.ArgumentConfig{
 				Type: gql.String,
 			},
+			IndexDirectivePropUnique: &gql.ArgumentConfig{
+				Type: gql.Boolean,
+			},
 		},
 		Locations: []string{
 			gql.DirectiveLocationField,
 		},
 	})
 
+	// CRDTEnum is an enum of the CRDT types fields can be replicated with.
+	CRDTEnum = gql.NewEnum(gql.EnumConfig{
+		Name:        "CRDTType",
+		Description: crdtTypeDescription,
+		Values: gql.EnumValueConfigMap{
+			CRDTDirectiveTypeLWW: &gql.EnumValueConfig{
+				Value:       CRDTDirectiveTypeLWW,
+				Description: lwwCRDTTypeDescription,
+			},
+			CRDTDirectiveTypePNCounter: &gql.EnumValueConfig{
+				Value:       CRDTDirectiveTypePNCounter,
+				Description: pnCounterCRDTTypeDescription,
+			},
+			CRDTDirectiveTypeORSet: &gql.EnumValueConfig{
+				Value:       CRDTDirectiveTypeORSet,
+				Description: orSetCRDTTypeDescription,
+			},
+			CRDTDirectiveTypeRGA: &gql.EnumValueConfig{
+				Value:       CRDTDirectiveTypeRGA,
+				Description: rgaCRDTTypeDescription,
+			},
+		},
+	})
+
+	// CRDTFieldDirective @crdt is used to select the CRDT type
+	// the values of a field are replicated with.
+	CRDTFieldDirective *gql.Directive = gql.NewDirective(gql.DirectiveConfig{
+		Name:        CRDTDirectiveLabel,
+		Description: crdtDirectiveDescription,
+		Args: gql.FieldConfigArgument{
+			CRDTDirectivePropType: &gql.ArgumentConfig{
+				Type: CRDTEnum,
+			},
+		},
+		Locations: []string{
+			gql.DirectiveLocationFieldDefinition,
+		},
+	})
+
 	// PrimaryDirective @primary is used to indicate the primary
 	// side of a one-to-one relationship.
 	PrimaryDirective = gql.NewDirective(gql.DirectiveConfig{
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"io"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
@@ -354,6 +355,47 @@ func (w *Wrapper) GetAllIndexes(ctx context.Context) (map[client.CollectionName]
 	return indexes, nil
 }
 
+func (w *Wrapper) AddWebhook(ctx context.Context, webhook client.Webhook) (client.Webhook, error) {
+	args := []string{"client", "webhook", "add"}
+	args = append(args, "--collection", webhook.Collection)
+	if webhook.Filter != "" {
+		args = append(args, "--filter", webhook.Filter)
+	}
+	args = append(args, webhook.URL)
+
+	data, err := w.cmd.execute(ctx, args)
+	if err != nil {
+		return client.Webhook{}, err
+	}
+	var res client.Webhook
+	if err := json.Unmarshal(data, &res); err != nil {
+		return client.Webhook{}, err
+	}
+	return res, nil
+}
+
+func (w *Wrapper) DeleteWebhook(ctx context.Context, id uint32) error {
+	args := []string{"client", "webhook", "delete"}
+	args = append(args, strconv.FormatUint(uint64(id), 10))
+
+	_, err := w.cmd.execute(ctx, args)
+	return err
+}
+
+func (w *Wrapper) GetAllWebhooks(ctx context.Context) ([]client.Webhook, error) {
+	args := []string{"client", "webhook", "list"}
+
+	data, err := w.cmd.execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []client.Webhook
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
 func (w *Wrapper) ExecRequest(ctx context.Context, query string) *client.RequestResult {
 	args := []string{"client", "query"}
 	args = append(args, query)
@@ -14,6 +14,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -235,6 +236,53 @@ func (c *Collection) UpdateWithKeys(
 	return c.updateWith(ctx, args)
 }
 
+func (c *Collection) UpsertWithFilter(
+	ctx context.Context,
+	filter any,
+	doc *client.Document,
+	updater string,
+) (*client.UpsertResult, error) {
+	args := []string{"client", "collection", "upsert"}
+	args = append(args, "--name", c.Description().Name)
+	args = append(args, "--updater", updater)
+
+	switch t := filter.(type) {
+	case string:
+		args = append(args, "--filter", t)
+	default:
+		filterJSON, err := json.Marshal(filter)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--filter", string(filterJSON))
+	}
+
+	// We must call this here, else the doc key on the given object will not match
+	// that of the document saved in the database
+	err := doc.RemapAliasFieldsAndDockey(c.Schema().Fields)
+	if err != nil {
+		return nil, err
+	}
+	document, err := doc.String()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, document)
+
+	data, err := c.cmd.execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var res client.UpsertResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	if res.Created {
+		doc.Clean()
+	}
+	return &res, nil
+}
+
 func (c *Collection) DeleteWith(ctx context.Context, target any) (*client.DeleteResult, error) {
 	switch t := target.(type) {
 	case string, map[string]any, *request.Filter:
@@ -371,8 +419,14 @@ func (c *Collection) CreateIndex(
 	fields := make([]string, len(indexDesc.Fields))
 	for i := range indexDesc.Fields {
 		fields[i] = indexDesc.Fields[i].Name
+		if indexDesc.Fields[i].Direction != "" {
+			fields[i] += ":" + string(indexDesc.Fields[i].Direction)
+		}
 	}
 	args = append(args, "--fields", strings.Join(fields, ","))
+	if indexDesc.Unique {
+		args = append(args, "--unique")
+	}
 
 	data, err := c.cmd.execute(ctx, args)
 	if err != nil {
@@ -407,3 +461,20 @@ func (c *Collection) GetIndexes(ctx context.Context) ([]client.IndexDescription,
 	}
 	return indexes, nil
 }
+
+func (c *Collection) GetChanges(ctx context.Context, after uint64, limit uint64) ([]client.Change, error) {
+	args := []string{"client", "collection", "changes"}
+	args = append(args, "--name", c.Description().Name)
+	args = append(args, "--after", strconv.FormatUint(after, 10))
+	args = append(args, "--limit", strconv.FormatUint(limit, 10))
+
+	data, err := c.cmd.execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var changes []client.Change
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
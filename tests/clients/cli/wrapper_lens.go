@@ -41,6 +41,10 @@ func (w *LensRegistry) SetMigration(ctx context.Context, config client.LensConfi
 	}
 	args = append(args, string(lensCfg))
 
+	for path, id := range config.WasmModuleIDs {
+		args = append(args, "--wasm-module-id", path+"="+id)
+	}
+
 	_, err = w.cmd.execute(ctx, args)
 	return err
 }
@@ -147,6 +147,18 @@ func (w *Wrapper) GetAllIndexes(ctx context.Context) (map[client.CollectionName]
 	return w.client.GetAllIndexes(ctx)
 }
 
+func (w *Wrapper) AddWebhook(ctx context.Context, webhook client.Webhook) (client.Webhook, error) {
+	return w.client.AddWebhook(ctx, webhook)
+}
+
+func (w *Wrapper) DeleteWebhook(ctx context.Context, id uint32) error {
+	return w.client.DeleteWebhook(ctx, id)
+}
+
+func (w *Wrapper) GetAllWebhooks(ctx context.Context) ([]client.Webhook, error) {
+	return w.client.GetAllWebhooks(ctx)
+}
+
 func (w *Wrapper) ExecRequest(ctx context.Context, query string) *client.RequestResult {
 	return w.client.ExecRequest(ctx, query)
 }
@@ -0,0 +1,233 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upsert
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration/collection"
+)
+
+func TestUpsertWithFilter_WithNoMatchingDocs_CreatesDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test upsert users with filter and no matching documents",
+		Docs: map[string][]string{
+			"Users": {`{"name": "John", "age": 21}`},
+		},
+		CollectionCalls: map[string][]func(client.Collection) error{
+			"Users": []func(c client.Collection) error{
+				func(c client.Collection) error {
+					ctx := context.Background()
+
+					doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 33}`))
+					require.NoError(t, err)
+
+					res, err := c.UpsertWithFilter(ctx, `{name: {_eq: "Fred"}}`, doc, `{"age": 34}`)
+					if err != nil {
+						return err
+					}
+
+					assert.True(t, res.Created)
+					assert.Equal(t, int64(1), res.Count)
+					assert.Equal(t, []string{doc.Key().String()}, res.DocKeys)
+
+					d, err := c.Get(ctx, doc.Key(), false)
+					if err != nil {
+						return err
+					}
+
+					age, err := d.Get("age")
+					if err != nil {
+						return err
+					}
+
+					assert.Equal(t, int64(33), age)
+
+					return nil
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestUpsertWithFilter_WithMatchingDoc_UpdatesDoc(t *testing.T) {
+	docStr := `{"name": "John", "age": 21}`
+
+	existing, err := client.NewDocFromJSON([]byte(docStr))
+	require.NoError(t, err)
+
+	test := testUtils.TestCase{
+		Description: "Test upsert users with filter and a matching document",
+		Docs: map[string][]string{
+			"Users": {docStr},
+		},
+		CollectionCalls: map[string][]func(client.Collection) error{
+			"Users": []func(c client.Collection) error{
+				func(c client.Collection) error {
+					ctx := context.Background()
+
+					doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 1}`))
+					require.NoError(t, err)
+
+					res, err := c.UpsertWithFilter(ctx, `{name: {_eq: "John"}}`, doc, `{"age": 22}`)
+					if err != nil {
+						return err
+					}
+
+					assert.False(t, res.Created)
+					assert.Equal(t, int64(1), res.Count)
+					assert.Equal(t, []string{existing.Key().String()}, res.DocKeys)
+
+					d, err := c.Get(ctx, existing.Key(), false)
+					if err != nil {
+						return err
+					}
+
+					age, err := d.Get("age")
+					if err != nil {
+						return err
+					}
+
+					assert.Equal(t, int64(22), age)
+
+					_, err = c.Get(ctx, doc.Key(), false)
+					assert.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+					return nil
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestUpsertWithFilter_WithExistingDocNotMatchingFilter_ReturnsError(t *testing.T) {
+	docStr := `{"name": "John", "age": 21}`
+
+	test := testUtils.TestCase{
+		Description: "Test upsert users with filter and an existing create document not matching the filter",
+		Docs: map[string][]string{
+			"Users": {docStr},
+		},
+		CollectionCalls: map[string][]func(client.Collection) error{
+			"Users": []func(c client.Collection) error{
+				func(c client.Collection) error {
+					ctx := context.Background()
+
+					doc, err := client.NewDocFromJSON([]byte(docStr))
+					require.NoError(t, err)
+
+					_, err = c.UpsertWithFilter(ctx, `{name: {_eq: "Fred"}}`, doc, `{"age": 22}`)
+					return err
+				},
+			},
+		},
+		ExpectedError: "a document with the given dockey already exists",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestUpsertWithFilter_WithConcurrentUpsertsOfSameDoc_CreatesOnceAndUpdates(t *testing.T) {
+	// Each conflict means another upsert was committed, so every upsert is guaranteed
+	// to succeed within the default number of transaction retries.
+	const upserts = 5
+
+	test := testUtils.TestCase{
+		Description: "Test concurrent upserts of the same document",
+		Docs:        map[string][]string{},
+		CollectionCalls: map[string][]func(client.Collection) error{
+			"Users": []func(c client.Collection) error{
+				func(c client.Collection) error {
+					ctx := context.Background()
+
+					var wg sync.WaitGroup
+					results := make([]*client.UpsertResult, upserts)
+					errs := make([]error, upserts)
+					for i := 0; i < upserts; i++ {
+						wg.Add(1)
+						go func(i int) {
+							defer wg.Done()
+							doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 33}`))
+							if err != nil {
+								errs[i] = err
+								return
+							}
+							results[i], errs[i] = c.UpsertWithFilter(ctx, `{name: {_eq: "Fred"}}`, doc, `{"age": 34}`)
+						}(i)
+					}
+					wg.Wait()
+
+					created := 0
+					for i := 0; i < upserts; i++ {
+						require.NoError(t, errs[i])
+						assert.Equal(t, int64(1), results[i].Count)
+						if results[i].Created {
+							created++
+						}
+					}
+					assert.Equal(t, 1, created)
+
+					doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 33}`))
+					require.NoError(t, err)
+
+					d, err := c.Get(ctx, doc.Key(), false)
+					if err != nil {
+						return err
+					}
+
+					age, err := d.Get("age")
+					if err != nil {
+						return err
+					}
+
+					assert.Equal(t, int64(34), age)
+
+					return nil
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestUpsertWithFilter_WithInvalidUpdater_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test upsert users with filter and invalid updater",
+		Docs:        map[string][]string{},
+		CollectionCalls: map[string][]func(client.Collection) error{
+			"Users": []func(c client.Collection) error{
+				func(c client.Collection) error {
+					ctx := context.Background()
+
+					doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred"}`))
+					require.NoError(t, err)
+
+					_, err = c.UpsertWithFilter(ctx, `{name: {_eq: "Fred"}}`, doc, `"name: Eric"`)
+					return err
+				},
+			},
+		},
+		ExpectedError: "the updater of a document is of invalid type",
+	}
+
+	executeTestCase(t, test)
+}
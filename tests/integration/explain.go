@@ -34,27 +34,30 @@ var (
 		"subType": {},
 
 		// These are all valid nodes.
-		"averageNode":   {},
-		"countNode":     {},
-		"createNode":    {},
-		"dagScanNode":   {},
-		"deleteNode":    {},
-		"groupNode":     {},
-		"limitNode":     {},
-		"multiScanNode": {},
-		"orderNode":     {},
-		"parallelNode":  {},
-		"pipeNode":      {},
-		"scanNode":      {},
-		"selectNode":    {},
-		"selectTopNode": {},
-		"sumNode":       {},
-		"topLevelNode":  {},
-		"typeIndexJoin": {},
-		"typeJoinMany":  {},
-		"typeJoinOne":   {},
-		"updateNode":    {},
-		"valuesNode":    {},
+		"aggregateFilterNode": {},
+		"averageNode":         {},
+		"countNode":           {},
+		"createNode":          {},
+		"dagScanNode":         {},
+		"deleteNode":          {},
+		"groupNode":           {},
+		"limitNode":           {},
+		"maxNode":             {},
+		"minNode":             {},
+		"multiScanNode":       {},
+		"orderNode":           {},
+		"parallelNode":        {},
+		"pipeNode":            {},
+		"scanNode":            {},
+		"selectNode":          {},
+		"selectTopNode":       {},
+		"sumNode":             {},
+		"topLevelNode":        {},
+		"typeIndexJoin":       {},
+		"typeJoinMany":        {},
+		"typeJoinOne":         {},
+		"updateNode":          {},
+		"valuesNode":          {},
 	}
 )
 
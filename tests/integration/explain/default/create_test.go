@@ -103,3 +103,49 @@ func TestDefaultExplainMutationRequestDoesNotCreateDocGivenDuplicate(t *testing.
 
 	explainUtils.ExecuteTestCase(t, test)
 }
+
+func TestDefaultExplainMutationRequestWithCreateInput(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Explain (default) mutation request with batch create.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `mutation @explain {
+					create_Author(input: [
+						"{\"name\": \"Shahzad Lone\",\"age\": 27}",
+						"{\"name\": \"John Grisham\",\"age\": 65}"
+					]) {
+						name
+						age
+					}
+				}`,
+
+				ExpectedPatterns: []dataMap{createPattern},
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "createNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"input": []dataMap{
+								{
+									"age":  float64(27),
+									"name": "Shahzad Lone",
+								},
+								{
+									"age":  float64(65),
+									"name": "John Grisham",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
@@ -85,9 +85,11 @@ func TestDefaultExplainRequestWithGroupByWithAverageOnAnInnerField(t *testing.T)
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "_group",
+									"fieldName":      "_group",
+									"childFieldName": "age",
 									"filter": dataMap{
 										"age": dataMap{
 											"_ne": nil,
@@ -172,10 +174,12 @@ func TestDefaultExplainRequestWithAverageInsideTheInnerGroupOnAField(t *testing.
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "_group",
-									"filter":    nil,
+									"fieldName":      "_group",
+									"childFieldName": "_avg",
+									"filter":         nil,
 								},
 							},
 						},
@@ -254,10 +258,12 @@ func TestDefaultExplainRequestWithAverageInsideTheInnerGroupOnAFieldAndNestedGro
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "_group",
-									"filter":    nil,
+									"fieldName":      "_group",
+									"childFieldName": "_avg",
+									"filter":         nil,
 								},
 							},
 						},
@@ -337,10 +343,12 @@ func TestDefaultExplainRequestWithAverageInsideTheInnerGroupAndNestedGroupByWith
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "_group",
-									"filter":    nil,
+									"fieldName":      "_group",
+									"childFieldName": "_avg",
+									"filter":         nil,
 								},
 							},
 						},
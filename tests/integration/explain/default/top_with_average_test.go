@@ -101,9 +101,11 @@ func TestDefaultExplainTopLevelAverageRequest(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: true, // should be leaf of it's branch, so will have no child nodes.
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "Author",
+									"fieldName":      "Author",
+									"childFieldName": "age",
 									"filter": dataMap{
 										"age": dataMap{
 											"_ne": nil,
@@ -194,9 +196,11 @@ func TestDefaultExplainTopLevelAverageRequestWithFilter(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: true, // should be leaf of it's branch, so will have no child nodes.
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "Author",
+									"fieldName":      "Author",
+									"childFieldName": "age",
 									"filter": dataMap{
 										"age": dataMap{
 											"_gt": int32(26),
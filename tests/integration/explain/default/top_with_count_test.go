@@ -70,10 +70,12 @@ func TestDefaultExplainTopLevelCountRequest(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: true, // should be leaf of it's branch, so will have no child nodes.
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "Author",
-									"filter":    nil,
+									"fieldName":      "Author",
+									"childFieldName": nil,
+									"filter":         nil,
 								},
 							},
 						},
@@ -134,9 +136,11 @@ func TestDefaultExplainTopLevelCountRequestWithFilter(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: true, // should be leaf of it's branch, so will have no child nodes.
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "Author",
+									"fieldName":      "Author",
+									"childFieldName": nil,
 									"filter": dataMap{
 										"age": dataMap{
 											"_gt": int32(26),
@@ -0,0 +1,91 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+var aggregateFilterPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"aggregateFilterNode": dataMap{
+				"countNode": dataMap{
+					"selectNode": dataMap{
+						"typeIndexJoin": normalTypeJoinPattern,
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestDefaultExplainRequestWithCountFilter(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request with filter on count.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Author(filter: {age: {_gt: 30}, _count: {_gt: 2}}) {
+						name
+						_count(books: {})
+					}
+				}`,
+
+				ExpectedPatterns: []dataMap{aggregateFilterPattern},
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "aggregateFilterNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"filter": dataMap{
+								"_count": dataMap{
+									"_gt": int32(2),
+								},
+							},
+						},
+					},
+					{
+						TargetNodeName:    "scanNode",
+						OccurancesToSkip:  0,
+						IncludeChildNodes: true,
+						ExpectedAttributes: dataMap{
+							"collectionID":   "3",
+							"collectionName": "Author",
+							"filter": dataMap{
+								"age": dataMap{
+									"_gt": int32(30),
+								},
+							},
+							"spans": []dataMap{
+								{
+									"start": "/3",
+									"end":   "/4",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
@@ -62,9 +62,11 @@ func TestDefaultExplainRequestWithAverageOnJoinedField(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "books",
+									"fieldName":      "books",
+									"childFieldName": "pages",
 									"filter": dataMap{
 										"pages": dataMap{
 											"_ne": nil,
@@ -199,9 +201,11 @@ func TestDefaultExplainRequestWithAverageOnMultipleJoinedFieldsWithFilter(t *tes
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "books",
+									"fieldName":      "books",
+									"childFieldName": "pages",
 									"filter": dataMap{
 										"pages": dataMap{
 											"_ne": nil,
@@ -209,7 +213,8 @@ func TestDefaultExplainRequestWithAverageOnMultipleJoinedFieldsWithFilter(t *tes
 									},
 								},
 								{
-									"fieldName": "articles",
+									"fieldName":      "articles",
+									"childFieldName": "pages",
 									"filter": dataMap{
 										"pages": dataMap{
 											"_gt": int32(3),
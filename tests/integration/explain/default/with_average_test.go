@@ -62,10 +62,12 @@ func TestDefaultExplainRequestWithAverageOnArrayField(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"filter":    dataMap{"_ne": nil},
-									"fieldName": "chapterPages",
+									"filter":         dataMap{"_ne": nil},
+									"fieldName":      "chapterPages",
+									"childFieldName": nil,
 								},
 							},
 						},
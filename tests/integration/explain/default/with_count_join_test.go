@@ -53,10 +53,12 @@ func TestDefaultExplainRequestWithCountOnOneToManyJoinedField(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"filter":    nil,
-									"fieldName": "books",
+									"filter":         nil,
+									"fieldName":      "books",
+									"childFieldName": nil,
 								},
 							},
 						},
@@ -156,15 +158,18 @@ func TestDefaultExplainRequestWithCountOnOneToManyJoinedFieldWithManySources(t *
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"filter":    nil,
-									"fieldName": "books",
+									"filter":         nil,
+									"fieldName":      "books",
+									"childFieldName": nil,
 								},
 
 								{
-									"filter":    nil,
-									"fieldName": "articles",
+									"filter":         nil,
+									"fieldName":      "articles",
+									"childFieldName": nil,
 								},
 							},
 						},
@@ -53,10 +53,12 @@ func TestDefaultExplainRequestWithCountOnInlineArrayField(t *testing.T) {
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"filter":    nil,
-									"fieldName": "chapterPages",
+									"filter":         nil,
+									"fieldName":      "chapterPages",
+									"childFieldName": nil,
 								},
 							},
 						},
@@ -83,3 +85,45 @@ func TestDefaultExplainRequestWithCountOnInlineArrayField(t *testing.T) {
 
 	explainUtils.ExecuteTestCase(t, test)
 }
+
+func TestDefaultExplainRequestWithCountDistinctOnInlineArrayField(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request with distinct count on an inline array field.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Book {
+						name
+						_count(chapterPages: {}, distinct: true)
+					}
+				}`,
+
+				ExpectedPatterns: []dataMap{countPattern},
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "countNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"distinct": true,
+							"sources": []dataMap{
+								{
+									"filter":         nil,
+									"fieldName":      "chapterPages",
+									"childFieldName": nil,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
@@ -64,6 +64,53 @@ func TestDefaultExplainRequestWithStringEqualFilter(t *testing.T) {
 	explainUtils.ExecuteTestCase(t, test)
 }
 
+func TestDefaultExplainRequestWithStringRegexFilter(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request with string regex (_regex) filter.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Author(filter: {name: {_regex: "^Lo+ne$"}}) {
+						name
+						age
+					}
+				}`,
+
+				ExpectedPatterns: []dataMap{basicPattern},
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "scanNode",
+						IncludeChildNodes: true, // should be last node, so will have no child nodes.
+						ExpectedAttributes: dataMap{
+							"collectionID":   "3",
+							"collectionName": "Author",
+							"filter": dataMap{
+								"name": dataMap{
+									"_regex": "^Lo+ne$",
+								},
+							},
+							"spans": []dataMap{
+								{
+									"start": "/3",
+									"end":   "/4",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
+
 func TestDefaultExplainRequestWithIntegerEqualFilter(t *testing.T) {
 	test := testUtils.TestCase{
 
@@ -62,10 +62,12 @@ func TestDefaultExplainRequestWithOnlyLimitOnRelatedChildWithCount(t *testing.T)
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "articles",
-									"filter":    nil,
+									"fieldName":      "articles",
+									"childFieldName": nil,
+									"filter":         nil,
 								},
 							},
 						},
@@ -142,10 +144,12 @@ func TestDefaultExplainRequestWithLimitArgsOnParentAndRelatedChildWithCount(t *t
 						TargetNodeName:    "countNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"distinct": false,
 							"sources": []dataMap{
 								{
-									"fieldName": "articles",
-									"filter":    nil,
+									"fieldName":      "articles",
+									"childFieldName": nil,
+									"filter":         nil,
 								},
 							},
 						},
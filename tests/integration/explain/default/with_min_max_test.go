@@ -0,0 +1,126 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+var minPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"minNode": dataMap{
+				"selectNode": dataMap{
+					"scanNode": dataMap{},
+				},
+			},
+		},
+	},
+}
+
+var maxPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"maxNode": dataMap{
+				"selectNode": dataMap{
+					"typeIndexJoin": normalTypeJoinPattern,
+				},
+			},
+		},
+	},
+}
+
+func TestDefaultExplainRequestWithMinOnInlineArrayField(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request with min on an inline array field.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Book {
+						name
+						_min(chapterPages: {filter: {_gt: 2}})
+					}
+				}`,
+
+				ExpectedPatterns: []dataMap{minPattern},
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "minNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"sources": []dataMap{
+								{
+									"fieldName":      "chapterPages",
+									"childFieldName": nil,
+									"filter": dataMap{
+										"_gt": int32(2),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
+
+func TestDefaultExplainRequestWithMaxOnJoinedField(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (default) request with max on a joined field.",
+
+		Actions: []any{
+			explainUtils.SchemaForExplainTests,
+
+			testUtils.ExplainRequest{
+
+				Request: `query @explain {
+					Author {
+						name
+						_max(books: {field: pages})
+					}
+				}`,
+
+				ExpectedPatterns: []dataMap{maxPattern},
+
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "maxNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: dataMap{
+							"sources": []dataMap{
+								{
+									"fieldName":      "books",
+									"childFieldName": "pages",
+									"filter":         nil,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	explainUtils.ExecuteTestCase(t, test)
+}
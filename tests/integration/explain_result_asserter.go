@@ -55,6 +55,23 @@ func readNumberProp(t *testing.T, val any, prop string) uint64 {
 	return 0
 }
 
+// findSelectNode returns the selectNode of the given selectTopNode, looking through
+// the limit and order nodes that may be placed above it.
+func findSelectNode(node dataMap) (dataMap, bool) {
+	for {
+		if selectNode, ok := node["selectNode"].(dataMap); ok {
+			return selectNode, true
+		}
+		if limitNode, ok := node["limitNode"].(dataMap); ok {
+			node = limitNode
+		} else if orderNode, ok := node["orderNode"].(dataMap); ok {
+			node = orderNode
+		} else {
+			return nil, false
+		}
+	}
+}
+
 func (a *ExplainResultAsserter) Assert(t *testing.T, result []dataMap) {
 	require.Len(t, result, 1, "Expected len(result) = 1, got %d", len(result))
 	explainNode, ok := result[0]["explain"].(dataMap)
@@ -72,7 +89,7 @@ func (a *ExplainResultAsserter) Assert(t *testing.T, result []dataMap) {
 	}
 	selectTopNode, ok := explainNode["selectTopNode"].(dataMap)
 	require.True(t, ok, "Expected selectTopNode")
-	selectNode, ok := selectTopNode["selectNode"].(dataMap)
+	selectNode, ok := findSelectNode(selectTopNode)
 	require.True(t, ok, "Expected selectNode")
 
 	if a.filterMatches.HasValue() {
@@ -0,0 +1,267 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithCompositeIndex_WithEqualFilterOnFirstField_ShouldFetchByPrefix(t *testing.T) {
+	req := `query {
+		User(filter: {verified: {_eq: false}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq filter on the first field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"], directions: [ASC, DESC]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Islam"},
+					{"name": "John"},
+					{"name": "Fred"},
+					{"name": "Shahzad"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(4).WithFieldFetches(12).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithEqualAndGreaterThanFilter_ShouldFetchOnlyPrefix(t *testing.T) {
+	req := `query {
+		User(filter: {verified: {_eq: true}, age: {_gt: 40}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq on the first and _gt on the second field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"], directions: [ASC, DESC]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris"},
+					{"name": "Keenan"},
+					{"name": "Roy"},
+					{"name": "Addo"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(4).WithFieldFetches(12).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithFilterOnlyOnSecondField_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_eq: 44}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index is not used if its first field is not filtered",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Roy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(10).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithFilterInsideOr_ShouldFilterByDocument(t *testing.T) {
+	req := `query {
+		User(filter: {
+			verified: {_eq: true},
+			_or: [{age: {_lt: 25}}, {age: {_gt: 50}}]
+		}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with conditions on indexed field inside _or",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"], directions: [ASC, DESC]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris"},
+					{"name": "Bruno"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(6),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithUniqueCompositeIndex_WithEqualFilterOnAllFields_ShouldFetchSingleKey(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Islam"}, age: {_eq: 32}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test unique composite index filtering with _eq filter on all fields",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["name", "age"], unique: true) {
+					name: String
+					age: Int
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Islam", "age": 32},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithFieldFetches(2).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithUniqueCompositeIndex_WithEqualFilterOnNonMatchingValues_ShouldFetchNothing(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Islam"}, age: {_eq: 33}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test unique composite index filtering with _eq filter on values of different docs",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["name", "age"], unique: true) {
+					name: String
+					age: Int
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(0).WithFieldFetches(0).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithDateTimeField_ShouldFetchByPrefix(t *testing.T) {
+	req := `query {
+		Event(filter: {
+			tenant: {_eq: "acme"},
+			createdAt: {_gt: "2021-07-23T03:00:00-00:00"}
+		}) {
+			name
+			createdAt
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq on a string and _gt on a datetime field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event @index(fields: ["tenant", "createdAt"]) {
+						name: String
+						tenant: String
+						createdAt: DateTime
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Launch", "tenant": "acme", "createdAt": "2021-07-23T08:00:00+02:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Release", "tenant": "acme", "createdAt": "2021-07-24T03:46:56-05:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Kickoff", "tenant": "acme", "createdAt": "2021-07-22T03:46:56-05:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Other", "tenant": "globex", "createdAt": "2021-07-25T03:46:56-05:00"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Launch", "createdAt": "2021-07-23T08:00:00+02:00"},
+					{"name": "Release", "createdAt": "2021-07-24T03:46:56-05:00"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
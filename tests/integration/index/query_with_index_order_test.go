@@ -0,0 +1,258 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithOrderAndLimit_ShouldFetchOnlyLimitedDocsInOrder(t *testing.T) {
+	req := `query {
+		User(order: {age: ASC}, limit: 3) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by an indexed field reads only the limited number of docs",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Shahzad", "age": 20},
+					{"name": "Bruno", "age": 23},
+					{"name": "Fred", "age": 28},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithFieldFetches(6).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderOppositeToIndexDirection_ShouldReadIndexInReverse(t *testing.T) {
+	req := `query {
+		User(order: {age: DESC}, limit: 3) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering opposite to the index direction reads the index in reverse",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris"},
+					{"name": "Keenan"},
+					{"name": "Roy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderAndRangeFilter_ShouldFetchOnlyLimitedDocsInOrder(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 30}}, order: {age: ASC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by an indexed field that is filtered with a range",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Islam"},
+					{"name": "Andy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderAndNilValues_ShouldReturnNilsFirst(t *testing.T) {
+	req := `query {
+		User(order: {age: ASC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by an indexed field returns docs without the value first",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"name": "Alice"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Alice"},
+					{"name": "Shahzad"},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithEqualFilterAndOrderOnNextField_ShouldFetchInOrder(t *testing.T) {
+	req := `query {
+		User(filter: {verified: {_eq: true}}, order: {age: DESC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by the composite index field that follows the _eq filtered one",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"], directions: [ASC, DESC]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris"},
+					{"name": "Keenan"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithOrderOnSecondFieldOnly_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(order: {age: DESC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by a composite index field that is not leading does not use the index",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris"},
+					{"name": "Keenan"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(10).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderByDateTimeAndLimit_ShouldFetchLatestDocs(t *testing.T) {
+	req := `query {
+		Event(order: {createdAt: DESC}, limit: 2) {
+			name
+			createdAt
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by an indexed datetime field reads only the latest docs",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event {
+						name: String
+						createdAt: DateTime @index
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Launch", "createdAt": "2021-07-23T08:00:00+02:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Release", "createdAt": "2021-07-24T03:46:56-05:00"}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{"name": "Kickoff", "createdAt": "2021-07-22T03:46:56-05:00"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Release", "createdAt": "2021-07-24T03:46:56-05:00"},
+					{"name": "Launch", "createdAt": "2021-07-23T08:00:00+02:00"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
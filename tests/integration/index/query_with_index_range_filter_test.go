@@ -0,0 +1,249 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithGreaterAndLessThanFilter_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 28, _lt: 42}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _gt and _lt on the same field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "John"},
+					{"name": "Islam"},
+					{"name": "Andy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithFieldFetches(6).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithRangeFilterOnDescendingField_ShouldFetchOnlyRange(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_ge: 42, _le: 48}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _ge and _le on a descending field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["age", "name"], directions: [DESC, ASC]) {
+					name: String
+					age: Int
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Keenan"},
+					{"name": "Roy"},
+					{"name": "Addo"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithFieldFetches(6).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithLessThanFilterAndNilValues_ShouldNotFetchNils(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_lt: 22}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _lt does not read docs without the field value",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc:          `{"name": "Alice"}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Shahzad"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithFieldFetches(2).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithLikePrefixFilterOnDescendingField_ShouldFetchOnlyPrefix(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_like: "A%"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with a prefix _like pattern on a descending field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["name", "age"], directions: [DESC, ASC]) {
+					name: String
+					age: Int
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Andy"},
+					{"name": "Addo"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithFieldFetches(4).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithAnchoredRegexFilter_ShouldFetchOnlyPrefix(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_regex: "^A.d"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with a _regex pattern anchored to a literal prefix",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String @index
+					age: Int
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Addo"},
+					{"name": "Andy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithFieldFetches(2).WithIndexFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithEqualAndRangeFilter_ShouldFetchOnlyRangeWithinPrefix(t *testing.T) {
+	req := `query {
+		User(filter: {verified: {_eq: true}, age: {_ge: 30, _lt: 45}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq on the first and a range on the second field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User @index(fields: ["verified", "age"]) {
+					name: String
+					age: Int
+					verified: Boolean
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Andy"},
+					{"name": "Addo"},
+					{"name": "Roy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithFieldFetches(9).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithNotEqualFilter_ShouldSkipOnlyValue(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_ne: 44}, name: {_like: "%o%"}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering with _ne reads all keys except those of the value",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					age: Int @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Bruno"},
+					{"name": "John"},
+					{"name": "Addo"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(9),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
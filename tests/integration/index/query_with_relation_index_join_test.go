@@ -0,0 +1,293 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestIndexOnRelation_IfIndexedRelationField_ShouldIndexForeignKeyField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Index on a relation field is created on its foreign key field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						devices: [Device]
+					}
+
+					type Device {
+						model: String
+						owner: User @index
+					}
+				`,
+			},
+			testUtils.GetIndexes{
+				CollectionID: 1,
+				ExpectedIndexes: []client.IndexDescription{
+					{
+						Name: "Device_owner_id_ASC",
+						ID:   1,
+						Fields: []client.IndexedFieldDescription{
+							{
+								Name:      "owner_id",
+								Direction: client.Ascending,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnOneToManyRelation_IfJoiningChildren_ShouldFetchThemByIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Keenan"}}) {
+			name
+			devices {
+				model
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Join children of 1-N relation through the index on the relation field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					devices: [Device]
+				}
+
+				type Device {
+					model: String
+					owner: User @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Keenan",
+						"devices": []map[string]any{
+							{"model": "iPhone 13"},
+							{"model": "iPad Mini"},
+							{"model": "MacBook Pro"},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(13).WithFieldFetches(16).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnOneToOneRelation_IfJoiningSecondarySide_ShouldFetchItByIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Islam"}}) {
+			name
+			address {
+				city
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Join secondary side of 1-1 relation through the index on the relation field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					address: Address
+				}
+
+				type Address {
+					user: User @primary @index
+					city: String
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Islam",
+						"address": map[string]any{
+							"city": "Munich",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(11).WithFieldFetches(12).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnForeignKeyField_IfJoiningChildren_ShouldFetchThemByIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Chris"}}) {
+			name
+			devices {
+				model
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Join children of 1-N relation through an index created on the foreign key field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					devices: [Device]
+				}
+
+				type Device {
+					model: String
+					owner: User
+				}
+			`),
+			testUtils.CreateIndex{
+				CollectionID: 1,
+				FieldName:    "owner_id",
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Chris",
+						"devices": []map[string]any{
+							{"model": "Walkman"},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(11).WithFieldFetches(12).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnOneToManyRelation_IfChildrenFilteredByIndexedField_ShouldKeepFieldIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Keenan"}}) {
+			name
+			devices(filter: {model: {_eq: "MacBook Pro"}}) {
+				model
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Join children of 1-N relation filtered by another indexed field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					devices: [Device]
+				}
+
+				type Device {
+					model: String @index
+					owner: User @index
+				}
+			`),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Keenan",
+						"devices": []map[string]any{
+							{"model": "MacBook Pro"},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnOneToManyRelation_IfJoiningChildrenWithMigration_ShouldFetchThemByIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Keenan"}}) {
+			name
+			devices {
+				model
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Join children of 1-N relation through the index on the relation field, " +
+			"with a migration of another field",
+		Actions: []any{
+			createSchemaWithDocs(`
+				type User {
+					name: String
+					devices: [Device]
+				}
+
+				type Device {
+					model: String
+					year: Int
+					owner: User @index
+				}
+			`),
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Device/Fields/year/Kind", "value": "Float" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Keenan",
+						"devices": []map[string]any{
+							{"model": "MacBook Pro"},
+							{"model": "iPhone 13"},
+							{"model": "iPad Mini"},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package create
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationCreate_WithInput_CreatesAllDocsInInputOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple batch create mutation, results are in the order of the input",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_Users(input: [
+						"{\"name\": \"John\", \"age\": 27}",
+						"{\"name\": \"Islam\", \"age\": 32}",
+						"{\"name\": \"Fred\", \"age\": 16}"
+					]) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"age":  int64(27),
+					},
+					{
+						"name": "Islam",
+						"age":  int64(32),
+					},
+					{
+						"name": "Fred",
+						"age":  int64(16),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					_count(Users: {})
+				}`,
+				Results: []map[string]any{
+					{
+						"_count": 3,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationCreate_WithInputContainingDuplicate_CreatesNoDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple batch create mutation with a duplicate document",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_Users(input: [
+						"{\"name\": \"John\"}",
+						"{\"name\": \"Islam\"}",
+						"{\"name\": \"John\"}"
+					]) {
+						name
+					}
+				}`,
+				ExpectedError: "a document with the given dockey already exists",
+			},
+			testUtils.Request{
+				// Ensure that no documents have been written.
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationCreate_WithInputContainingNonExistantField_CreatesNoDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple batch create mutation with non existant field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_Users(input: [
+						"{\"name\": \"John\"}",
+						"{\"name\": \"Islam\", \"fieldDoesNotExist\": 27}"
+					]) {
+						name
+					}
+				}`,
+				ExpectedError: "The given field does not exist. Name: fieldDoesNotExist",
+			},
+			testUtils.Request{
+				// Ensure that no documents have been written.
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationCreate_WithInputAndData_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple batch create mutation with both input and data",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_Users(data: "{\"name\": \"Fred\"}", input: ["{\"name\": \"John\"}"]) {
+						name
+					}
+				}`,
+				ExpectedError: "create may not be given both data and input",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationCreate_WithEmptyInput_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple batch create mutation with empty input",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_Users(input: []) {
+						name
+					}
+				}`,
+				ExpectedError: "given data payload is empty",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
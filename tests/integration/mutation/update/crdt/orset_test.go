@@ -0,0 +1,330 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestORSetUpdate_WithArray_ShouldSetElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR set with the full array",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b", "c"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": ["d", "c", "a"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": ["d", "e", "c", "a"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"a", "c", "d", "e"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithDuplicateElements_ShouldOnlyKeepDistinctElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Creation of an OR set with duplicate elements",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						points: [Int!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": [3, 1, 3, 2, 1]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						points
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"points": []int64{1, 2, 3},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithAddAndRemoveOperations_ShouldChangeElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR set with add and remove operations",
+		// Set operations can only be given with the JSON of a GQL update mutation
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": {
+						"_add": ["c", "a"],
+						"_remove": ["b"]
+					}
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": {
+						"_add": ["d"]
+					}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"a", "c", "d"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithAddOperationOnNillableArray_ShouldAddElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR set of nillable floats with an add operation",
+		// Set operations can only be given with the JSON of a GQL update mutation
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						scores: [Float] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"scores": {
+						"_add": [1.5, null]
+					}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						scores
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"scores": []immutable.Option[float64]{immutable.Some(1.5), immutable.None[float64]()},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithUnknownOperation_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR set with an unknown operation",
+		// Set operations can only be given with the JSON of a GQL update mutation
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": {
+						"_clear": true
+					}
+				}`,
+				ExpectedError: "unknown set operation. Field: tags, Operation: _clear",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithFilterAndRemoveOperation_ShouldRemoveElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an OR set with a filter and a remove operation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(filter: {name: {_eq: "John"}}, data: "{\"tags\": {\"_remove\": [\"a\"]}}") {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"b"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_QueryWithFirstCid_ShouldReturnInitialElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query of an updated OR set at the commit of its creation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": ["b", "c"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users (
+						cid: "bafybeigbcsifzhisctn2qienkjngljg2rt4andpfcvap4ibk3sxfadcaay",
+						dockey: "bae-f98a3a3c-4e6a-5111-862b-f688af604683"
+					) {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"a", "b"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,292 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestRGAUpdate_WithString_ShouldSetText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an RGA with the full text",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "Hello world"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": "Hello there world"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": "Hi there world!"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						notes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"notes": "Hi there world!",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithNull_ShouldClearText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an RGA with null",
+		// A String field can not be set to null with the JSON of a GQL update mutation
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.CollectionNamedMutationType,
+			testUtils.CollectionSaveMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "Hello world"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": null
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						notes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"notes": "",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithSpliceOperations_ShouldEditText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an RGA with splice operations",
+		// Text operations can only be given with the JSON of a GQL update mutation
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "Hello world"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": {
+						"_splice": {"index": 6, "insert": "brave "}
+					}
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": {
+						"_splice": [
+							{"index": 0, "delete": 5, "insert": "Goodbye"},
+							{"index": 19, "insert": "!"}
+						]
+					}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						notes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"notes": "Goodbye brave world!",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithSpliceOfMultiByteCharacters_ShouldEditCharacters(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an RGA with a splice of multi-byte characters",
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "héllo wörld"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": {
+						"_splice": {"index": 7, "delete": 1, "insert": "ø"}
+					}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						notes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"notes": "héllo wørld",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithSpliceOutOfRange_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an RGA with a splice beyond the end of the text",
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "Hello"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": {
+						"_splice": {"index": 3, "delete": 5}
+					}
+				}`,
+				ExpectedError: "text splice is out of range. Field: notes, Index: 3, Delete: 5",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithUnknownOperation_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of an RGA with an unknown operation",
+		SupportedMutationTypes: immutable.Some([]testUtils.MutationType{
+			testUtils.GQLRequestMutationType,
+		}),
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "Hello"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"notes": {
+						"_append": "!"
+					}
+				}`,
+				ExpectedError: "unknown text operation. Field: notes, Operation: _append",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package field_kinds
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationUpdate_WithBlobField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update of Blob field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						thumbnail: Blob
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"thumbnail": "aGVsbG8="
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"thumbnail": "d29ybGQ="
+				}`,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						Users {
+							thumbnail
+						}
+					}
+				`,
+				Results: []map[string]any{
+					{
+						"thumbnail": "d29ybGQ=",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpdate_WithBlobFieldAndInvalidValue_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of Blob field with a value that is not base64 encoded",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						thumbnail: Blob
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"thumbnail": "aGVsbG8="
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"thumbnail\": \"not base64!\"}") {
+						name
+					}
+				}`,
+				ExpectedError: "illegal base64 data",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
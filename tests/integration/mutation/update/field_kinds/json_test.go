@@ -0,0 +1,103 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package field_kinds
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationUpdate_WithJSONField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update of JSON field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"custom": {"vendor": "Acme", "tags": ["a"]}
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"custom": {"vendor": {"name": "Globex"}, "tags": []}
+				}`,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						Users {
+							custom
+						}
+					}
+				`,
+				Results: []map[string]any{
+					{
+						"custom": map[string]any{
+							"vendor": map[string]any{"name": "Globex"},
+							"tags":   []any{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpdate_WithJSONFieldAndGQLMutation(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update of JSON field through a GQL mutation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"custom": {"rating": 1}
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"custom\": {\"rating\": 5}}") {
+						name
+						custom
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"custom": map[string]any{
+							"rating": float64(5),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
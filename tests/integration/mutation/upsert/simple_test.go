@@ -0,0 +1,329 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upsert
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationUpsert_WithNoMatchingDocs_CreatesDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation with no matching documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						filter: {name: {_eq: "Fred"}},
+						create: "{\"name\": \"Fred\", \"age\": 33}",
+						update: "{\"age\": 34}"
+					) {
+						_key
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-67ba41fd-cbd0-5c36-9dcd-1e60cb23cb5d",
+						"name": "Fred",
+						"age":  int64(33),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Fred",
+						"age":  int64(33),
+					},
+					{
+						"name": "John",
+						"age":  int64(27),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpsert_WithMatchingDoc_UpdatesDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation with a matching document",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						filter: {name: {_eq: "John"}},
+						create: "{\"name\": \"John\", \"age\": 1}",
+						update: "{\"age\": 28}"
+					) {
+						_key
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-88b63198-7d38-5714-a9ff-21ba46374fd1",
+						"name": "John",
+						"age":  int64(28),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"age":  int64(28),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpsert_WithMultipleMatchingDocs_UpdatesAllDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation with multiple matching documents",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Islam",
+					"age": 32
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred",
+					"age": 16
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						filter: {age: {_gt: 18}},
+						create: "{\"name\": \"Andy\", \"age\": 40}",
+						update: "{\"age\": 50}"
+					) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Islam",
+						"age":  int64(50),
+					},
+					{
+						"name": "John",
+						"age":  int64(50),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpsert_WithUpdatedDocNoLongerMatchingFilter_ReturnsDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation where the updated document no longer matches the filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						verified: Boolean
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"verified": false
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						filter: {verified: {_eq: false}},
+						create: "{\"name\": \"John\", \"verified\": true}",
+						update: "{\"verified\": true}"
+					) {
+						name
+						verified
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":     "John",
+						"verified": true,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpsert_WithExistingDocNotMatchingFilter_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation where the document to create already exists",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						filter: {name: {_eq: "Fred"}},
+						create: "{\"name\": \"John\", \"age\": 27}",
+						update: "{\"age\": 28}"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "a document with the given dockey already exists",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpsert_WithoutFilter_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation without a filter",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						create: "{\"name\": \"John\"}",
+						update: "{\"name\": \"Fred\"}"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "upsert requires a filter",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpsert_WithoutCreateData_ReturnsError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple upsert mutation without create data",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					upsert_Users(
+						filter: {name: {_eq: "John"}},
+						update: "{\"name\": \"Fred\"}"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "upsert requires both create and update data",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
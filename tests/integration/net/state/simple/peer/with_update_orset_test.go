@@ -0,0 +1,96 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package peer_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// TestP2PWithORSetUpdatesPerNode tests that elements concurrently added to and removed
+// from a set on different nodes are all kept once the nodes are synced.
+func TestP2PWithORSetUpdatesPerNode(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Tags": ["a"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Add "b" on the first node while the nodes are not connected
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Tags": ["a", "b"]
+				}`,
+				DontSync: true,
+			},
+			testUtils.UpdateDoc{
+				// Remove "a" and add "c" on the second node while the nodes are not connected
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Tags": ["c"]
+				}`,
+				DontSync: true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.UpdateDoc{
+				// Add "d" on the first node, the second node receives the previous
+				// addition of the first node with it
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Tags": ["a", "b", "d"]
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.UpdateDoc{
+				// Add "e" on the second node, which has all changes by now
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Tags": ["b", "c", "d", "e"]
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Tags": []string{"b", "c", "d", "e"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
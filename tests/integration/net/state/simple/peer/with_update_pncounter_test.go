@@ -0,0 +1,96 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package peer_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// TestP2PWithPNCounterUpdatesPerNode tests that concurrent changes to a counter
+// made on different nodes are all kept once the nodes are synced.
+func TestP2PWithPNCounterUpdatesPerNode(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Views: Int @crdt(type: pncounter)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Views": 10
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Increment by 5 on the first node while the nodes are not connected
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Views": 15
+				}`,
+				DontSync: true,
+			},
+			testUtils.UpdateDoc{
+				// Increment by 3 on the second node while the nodes are not connected
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Views": 13
+				}`,
+				DontSync: true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.UpdateDoc{
+				// Increment by 1 on the first node, the second node receives the
+				// previous increment of the first node with it
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Views": 16
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.UpdateDoc{
+				// Decrement by 1 on the second node, which has all changes by now
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Views": 18
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Views
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Views": int64(18),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package peer_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// TestP2PWithRGAUpdatesPerNode tests that concurrent edits of different parts of a
+// text made on different nodes are all kept once the nodes are synced.
+func TestP2PWithRGAUpdatesPerNode(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on all nodes
+				Doc: `{
+					"Name": "John",
+					"Notes": "Hello world"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Insert "brave " on the first node while the nodes are not connected
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Notes": "Hello brave world"
+				}`,
+				DontSync: true,
+			},
+			testUtils.UpdateDoc{
+				// Replace "Hello" with "Hi" and append "!" on the second node while
+				// the nodes are not connected
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Notes": "Hi world!"
+				}`,
+				DontSync: true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.UpdateDoc{
+				// Insert "new " on the first node, the second node receives the previous
+				// insertion of the first node with it
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Notes": "Hello brave new world"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.UpdateDoc{
+				// Append "?" on the second node, which has all changes by now
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"Notes": "Hi brave new world!?"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Notes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Notes": "Hi brave new world!?",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
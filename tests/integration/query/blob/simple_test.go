@@ -0,0 +1,158 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package blob
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func createUsersWithThumbnails() []any {
+	return []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Users {
+					name: String
+					thumbnail: Blob
+				}
+			`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "John",
+				"thumbnail": "aGVsbG8="
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "Fred",
+				"thumbnail": "AAEC"
+			}`,
+		},
+	}
+}
+
+func TestQueryBlob_ShouldReturnBase64EncodedValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query of a Blob field",
+		Actions: append(
+			createUsersWithThumbnails(),
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						thumbnail
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":      "Fred",
+						"thumbnail": "AAEC",
+					},
+					{
+						"name":      "John",
+						"thumbnail": "aGVsbG8=",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryBlob_WithEqFilter_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query with equality filter on a Blob field",
+		Actions: append(
+			createUsersWithThumbnails(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {thumbnail: {_eq: "AAEC"}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "Fred"},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryBlob_WithInvalidBase64Filter_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query with filter on a Blob field with a value that is not base64 encoded",
+		Actions: append(
+			createUsersWithThumbnails(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {thumbnail: {_eq: "not base64!"}}) {
+						name
+					}
+				}`,
+				ExpectedError: "Argument \"filter\" has invalid value {thumbnail: {_eq: \"not base64!\"}}.",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryBlob_WithOrder_ShouldOrderByBytes(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query ordered by a Blob field",
+		Actions: append(
+			createUsersWithThumbnails(),
+			testUtils.Request{
+				Request: `query {
+					Users(order: {thumbnail: DESC}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "John"},
+					{"name": "Fred"},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryBlob_WithInvalidBase64Value_ShouldError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Create with a Blob value that is not base64 encoded",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						thumbnail: Blob
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"thumbnail": "not base64!"
+				}`,
+				ExpectedError: "invalid field value",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package blob
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryBlob_WithGroupAndDistinctCount_ShouldCountDistinctValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Group query with a distinct count of Blob values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						points: Int
+						thumbnail: Blob
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 1,
+					"thumbnail": "aGVsbG8="
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 2,
+					"thumbnail": "AAEC"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 3,
+					"thumbnail": "AAEC"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(groupBy: [name]) {
+						name
+						_count(_group: {field: thumbnail}, distinct: true)
+						all: _count(_group: {})
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"_count": 2,
+						"all":    3,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
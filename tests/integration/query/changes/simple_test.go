@@ -0,0 +1,118 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package changes
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryChanges(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple changes query",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID: 0,
+				DocID:        0,
+			},
+			testUtils.Request{
+				Request: `query {
+					changes(collection: "Users") {
+						sequence
+						type
+						dockey
+						cid
+						height
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"sequence": int64(1),
+						"type":     "CREATE",
+						"dockey":   "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						"cid":      "bafybeigtscyfb46emlw6ptsjrrflujsbgfszpqc2o4n6kqggu3jexw4ncy",
+						"height":   int64(1),
+					},
+					{
+						"sequence": int64(2),
+						"type":     "UPDATE",
+						"dockey":   "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						"cid":      "bafybeihcjyc7vmb2ue4i6ogcejlnibs47ot6uqatrw7vu3j7hvrxxbs42i",
+						"height":   int64(2),
+					},
+					{
+						"sequence": int64(3),
+						"type":     "DELETE",
+						"dockey":   "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						"cid":      "bafybeiajs3j7ggvgd3wbybcjrhax4ek3d62ow6vwvhjoj2gtchsckpwpbq",
+						"height":   int64(3),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryChanges_WithNoChanges_ReturnsEmpty(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple changes query, no changes",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.Request{
+				Request: `query {
+					changes(collection: "Users") {
+						sequence
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryChanges_WithUnknownCollection_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple changes query, unknown collection",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.Request{
+				Request: `query {
+					changes(collection: "Books") {
+						sequence
+					}
+				}`,
+				ExpectedError: "datastore: key not found",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
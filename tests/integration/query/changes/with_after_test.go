@@ -0,0 +1,144 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package changes
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryChanges_WithAfter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Changes query with after",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name":	"Shahzad",
+					"age":	28
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					changes(collection: "Users", after: 1) {
+						sequence
+						type
+						dockey
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"sequence": int64(2),
+						"type":     "CREATE",
+						"dockey":   "bae-1e608f7d-b01e-5dd5-ad4a-9c6cc3005a36",
+					},
+					{
+						"sequence": int64(3),
+						"type":     "UPDATE",
+						"dockey":   "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryChanges_WithAfterAndLimit(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Changes query with after and limit",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	23
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					changes(collection: "Users", after: 1, limit: 1) {
+						sequence
+						type
+						height
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"sequence": int64(2),
+						"type":     "UPDATE",
+						"height":   int64(2),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryChanges_WithAfterLastChange_ReturnsEmpty(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Changes query with after the last change",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					changes(collection: "Users", after: 1) {
+						sequence
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
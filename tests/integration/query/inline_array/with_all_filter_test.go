@@ -0,0 +1,80 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineFloatArrayWithAllFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by all items greater than value",
+		Request: `query {
+					Users(filter: {favouriteFloats: {_all: {_ge: 1}}}) {
+						name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"favouriteFloats": [1, 2.5, 6]
+				}`,
+				`{
+					"name": "Shahzad",
+					"favouriteFloats": [0.5, 5]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineBoolArrayWithAllFilterAndEmptyArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by all items equal to value, with an empty array",
+		Request: `query {
+					Users(filter: {likedIndexes: {_all: {_eq: true}}}) {
+						name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"likedIndexes": []
+				}`,
+				`{
+					"name": "Shahzad",
+					"likedIndexes": [true, false]
+				}`,
+				`{
+					"name": "Andy"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntArrayWithAnyFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by any item greater than value",
+		Request: `query {
+					Users(filter: {favouriteIntegers: {_any: {_gt: 5}}}) {
+						name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"favouriteIntegers": [1, 2, 6]
+				}`,
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [1, 5]
+				}`,
+				`{
+					"name": "Andy",
+					"favouriteIntegers": []
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableStringArrayWithAnyLikeFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by any item like value",
+		Request: `query {
+					Users(filter: {pageHeaders: {_any: {_ilike: "%WORLD%"}}}) {
+						name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"pageHeaders": ["hello", null, "hello world"]
+				}`,
+				`{
+					"name": "Shahzad",
+					"pageHeaders": ["hello", null]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineStringArrayWithContainsFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by contained string",
+		Request: `query {
+					Users(filter: {preferredStrings: {_contains: "the previous"}}) {
+						name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"preferredStrings": ["", "the previous", "the first", "empty string"]
+				}`,
+				`{
+					"name": "Shahzad",
+					"preferredStrings": ["the first"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableIntArrayWithContainsNullFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by contained null",
+		Request: `query {
+					Users(filter: {testScores: {_contains: null}}) {
+						name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"testScores": [-1, null, 2]
+				}`,
+				`{
+					"name": "Shahzad",
+					"testScores": [3]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntegerArrayWithCountDistinct(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, distinct count of integer array",
+		Request: `query {
+					Users {
+						name
+						_count(favouriteIntegers: {}, distinct: true)
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, -1, 1, 0, 2]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":   "Shahzad",
+				"_count": 4,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableStringArrayWithCountDistinctAndFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, distinct count of filtered nillable string array",
+		Request: `query {
+					Users {
+						name
+						_count(pageHeaders: {filter: {_ne: "header"}}, distinct: true)
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"pageHeaders": ["the first", "header", null, "the first", "the last", null]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":   "Shahzad",
+				"_count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithCountDistinctFalse(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, count of integer array with distinct false",
+		Request: `query {
+					Users {
+						name
+						_count(favouriteIntegers: {}, distinct: false)
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, -1, 1, 0, 2]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":   "Shahzad",
+				"_count": 6,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntegerArrayWithMinMaxAndEmptyArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array with no filter, min and max of empty integer array",
+		Request: `query {
+					Users {
+						name
+						_min(favouriteIntegers: {})
+						_max(favouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "John",
+					"favouriteIntegers": []
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "John",
+				"_min": nil,
+				"_max": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithMinMaxAndPopulatedArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array with no filter, min and max of integer array",
+		Request: `query {
+					Users {
+						name
+						_min(favouriteIntegers: {})
+						_max(favouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, -1, 1, 0]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": int64(-1),
+				"_max": int64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableIntegerArrayWithMinMaxAndPopulatedArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array with no filter, min and max of nillable integer array",
+		Request: `query {
+					Users {
+						name
+						_min(testScores: {})
+						_max(testScores: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"testScores": [-1, null, 13, 0]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": int64(-1),
+				"_max": int64(13),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineFloatArrayWithMinMaxAndPopulatedArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array with no filter, min and max of float array",
+		Request: `query {
+					Users {
+						name
+						_min(favouriteFloats: {})
+						_max(favouriteFloats: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteFloats": [3.1425, 0.00000000001, 10]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": float64(0.00000000001),
+				"_max": float64(10),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithMinMaxWithFilterAndLimit(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min and max of filtered, ordered and limited integer array",
+		Request: `query {
+					Users {
+						name
+						_min(favouriteIntegers: {filter: {_gt: 0}, order: ASC, limit: 2})
+						_max(favouriteIntegers: {filter: {_gt: 0}, order: ASC, limit: 2})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, 5, 1, 0, 7]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": int64(1),
+				"_max": int64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineStringArrayWithMinMaxAndPopulatedArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array with no filter, min and max of string array",
+		Request: `query {
+					Users {
+						name
+						_min(preferredStrings: {})
+						_max(pageHeaders: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"preferredStrings": ["", "the previous", "the first", "empty string"],
+					"pageHeaders": ["the first", null, "the previous"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": "",
+				"_max": "the previous",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
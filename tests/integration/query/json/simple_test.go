@@ -0,0 +1,126 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryJSON_WithNestedObject_ShouldReturnObject(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query of a JSON field holding a nested object",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"custom": {
+						"vendor": {
+							"name": "Acme",
+							"tags": ["a", "b"]
+						},
+						"score": 7.5,
+						"active": true
+					}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						custom
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"custom": map[string]any{
+							"vendor": map[string]any{
+								"name": "Acme",
+								"tags": []any{"a", "b"},
+							},
+							"score":  float64(7.5),
+							"active": true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithScalarsAndArray_ShouldReturnValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query of JSON fields holding values that are not objects",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"custom": [1, "two", null]
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred",
+					"custom": "plain"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Andy"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						custom
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "Andy",
+						"custom": nil,
+					},
+					{
+						"name":   "Fred",
+						"custom": "plain",
+					},
+					{
+						"name":   "John",
+						"custom": []any{float64(1), "two", nil},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryJSON_WithGroupAndDistinctCount_ShouldCountDistinctValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Group query with a distinct count of JSON values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						points: Int
+						custom: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 1,
+					"custom": {"tags": ["a", "b"], "score": 1}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 2,
+					"custom": {"score": 1, "tags": ["a", "b"]}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 3,
+					"custom": {"tags": ["a"], "score": 1}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 4,
+					"custom": ["a", "b"]
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 5,
+					"custom": "{\"score\":1,\"tags\":[\"a\",\"b\"]}"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(groupBy: [name]) {
+						name
+						_count(_group: {field: custom}, distinct: true)
+						all: _count(_group: {})
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"_count": 4,
+						"all":    5,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func createUsersWithCustomJSON() []any {
+	return []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Users {
+					name: String
+					custom: JSON
+				}
+			`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "John",
+				"custom": {"vendor": {"name": "Acme", "rating": 4}, "region": "eu"}
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "Fred",
+				"custom": {"vendor": {"name": "Globex", "rating": 2}, "region": "us"}
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "Andy",
+				"custom": "no vendor"
+			}`,
+		},
+	}
+}
+
+func TestQueryJSON_WithEqFilterOnNestedProperty_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query with equality filter on a nested property of a JSON field",
+		Actions: append(
+			createUsersWithCustomJSON(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {custom: {vendor: {name: {_eq: "Acme"}}}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "John"},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithGtFilterOnNestedNumber_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query with greater than filter on a nested number of a JSON field",
+		Actions: append(
+			createUsersWithCustomJSON(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {custom: {vendor: {rating: {_gt: 3}}}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "John"},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithOperatorsWithinPath_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query with logical and set operators within the path of a JSON field filter",
+		Actions: append(
+			createUsersWithCustomJSON(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {custom: {_or: [
+						{region: {_in: ["us", "ap"]}},
+						{vendor: {name: {_like: "%cm%"}}}
+					]}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "John"},
+					{"name": "Fred"},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithEqFilterOnScalarValue_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Query with equality filter on a JSON field holding a scalar",
+		Actions: append(
+			createUsersWithCustomJSON(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {custom: {_eq: "no vendor"}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "Andy"},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var aggregateFilterDocs = map[int][]string{
+	//books
+	0: { // bae-fd541c25-229e-5280-b44b-e5c2af3e374d
+		`{
+			"name": "Painted House",
+			"rating": 4.9,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "A Time for Mercy",
+			"rating": 4.5,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "Theif Lord",
+			"rating": 4.8,
+			"author_id": "bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04"
+		}`,
+	},
+	//authors
+	1: {
+		// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+		`{
+			"name": "John Grisham",
+			"age": 65,
+			"verified": true
+		}`,
+		// bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04
+		`{
+			"name": "Cornelia Funke",
+			"age": 62,
+			"verified": false
+		}`,
+	},
+}
+
+func TestQueryOneToManyWithCountFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, filtered by count",
+		Request: `query {
+				Author(filter: {_count: {_gt: 1}}) {
+					name
+					_count(published: {})
+				}
+			}`,
+		Docs: aggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"name":   "John Grisham",
+				"_count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithFilteredSumFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, filtered by the sum of filtered children",
+		Request: `query {
+				Author(filter: {verified: {_eq: false}, _sum: {_gt: 4}}) {
+					name
+					_sum(published: {field: rating, filter: {rating: {_gt: 4.6}}})
+				}
+			}`,
+		Docs: aggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+				"_sum": 4.8,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterOnChildSelect(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from one side, with related author filtered by count",
+		Request: `query {
+				Book {
+					name
+					author(filter: {_count: {_gt: 1}}) {
+						name
+						_count(published: {})
+					}
+				}
+			}`,
+		Docs: aggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"name":   "Theif Lord",
+				"author": nil,
+			},
+			{
+				"name": "Painted House",
+				"author": map[string]any{
+					"name":   "John Grisham",
+					"_count": 2,
+				},
+			},
+			{
+				"name": "A Time for Mercy",
+				"author": map[string]any{
+					"name":   "John Grisham",
+					"_count": 2,
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterOnRelation_Errors(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, filtered by count of related object",
+		Request: `query {
+				Author(filter: {published: {_count: {_gt: 1}}}) {
+					name
+				}
+			}`,
+		Docs:          aggregateFilterDocs,
+		ExpectedError: "aggregates may only be filtered at the top level of a select filter",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterWithinAggregateFilter_Errors(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, with count within aggregate target filter",
+		Request: `query {
+				Author {
+					name
+					_count(published: {filter: {_count: {_gt: 1}}})
+				}
+			}`,
+		Docs:          aggregateFilterDocs,
+		ExpectedError: "aggregates may only be filtered at the top level of a select filter",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterOnAggregateRequestedTwice_Errors(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, filtered by count requested twice",
+		Request: `query {
+				Author(filter: {_count: {_gt: 1}}) {
+					name
+					allBooks: _count(published: {})
+					goodBooks: _count(published: {filter: {rating: {_gt: 4.6}}})
+				}
+			}`,
+		Docs:          aggregateFilterDocs,
+		ExpectedError: "aggregate must be requested only once in order to be filtered. Name: _count",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterOnAliasedAggregate(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, filtered by aliased count",
+		Request: `query {
+				Author(filter: {_count: {_gt: 1}}) {
+					name
+					bookCount: _count(published: {})
+				}
+			}`,
+		Docs: aggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"name":      "John Grisham",
+				"bookCount": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var groupAggregateFilterDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Age": 21
+		}`,
+		`{
+			"Name": "John",
+			"Age": 32
+		}`,
+		`{
+			"Name": "Carlo",
+			"Age": 55
+		}`,
+		`{
+			"Name": "Alice",
+			"Age": 19
+		}`,
+		`{
+			"Name": "Alice",
+			"Age": 25
+		}`,
+		`{
+			"Name": "Alice",
+			"Age": 44
+		}`,
+	},
+}
+
+func TestQuerySimpleWithGroupByStringWithCountFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, filtered by group count",
+		Request: `query {
+					Users(groupBy: [Name], filter: {_count: {_gt: 1}}) {
+						Name
+						_count(_group: {})
+					}
+				}`,
+		Docs: groupAggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"Name":   "Alice",
+				"_count": 3,
+			},
+			{
+				"Name":   "John",
+				"_count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithDocFilterAndCountFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, filtered by document values and group count",
+		Request: `query {
+					Users(groupBy: [Name], filter: {Age: {_gt: 20}, _count: {_gt: 1}}) {
+						Name
+						_count(_group: {})
+					}
+				}`,
+		Docs: groupAggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"Name":   "John",
+				"_count": 2,
+			},
+			{
+				"Name":   "Alice",
+				"_count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithAliasedAverageFilterAndOrderAndLimit(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, filtered by aliased group average, with order and limit",
+		Request: `query {
+					Users(groupBy: [Name], filter: {_avg: {_ge: 29}}, order: {Name: DESC}, limit: 1) {
+						Name
+						AverageAge: _avg(_group: {field: Age})
+					}
+				}`,
+		Docs: groupAggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"Name":       "Carlo",
+				"AverageAge": float64(55),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithCompoundAggregateFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, filtered by compound aggregate conditions",
+		Request: `query {
+					Users(groupBy: [Name], filter: {_or: [{_count: {_gt: 2}}, {_max: {_gt: 50}}]}) {
+						Name
+						_count(_group: {})
+						_max(_group: {field: Age})
+					}
+				}`,
+		Docs: groupAggregateFilterDocs,
+		Results: []map[string]any{
+			{
+				"Name":   "Alice",
+				"_count": 3,
+				"_max":   int64(44),
+			},
+			{
+				"Name":   "Carlo",
+				"_count": 1,
+				"_max":   int64(55),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithFilterOnUnrequestedAggregate_Errors(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, filtered by an aggregate that is not requested",
+		Request: `query {
+					Users(groupBy: [Name], filter: {_sum: {_gt: 1}}) {
+						Name
+						_count(_group: {})
+					}
+				}`,
+		Docs:          groupAggregateFilterDocs,
+		ExpectedError: "aggregate must be requested in order to be filtered",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithMixedCompoundAggregateFilter_Errors(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, filtered by compound aggregate and document conditions",
+		Request: `query {
+					Users(groupBy: [Name], filter: {_or: [{_count: {_gt: 2}}, {Age: {_gt: 50}}]}) {
+						Name
+						_count(_group: {})
+					}
+				}`,
+		Docs:          groupAggregateFilterDocs,
+		ExpectedError: "aggregates may only be filtered at the top level of a select filter",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithAggregateFilterWithinGroup_Errors(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, with an aggregate filter on the group",
+		Request: `query {
+					Users(groupBy: [Name]) {
+						Name
+						_group(groupBy: [Age], filter: {_count: {_gt: 1}}) {
+							Age
+							_count(_group: {})
+						}
+					}
+				}`,
+		Docs:          groupAggregateFilterDocs,
+		ExpectedError: "aggregates may not be filtered within _group",
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithGroupByStringWithoutRenderedGroupAndChildMinMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, min and max on non-rendered group values",
+		Request: `query {
+					Users(groupBy: [Name]) {
+						Name
+						_min(_group: {field: Age})
+						_max(_group: {field: HeightM})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 32,
+					"HeightM": 1.82
+				}`,
+				`{
+					"Name": "John",
+					"Age": 38,
+					"HeightM": 1.79
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Alice",
+				"_min": int64(19),
+				"_max": nil,
+			},
+			{
+				"Name": "John",
+				"_min": int64(32),
+				"_max": 1.82,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithoutRenderedGroupAndChildCountDistinct(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, distinct count on non-rendered group values",
+		Request: `query {
+					Users(groupBy: [Name]) {
+						Name
+						_count(_group: {field: Age}, distinct: true)
+						all: _count(_group: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Email": "john@source.hub",
+					"Age": 32
+				}`,
+				`{
+					"Name": "John",
+					"Email": "john@source.network",
+					"Age": 32
+				}`,
+				`{
+					"Name": "John",
+					"Age": 38
+				}`,
+				`{
+					"Name": "Alice"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name":   "John",
+				"_count": 2,
+				"all":    3,
+			},
+			{
+				"Name":   "Alice",
+				"_count": 0,
+				"all":    1,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinMaxOfTopLevelCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, top level min and max",
+		Request: `query {
+					_min(Users: {field: Age})
+					_max(Users: {field: Age, filter: {Name: {_ne: "Bob"}}})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 62
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 30
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_min": int64(21),
+				"_max": int64(30),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithoutRenderedGroupAndChildMinMaxOfDateTimeAndString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, min and max on non-rendered date time and string group values",
+		Request: `query {
+					Users(groupBy: [Name]) {
+						Name
+						_min(_group: {field: CreatedAt})
+						_max(_group: {field: Email})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Email": "john@source.hub",
+					"CreatedAt": "2017-07-23T03:46:56-05:00"
+				}`,
+				`{
+					"Name": "John",
+					"Email": "johnny@source.hub",
+					"CreatedAt": "2017-07-23T05:00:00+02:00"
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				// Date times are compared by the time that they represent, not by their text.
+				"_min": "2017-07-23T05:00:00+02:00",
+				"_max": "johnny@source.hub",
+			},
+			{
+				"Name": "Alice",
+				"_min": nil,
+				"_max": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinMaxOfDateTimeAndString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with top level min and max on date time and string values",
+		Request: `query {
+					_min(Users: {field: Name})
+					_max(Users: {field: CreatedAt})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"CreatedAt": "2017-07-23T03:46:56-05:00"
+				}`,
+				`{
+					"Name": "Bob",
+					"CreatedAt": "2018-07-23T03:46:56-05:00"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_min": "Bob",
+				"_max": "2018-07-23T03:46:56-05:00",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -388,59 +388,106 @@ func TestSchemaAggregateInlineArrayCreatesUsersAverage(t *testing.T) {
 }
 */
 
-var aggregateGroupArg = map[string]any{
-	"name": "_group",
-	"type": map[string]any{
-		"name": "Users__CountSelector",
-		"inputFields": []any{
-			map[string]any{
-				"name": "filter",
-				"type": map[string]any{
-					"name": "UsersFilterArg",
-					"inputFields": []any{
-						map[string]any{
-							"name": "_and",
-							"type": map[string]any{
-								"name": nil,
+// makeAggregateGroupArg returns the _group argument of the count aggregate of a Users
+// type with a Favourites field, which is filtered with the given operator block.
+func makeAggregateGroupArg(favouritesOperatorBlock string) map[string]any {
+	return map[string]any{
+		"name": "_group",
+		"type": map[string]any{
+			"name": "Users__CountSelector",
+			"inputFields": []any{
+				map[string]any{
+					"name": "field",
+					"type": map[string]any{
+						"name":        "UsersScalarFieldsArg",
+						"inputFields": nil,
+					},
+				},
+				map[string]any{
+					"name": "filter",
+					"type": map[string]any{
+						"name": "UsersFilterArg",
+						"inputFields": []any{
+							map[string]any{
+								"name": "Favourites",
+								"type": map[string]any{
+									"name": favouritesOperatorBlock,
+								},
 							},
-						},
-						map[string]any{
-							"name": "_key",
-							"type": map[string]any{
-								"name": "IDOperatorBlock",
+							map[string]any{
+								"name": "_and",
+								"type": map[string]any{
+									"name": nil,
+								},
 							},
-						},
-						map[string]any{
-							"name": "_not",
-							"type": map[string]any{
-								"name": "UsersFilterArg",
+							map[string]any{
+								"name": "_avg",
+								"type": map[string]any{
+									"name": "FloatOperatorBlock",
+								},
 							},
-						},
-						map[string]any{
-							"name": "_or",
-							"type": map[string]any{
-								"name": nil,
+							map[string]any{
+								"name": "_count",
+								"type": map[string]any{
+									"name": "IntOperatorBlock",
+								},
+							},
+							map[string]any{
+								"name": "_key",
+								"type": map[string]any{
+									"name": "IDOperatorBlock",
+								},
+							},
+							map[string]any{
+								"name": "_max",
+								"type": map[string]any{
+									"name": "ComparableOperatorBlock",
+								},
+							},
+							map[string]any{
+								"name": "_min",
+								"type": map[string]any{
+									"name": "ComparableOperatorBlock",
+								},
+							},
+							map[string]any{
+								"name": "_not",
+								"type": map[string]any{
+									"name": "UsersFilterArg",
+								},
+							},
+							map[string]any{
+								"name": "_or",
+								"type": map[string]any{
+									"name": nil,
+								},
+							},
+							map[string]any{
+								"name": "_sum",
+								"type": map[string]any{
+									"name": "FloatOperatorBlock",
+								},
 							},
 						},
 					},
 				},
-			},
-			map[string]any{
-				"name": "limit",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "limit",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
-			},
-			map[string]any{
-				"name": "offset",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "offset",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
 			},
 		},
-	},
+	}
 }
 
 var aggregateVersionArg = map[string]any{
@@ -466,6 +513,14 @@ var aggregateVersionArg = map[string]any{
 	},
 }
 
+var aggregateDistinctArg = map[string]any{
+	"name": "distinct",
+	"type": map[string]any{
+		"name":        "Boolean",
+		"inputFields": nil,
+	},
+}
+
 func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *testing.T) {
 	test := testUtils.TestCase{
 		Actions: []any{
@@ -578,8 +633,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("BooleanListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -704,8 +760,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("NotNullBooleanListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -854,8 +911,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("IntListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -1004,8 +1062,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("NotNullIntListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -1154,8 +1213,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("FloatListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -1304,8 +1364,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("NotNullFloatListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -1386,6 +1447,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1404,6 +1471,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1422,6 +1495,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_regex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1442,8 +1521,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("StringListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
@@ -1524,6 +1604,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1542,6 +1628,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1560,6 +1652,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_regex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1580,8 +1678,9 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									makeAggregateGroupArg("NotNullStringListOperatorBlock"),
 									aggregateVersionArg,
+									aggregateDistinctArg,
 								},
 							},
 						},
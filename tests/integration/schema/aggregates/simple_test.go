@@ -59,6 +59,12 @@ func TestSchemaAggregateSimpleCreatesUsersCount(t *testing.T) {
 										"type": map[string]any{
 											"name": "Users__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "field",
+													"type": map[string]any{
+														"name": "UsersScalarFieldsArg",
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -100,6 +106,13 @@ func TestSchemaAggregateSimpleCreatesUsersCount(t *testing.T) {
 											},
 										},
 									},
+									map[string]any{
+										"name": "distinct",
+										"type": map[string]any{
+											"name":        "Boolean",
+											"inputFields": nil,
+										},
+									},
 								},
 							},
 						},
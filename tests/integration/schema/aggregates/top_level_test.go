@@ -60,6 +60,12 @@ func TestSchemaAggregateTopLevelCreatesCountGivenSchema(t *testing.T) {
 											"type": map[string]any{
 												"name": "Users__CountSelector",
 												"inputFields": []any{
+													map[string]any{
+														"name": "field",
+														"type": map[string]any{
+															"name": "UsersScalarFieldsArg",
+														},
+													},
 													map[string]any{
 														"name": "filter",
 														"type": map[string]any{
@@ -81,6 +87,13 @@ func TestSchemaAggregateTopLevelCreatesCountGivenSchema(t *testing.T) {
 												},
 											},
 										},
+										map[string]any{
+											"name": "distinct",
+											"type": map[string]any{
+												"name":        "Boolean",
+												"inputFields": nil,
+											},
+										},
 									},
 								},
 							},
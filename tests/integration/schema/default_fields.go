@@ -113,6 +113,20 @@ var aggregateFields = fields{
 			"name": "Int",
 		},
 	},
+	map[string]any{
+		"name": "_max",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Comparable",
+		},
+	},
+	map[string]any{
+		"name": "_min",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Comparable",
+		},
+	},
 	map[string]any{
 		"name": "_sum",
 		"type": map[string]any{
@@ -217,12 +231,17 @@ func buildFilterArg(objectName string, fields []argDef) Field {
 			"kind": "INPUT_OBJECT",
 			"name": filterArgName,
 		}),
+		makeInputObject("_avg", "FloatOperatorBlock", nil),
+		makeInputObject("_count", "IntOperatorBlock", nil),
 		makeInputObject("_key", "IDOperatorBlock", nil),
+		makeInputObject("_max", "ComparableOperatorBlock", nil),
+		makeInputObject("_min", "ComparableOperatorBlock", nil),
 		makeInputObject("_not", filterArgName, nil),
 		makeInputObject("_or", nil, map[string]any{
 			"kind": "INPUT_OBJECT",
 			"name": filterArgName,
 		}),
+		makeInputObject("_sum", "FloatOperatorBlock", nil),
 	}
 
 	for _, field := range fields {
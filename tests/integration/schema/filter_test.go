@@ -75,6 +75,20 @@ func TestFilterForSimpleSchema(t *testing.T) {
 															},
 														},
 													},
+													map[string]any{
+														"name": "_avg",
+														"type": map[string]any{
+															"name":   "FloatOperatorBlock",
+															"ofType": nil,
+														},
+													},
+													map[string]any{
+														"name": "_count",
+														"type": map[string]any{
+															"name":   "IntOperatorBlock",
+															"ofType": nil,
+														},
+													},
 													map[string]any{
 														"name": "_key",
 														"type": map[string]any{
@@ -82,6 +96,20 @@ func TestFilterForSimpleSchema(t *testing.T) {
 															"ofType": nil,
 														},
 													},
+													map[string]any{
+														"name": "_max",
+														"type": map[string]any{
+															"name":   "ComparableOperatorBlock",
+															"ofType": nil,
+														},
+													},
+													map[string]any{
+														"name": "_min",
+														"type": map[string]any{
+															"name":   "ComparableOperatorBlock",
+															"ofType": nil,
+														},
+													},
 													map[string]any{
 														"name": "_not",
 														"type": map[string]any{
@@ -98,6 +126,13 @@ func TestFilterForSimpleSchema(t *testing.T) {
 															},
 														},
 													},
+													map[string]any{
+														"name": "_sum",
+														"type": map[string]any{
+															"name":   "FloatOperatorBlock",
+															"ofType": nil,
+														},
+													},
 													map[string]any{
 														"name": "name",
 														"type": map[string]any{
@@ -213,6 +248,20 @@ func TestFilterForOneToOneSchema(t *testing.T) {
 															},
 														},
 													},
+													map[string]any{
+														"name": "_avg",
+														"type": map[string]any{
+															"name":   "FloatOperatorBlock",
+															"ofType": nil,
+														},
+													},
+													map[string]any{
+														"name": "_count",
+														"type": map[string]any{
+															"name":   "IntOperatorBlock",
+															"ofType": nil,
+														},
+													},
 													map[string]any{
 														"name": "_key",
 														"type": map[string]any{
@@ -220,6 +269,20 @@ func TestFilterForOneToOneSchema(t *testing.T) {
 															"ofType": nil,
 														},
 													},
+													map[string]any{
+														"name": "_max",
+														"type": map[string]any{
+															"name":   "ComparableOperatorBlock",
+															"ofType": nil,
+														},
+													},
+													map[string]any{
+														"name": "_min",
+														"type": map[string]any{
+															"name":   "ComparableOperatorBlock",
+															"ofType": nil,
+														},
+													},
 													map[string]any{
 														"name": "_not",
 														"type": map[string]any{
@@ -236,6 +299,13 @@ func TestFilterForOneToOneSchema(t *testing.T) {
 															},
 														},
 													},
+													map[string]any{
+														"name": "_sum",
+														"type": map[string]any{
+															"name":   "FloatOperatorBlock",
+															"ofType": nil,
+														},
+													},
 													map[string]any{
 														"name": "author",
 														"type": map[string]any{
@@ -0,0 +1,424 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/lens-vm/lens/host-go/config/model"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/lens"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaMigrationQueryWithNativeSetDefault(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with native set default module",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "email", "Kind": "String"} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+					DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							lens.NewSetDefaultModule("email", "unknown@example.com"),
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"email": "unknown@example.com",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationQueryWithNativeCopyField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with native copy field module",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "displayName", "Kind": "String"} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+					DestinationSchemaVersionID: "bafkreibmsmzlcl7uth57ivuhfcpkztuaem3pufakbamiyk5s2t5moucmgu",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							lens.NewCopyFieldModule("name", "displayName"),
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						displayName
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":        "John",
+						"displayName": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationQueryWithNativeMapValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with native map values module",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						status: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"status": "active"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred",
+					"status": "inactive"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Shahzad",
+					"status": "banned"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "email", "Kind": "String"} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "bafkreibquvxxy7etrq2ywrqp3vabmo7qd3jwzr7kr77qm52kfapbgd5kfe",
+					DestinationSchemaVersionID: "bafkreiasan5ekqg6fghiwikonyhijj3acl7yep3325pxtw7crfthaza4am",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							lens.NewMapValuesModule(
+								"status",
+								lens.ValueMapping{From: "active", To: "enabled"},
+								lens.ValueMapping{From: "inactive", To: "disabled"},
+							),
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						status
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "Fred",
+						"status": "disabled",
+					},
+					{
+						"name":   "Shahzad",
+						"status": "banned",
+					},
+					{
+						"name":   "John",
+						"status": "enabled",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationQueryWithNativeMapValuesOfIntField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with native map values module on an int field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						points: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"points": 1
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "email", "Kind": "String"} }
+					]
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "bafkreif6yteg27vvlpty4supibga4yeczqfy6aelze5cyjxugnywsbjlja",
+					DestinationSchemaVersionID: "bafkreierfaxsswojb7stsz4jt4wzxfmcsfij3ligoiz5tkme7gxzrgkbzi",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							lens.NewMapValuesModule("points", lens.ValueMapping{From: 1, To: 10}),
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						points
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"points": int64(10),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationQueryWithNativeMapValues_WithSetDefaultToOriginal_AppliesInverse(t *testing.T) {
+	schemaVersionID1 := "bafkreibquvxxy7etrq2ywrqp3vabmo7qd3jwzr7kr77qm52kfapbgd5kfe"
+	schemaVersionID2 := "bafkreiasan5ekqg6fghiwikonyhijj3acl7yep3325pxtw7crfthaza4am"
+
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with native map values module, inverse",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						status: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "email", "Kind": "String"} }
+					]
+				`,
+			},
+			// Create John using the new schema version
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"status": "enabled"
+				}`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      schemaVersionID1,
+					DestinationSchemaVersionID: schemaVersionID2,
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							lens.NewMapValuesModule("status", lens.ValueMapping{From: "active", To: "enabled"}),
+						},
+					},
+				},
+			},
+			testUtils.SetDefaultSchemaVersion{
+				SchemaVersionID: schemaVersionID1,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						status
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"status": "active",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationQueryWithNativeModules_Chained(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with multiple native modules",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						status: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "email", "Kind": "String"} }
+					]
+				`,
+				SetAsDefaultVersion: immutable.Some(true),
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "bafkreibquvxxy7etrq2ywrqp3vabmo7qd3jwzr7kr77qm52kfapbgd5kfe",
+					DestinationSchemaVersionID: "bafkreiasan5ekqg6fghiwikonyhijj3acl7yep3325pxtw7crfthaza4am",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							lens.NewSetDefaultModule("status", "new"),
+							lens.NewMapValuesModule("status", lens.ValueMapping{From: "new", To: "pending"}),
+							lens.NewCopyFieldModule("status", "email"),
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						status
+						email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "John",
+						"status": "pending",
+						"email":  "pending",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationQueryWithNativeMapValues_InvalidValues_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with native map values module and invalid values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "bafkreih27vuxrj4j2tmxnibfm77wswa36xji74hwhq7deipj5rvh3qyabq",
+					DestinationSchemaVersionID: "bafkreid5bpw7sipm63l5gxxjrs34yrq2ur5xrzyseez5rnj3pvnvkaya6m",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							{
+								Path: lens.MapValuesModulePath,
+								Arguments: map[string]any{
+									"target": "name",
+									"values": "John",
+								},
+							},
+						},
+					},
+				},
+				ExpectedError: "invalid native lens module argument",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
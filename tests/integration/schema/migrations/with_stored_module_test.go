@@ -0,0 +1,77 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/lens-vm/lens/host-go/config/model"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaMigrationWithUnknownStoredModule_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with unknown stored module",
+		Actions: []any{
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "does not exist",
+					DestinationSchemaVersionID: "also does not exist",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							{
+								Path: "/does/not/exist.wasm",
+							},
+						},
+					},
+					WasmModuleIDs: map[string]string{
+						"/does/not/exist.wasm": "bafkreibqmajfuynw4zkvehkwtt7whg2awomc5evze5cg2wk3e7acwjbytu",
+					},
+				},
+				ExpectedError: "the content of the wasm module could not be found",
+			},
+			testUtils.GetMigrations{
+				ExpectedResults: []client.LensConfig{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaMigrationWithInvalidStoredModuleID_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema migration, with invalid stored module ID",
+		Actions: []any{
+			testUtils.ConfigureMigration{
+				LensConfig: client.LensConfig{
+					SourceSchemaVersionID:      "does not exist",
+					DestinationSchemaVersionID: "also does not exist",
+					Lens: model.Lens{
+						Lenses: []model.LensModule{
+							{
+								Path: "/does/not/exist.wasm",
+							},
+						},
+					},
+					WasmModuleIDs: map[string]string{
+						"/does/not/exist.wasm": "not a cid",
+					},
+				},
+				ExpectedError: "invalid wasm module ID",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -33,7 +33,7 @@ func TestSchemaUpdatesAddFieldCRDTObjectWithBoolFieldErrors(t *testing.T) {
 						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "foo", "Kind": 2, "Typ":2} }
 					]
 				`,
-				ExpectedError: "only default or LWW (last writer wins) CRDT types are supported. Name: foo, CRDTType: 2",
+				ExpectedError: "only default, LWW (last writer wins), PN counter, OR set or RGA CRDT types are supported. Name: foo, CRDTType: 2",
 			},
 		},
 	}
@@ -88,56 +88,6 @@ func TestSchemaUpdatesAddFieldKind13(t *testing.T) {
 	testUtils.ExecuteTestCase(t, test)
 }
 
-func TestSchemaUpdatesAddFieldKind14(t *testing.T) {
-	test := testUtils.TestCase{
-		Description: "Test schema update, add field with kind deprecated (14)",
-		Actions: []any{
-			testUtils.SchemaUpdate{
-				Schema: `
-					type Users {
-						name: String
-					}
-				`,
-			},
-			testUtils.SchemaPatch{
-				Patch: `
-					[
-						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "foo", "Kind": 14} }
-					]
-				`,
-				ExpectedError: "no type found for given name. Type: 14",
-			},
-		},
-	}
-	testUtils.ExecuteTestCase(t, test)
-}
-
-func TestSchemaUpdatesAddFieldKind15(t *testing.T) {
-	test := testUtils.TestCase{
-		Description: "Test schema update, add field with kind deprecated (15)",
-		Actions: []any{
-			testUtils.SchemaUpdate{
-				Schema: `
-					type Users {
-						name: String
-					}
-				`,
-			},
-			testUtils.SchemaPatch{
-				Patch: `
-					[
-						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "foo", "Kind": 15} }
-					]
-				`,
-				ExpectedError: "no type found for given name. Type: 15",
-			},
-		},
-	}
-	testUtils.ExecuteTestCase(t, test)
-}
-
-// This test is currently the first unsupported value, if it becomes supported
-// please update this test to be the newly lowest unsupported value.
 func TestSchemaUpdatesAddFieldKind22(t *testing.T) {
 	test := testUtils.TestCase{
 		Description: "Test schema update, add field with kind unsupported (22)",
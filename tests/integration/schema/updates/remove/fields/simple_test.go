@@ -16,7 +16,7 @@ import (
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
 )
 
-func TestSchemaUpdatesRemoveFieldErrors(t *testing.T) {
+func TestSchemaUpdatesRemoveField(t *testing.T) {
 	test := testUtils.TestCase{
 		Description: "Test schema update, remove field",
 		Actions: []any{
@@ -28,13 +28,38 @@ func TestSchemaUpdatesRemoveFieldErrors(t *testing.T) {
 					}
 				`,
 			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"email": "john@source.hub"
+				}`,
+			},
 			testUtils.SchemaPatch{
 				Patch: `
 					[
 						{ "op": "remove", "path": "/Users/Fields/2" }
 					]
 				`,
-				ExpectedError: "deleting an existing field is not supported. Name: name",
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"email": "john@source.hub",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				ExpectedError: `Cannot query field "name" on type "Users".`,
 			},
 		},
 	}
@@ -59,7 +84,7 @@ func TestSchemaUpdatesRemoveAllFieldsErrors(t *testing.T) {
 						{ "op": "remove", "path": "/Users/Fields" }
 					]
 				`,
-				ExpectedError: "deleting an existing field is not supported",
+				ExpectedError: "deleting the key field or relation fields is not supported. Name: _key",
 			},
 		},
 	}
@@ -109,7 +134,7 @@ func TestSchemaUpdatesRemoveFieldIDErrors(t *testing.T) {
 						{ "op": "remove", "path": "/Users/Fields/2/ID" }
 					]
 				`,
-				ExpectedError: "deleting an existing field is not supported. Name: name, ID: 2",
+				ExpectedError: "the name of an existing field may not be reused by a different field. Name: name",
 			},
 		},
 	}
@@ -134,7 +159,7 @@ func TestSchemaUpdatesRemoveFieldKindErrors(t *testing.T) {
 						{ "op": "remove", "path": "/Users/Fields/2/Kind" }
 					]
 				`,
-				ExpectedError: "mutating an existing field is not supported. ID: 2, ProposedName: ",
+				ExpectedError: "the kind of an existing field may only be widened. Name: name, ExistingKind: String, ProposedKind: 0",
 			},
 		},
 	}
@@ -252,3 +277,150 @@ func TestSchemaUpdatesRemoveFieldRelationTypeErrors(t *testing.T) {
 	}
 	testUtils.ExecuteTestCase(t, test)
 }
+
+func TestSchemaUpdatesRemoveFieldByName(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, remove field by name",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						email: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"email": "john@source.hub"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "remove", "path": "/Users/Fields/name" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"email": "john@source.hub",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRemoveFieldThenAddFieldWithSameName(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, remove field then add a new field with the same name",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						email: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"email": "john@source.hub"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "remove", "path": "/Users/Fields/name" }
+					]
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "name", "Kind": 11} }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  nil,
+						"email": "john@source.hub",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRemoveRelationFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, remove relation field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						author: Author
+					}
+					type Author {
+						name: String
+						books: [Book]
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "remove", "path": "/Author/Fields/books" }
+					]
+				`,
+				ExpectedError: "deleting the key field or relation fields is not supported. Name: books",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRemoveIndexedFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, remove indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index
+						email: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "remove", "path": "/Users/Fields/name" }
+					]
+				`,
+				ExpectedError: "deleting an indexed field is not supported. Name: name, Index: Users_name_ASC",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -16,7 +16,7 @@ import (
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
 )
 
-func TestSchemaUpdatesReplaceFieldErrors(t *testing.T) {
+func TestSchemaUpdatesReplaceFieldDeletesExistingField(t *testing.T) {
 	test := testUtils.TestCase{
 		Description: "Test schema update, replace field",
 		Actions: []any{
@@ -28,13 +28,34 @@ func TestSchemaUpdatesReplaceFieldErrors(t *testing.T) {
 					}
 				`,
 			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"email": "john@source.hub"
+				}`,
+			},
 			testUtils.SchemaPatch{
 				Patch: `
 					[
 						{ "op": "replace", "path": "/Users/Fields/2", "value": {"Name": "Fax", "Kind": 11} }
 					]
 				`,
-				ExpectedError: "deleting an existing field is not supported. Name: name, ID: 2",
+			},
+			testUtils.Request{
+				// The replacement field is a new field, and so does not have the values of the
+				// field that it replaced.
+				Request: `query {
+					Users {
+						email
+						Fax
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"email": "john@source.hub",
+						"Fax":   nil,
+					},
+				},
 			},
 		},
 	}
@@ -0,0 +1,388 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replace
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaUpdatesReplaceFieldKindIntToFloat(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind Int with Float",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/age/Kind", "value": "Float" }
+					]
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Shahzad",
+					"age": 20.5
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users (filter: {age: {_gt: 20.7}}) {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"age":  float64(21),
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindIntToString(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind Int with String",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"age": 21
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/age/Kind", "value": "String" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users (filter: {age: {_eq: "21"}}) {
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"age": "21",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindBoolAndFloatToString(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kinds Boolean and Float with String",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						verified: Boolean
+						rating: Float
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"verified": true,
+					"rating": 4.5
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/verified/Kind", "value": "String" },
+						{ "op": "replace", "path": "/Users/Fields/rating/Kind", "value": "String" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						verified
+						rating
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"verified": "true",
+						"rating":   "4.5",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindStringToDateTime(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind String with DateTime",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						joined: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"joined": "2017-07-23T03:46:56Z"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Shahzad",
+					"joined": "last tuesday"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/joined/Kind", "value": "DateTime" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						joined
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":   "Shahzad",
+						"joined": nil,
+					},
+					{
+						"name":   "John",
+						"joined": "2017-07-23T03:46:56Z",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindIntArrayToNillableFloatArray(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind [Int!] with [Float]",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						scores: [Int!]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"scores": [1, 2]
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/scores/Kind", "value": "[Float]" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						scores
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"scores": []immutable.Option[float64]{
+							immutable.Some[float64](1),
+							immutable.Some[float64](2),
+						},
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindWithRename(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind and name",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"age": 21
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/age/Kind", "value": "String" },
+						{ "op": "replace", "path": "/Users/Fields/age/Name", "value": "ageText" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						ageText
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"ageText": "21",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindNarrowingErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind Float with Int",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						rating: Float
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/rating/Kind", "value": "Int" }
+					]
+				`,
+				ExpectedError: "the kind of an existing field may only be widened. Name: rating, ExistingKind: Float, ProposedKind: Int",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindNillableArrayToNonNillableArrayErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind [Int] with [Int!]",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						scores: [Int]
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/scores/Kind", "value": "[Int!]" }
+					]
+				`,
+				ExpectedError: "the kind of an existing field may only be widened. Name: scores, ExistingKind: [Int], ProposedKind: [Int!]",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindOfIndexedFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind of indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						age: Int @index
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/age/Kind", "value": "Float" }
+					]
+				`,
+				ExpectedError: "changing the kind of an indexed field is not supported. Name: age, Index: Users_age_ASC",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesReplaceFieldKindOfCounterErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field kind of PN counter field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						points: Int @crdt(type: pncounter)
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/points/Kind", "value": "Float" }
+					]
+				`,
+				ExpectedError: "only the kind of LWW register fields may be changed. Name: points, CRDT: 4",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,349 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replace
+
+import (
+	"testing"
+
+	"github.com/lens-vm/lens/host-go/config/model"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/lens"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaUpdatesRenameField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						email: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"email": "john@source.hub"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/2/Name", "value": "fullName" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						fullName
+						email
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fullName": "John",
+						"email":    "john@source.hub",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				ExpectedError: `Cannot query field "name" on type "Users".`,
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameFieldByName(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename field referenced by name",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/name/Name", "value": "fullName" },
+						{ "op": "test", "path": "/Users/Fields/fullName/Kind", "value": 11 }
+					]
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"fullName": "Shahzad"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						fullName
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fullName": "Shahzad",
+					},
+					{
+						"fullName": "John",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameFieldConfiguresMigration(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename field configures a migration between the schema versions",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						email: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/name/Name", "value": "fullName" },
+						{ "op": "remove", "path": "/Users/Fields/email" }
+					]
+				`,
+			},
+			testUtils.GetMigrations{
+				ExpectedResults: []client.LensConfig{
+					{
+						SourceSchemaVersionID:      "bafkreie73xdaaouiu476vygjwddkt7o7bxbsh4v2pnb7viejnnuf5um7km",
+						DestinationSchemaVersionID: "bafkreib4wn5kmbjiy5ua6y5rrqt3owjx45g7zltzdasnnsfldrwdywn4ly",
+						Lens: model.Lens{
+							Lenses: []model.LensModule{
+								lens.NewRenameFieldModule("name", "fullName"),
+								lens.NewDropFieldModule("email"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameFieldWithCid(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename field, query historical version by cid",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/name/Name", "value": "fullName" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users (
+							cid: "bafybeifwfw3g4q6tagffdwq4orrouoosdlsc5rb67q2uj7oplkq7ax5ysm",
+							dockey: "bae-decf6467-4c7c-50d7-b09d-0a7097ef6bad"
+						) {
+						fullName
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fullName": "John",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameFieldCommits(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename field, commits query returns historical field name",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/name/Name", "value": "fullName" }
+					]
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					commits(fieldId: "1") {
+						cid
+						fieldName
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"cid":       "bafybeigkzwldvaouqhgfvw3du3fho74ljkrhywo3tcd5mtgfxz7wlrg7ci",
+						"fieldName": "name",
+					},
+				},
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameKeyFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename key field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/0/Name", "value": "key" }
+					]
+				`,
+				ExpectedError: "renaming the key field or relation fields is not supported. Name: _key, ProposedName: key",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameRelationFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename relation field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						author: Author
+					}
+					type Author {
+						name: String
+						books: [Book]
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Author/Fields/books/Name", "value": "works" }
+					]
+				`,
+				ExpectedError: "renaming the key field or relation fields is not supported. Name: books, ProposedName: works",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameIndexedFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, rename indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/name/Name", "value": "fullName" }
+					]
+				`,
+				ExpectedError: "renaming an indexed field is not supported. Name: name, Index: Users_name_ASC",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaUpdatesRenameFieldsSwapNamesErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, swap field names",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						email: String
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Fields/1/Name", "value": "name" },
+						{ "op": "replace", "path": "/Users/Fields/2/Name", "value": "email" }
+					]
+				`,
+				ExpectedError: "the name of an existing field may not be reused by a different field. Name: name",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, test)
+}
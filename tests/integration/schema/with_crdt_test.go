@@ -0,0 +1,196 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaWithCRDTDirective_PNCounterOnInt(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						views: Int @crdt(type: pncounter)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"views": 5
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						views
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"views": int64(5),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTDirective_PNCounterOnString_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: pncounter)
+					}
+				`,
+				ExpectedError: "CRDT type is not supported for fields of the given kind. Name: name, CRDTType: 4, Kind: String",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTDirective_ORSetOnStringArray(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: orset)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["b", "a"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"a", "b"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTDirective_ORSetOnString_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: orset)
+					}
+				`,
+				ExpectedError: "CRDT type is not supported for fields of the given kind. Name: name, CRDTType: 5, Kind: String",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTDirective_RGAOnString(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						notes: String @crdt(type: rga)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"notes": "Hello"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						notes
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":  "John",
+						"notes": "Hello",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTDirective_RGAOnInt_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						views: Int @crdt(type: rga)
+					}
+				`,
+				ExpectedError: "CRDT type is not supported for fields of the given kind. Name: views, CRDTType: 6, Kind: Int",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTDirective_UnknownType_Errors(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						views: Int @crdt(type: gcounter)
+					}
+				`,
+				ExpectedError: "no CRDT type found for given name. Type: gcounter",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
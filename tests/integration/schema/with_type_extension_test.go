@@ -0,0 +1,605 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaWithTypeExtensionOneToManyRelationToExistingType(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, new type with one-to-many relation to existing type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Post {
+						title: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"title": "Hello world"
+				}`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+
+					extend type Post {
+						comments: [Comment]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"text": "First!",
+					"post_id": "bae-c5c2684d-d606-5de8-a2b6-d325f87a805f"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Post {
+						title
+						comments {
+							text
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title": "Hello world",
+						"comments": []map[string]any{
+							{
+								"text": "First!",
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Comment {
+						text
+						post {
+							title
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"text": "First!",
+						"post": map[string]any{
+							"title": "Hello world",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithTypeExtensionOneToOneRelationToExistingType(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, new type with one-to-one relation to existing type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Profile {
+						bio: String
+						user: User @primary
+					}
+
+					extend type User {
+						profile: Profile
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "User") {
+							name
+							fields {
+								name
+								type {
+									name
+									kind
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"name": "User",
+						"fields": DefaultFields.Append(
+							Field{
+								"name": "name",
+								"type": map[string]any{
+									"kind": "SCALAR",
+									"name": "String",
+								},
+							},
+						).Append(
+							Field{
+								"name": "profile",
+								"type": map[string]any{
+									"kind": "OBJECT",
+									"name": "Profile",
+								},
+							},
+						).Append(
+							Field{
+								"name": "profile_id",
+								"type": map[string]any{
+									"kind": "SCALAR",
+									"name": "ID",
+								},
+							},
+						).Tidy(),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithTypeExtensionOneSideOnExistingType(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, existing type extended with the one side of a one-to-many relation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Author {
+						name: String
+						books: [Book]
+					}
+
+					extend type Book {
+						author: Author
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+						books {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John Grisham",
+						"books": []map[string]any{
+							{
+								"name": "Painted House",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithTypeExtensionOfUnknownTypeErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, extending an unknown type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+
+					extend type Post {
+						comments: [Comment]
+					}
+				`,
+				ExpectedError: "the type to extend does not exist. Name: Post",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithRelationToExistingTypeWithoutExtension_GeneratesInverseField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, relation to existing type without extending it",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Post {
+						title: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"title": "Hello world"
+				}`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"text": "First!",
+					"post_id": "bae-c5c2684d-d606-5de8-a2b6-d325f87a805f"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Post {
+						title
+						comments {
+							text
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title": "Hello world",
+						"comments": []map[string]any{
+							{
+								"text": "First!",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithRelationToExistingTypeWithoutExtension_GeneratesPluralInverseField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, relations to existing type from types whose plural does not just add an s",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Product {
+						name: String
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Category {
+						name: String
+						product: Product
+					}
+
+					type Address {
+						city: String
+						product: Product
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Product {
+						name
+						categories {
+							name
+						}
+						addresses {
+							city
+						}
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithManyRelationToExistingTypeWithoutExtension_GeneratesInverseField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, many relation to existing type without extending it",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Author {
+						name: String
+						books: [Book]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+						books {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John Grisham",
+						"books": []map[string]any{
+							{
+								"name": "Painted House",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithRelationToExistingTypeWithoutExtension_CreatesNewSchemaVersion(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, relation to existing type without extending it creates a new schema version",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Post {
+						title: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"title": "Hello world"
+				}`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+				`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"title": "Hello everyone"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Post {
+						title
+						comments {
+							text
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title":    "Hello everyone",
+						"comments": []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithRelationToExistingTypeWithExistingInverseFieldNameErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, relation to existing type already having a field named as the inverse field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Post {
+						title: String
+						comments: Int
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+				`,
+				ExpectedError: "the inverse relation field already exists, declare it by extending the type. " +
+					"Type: Post, Field: comments",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithRelationToExistingTypeWithTypeExtensionOverridingInverseField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, relation to existing type with the inverse field declared by a type extension",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Post {
+						title: String
+						comments: Int
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+
+					extend type Post {
+						replies: [Comment]
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "Post") {
+							name
+							fields {
+								name
+								type {
+									name
+									kind
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"name": "Post",
+						"fields": DefaultFields.Append(
+							Field{
+								"name": "title",
+								"type": map[string]any{
+									"kind": "SCALAR",
+									"name": "String",
+								},
+							},
+						).Append(
+							Field{
+								"name": "comments",
+								"type": map[string]any{
+									"kind": "SCALAR",
+									"name": "Int",
+								},
+							},
+						).Append(
+							Field{
+								"name": "replies",
+								"type": map[string]any{
+									"kind": "LIST",
+									"name": nil,
+								},
+							},
+						).Tidy(),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithTypeExtensionWithIndexErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, type extension with an indexed field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Post {
+						title: String
+					}
+				`,
+			},
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Comment {
+						text: String
+						post: Post
+					}
+
+					extend type Post {
+						comments: [Comment] @index
+					}
+				`,
+				ExpectedError: "indexes may not be declared within a type extension. Type: Post",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
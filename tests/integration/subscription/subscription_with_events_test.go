@@ -0,0 +1,295 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package subscription
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSubscriptionWithEventTypeAndCreateUpdateDeleteMutations(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with event type and user creation, update and deletion",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User {
+						_key
+						name
+						age
+						_event {
+							type
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d",
+						"name": "John",
+						"age":  int64(27),
+						"_event": map[string]any{
+							"type": "CREATE",
+						},
+					},
+					{
+						"_key": "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d",
+						"name": "John",
+						"age":  int64(28),
+						"_event": map[string]any{
+							"type": "UPDATE",
+						},
+					},
+					{
+						"_key": "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d",
+						"name": "John",
+						"age":  int64(28),
+						"_event": map[string]any{
+							"type": "DELETE",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(data: "{\"name\": \"John\",\"age\": 27,\"points\": 42.1,\"verified\": true}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_User(id: "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d", data: "{\"age\": 28}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					delete_User(id: "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d") {
+						_key
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d",
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithUpdateEventAndPreviousValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with update event and previous values",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(events: [UPDATE]) {
+						name
+						age
+						_event {
+							type
+							previous {
+								age
+							}
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"age":  int64(28),
+						"_event": map[string]any{
+							"type": "UPDATE",
+							"previous": map[string]any{
+								"age": int64(27),
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(data: "{\"name\": \"John\",\"age\": 27,\"points\": 42.1,\"verified\": true}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_User(id: "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d", data: "{\"age\": 28}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithDeleteEventAndPreviousValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with delete event and previous values",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(events: [DELETE]) {
+						_key
+						_event {
+							type
+							previous {
+								name
+								age
+							}
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d",
+						"_event": map[string]any{
+							"type": "DELETE",
+							"previous": map[string]any{
+								"name": "John",
+								"age":  int64(27),
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(data: "{\"name\": \"John\",\"age\": 27,\"points\": 42.1,\"verified\": true}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					delete_User(id: "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d") {
+						_key
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d",
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithCreateEventHasNoPreviousValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with create event has no previous values",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(events: CREATE) {
+						name
+						_event {
+							type
+							previous {
+								name
+							}
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"_event": map[string]any{
+							"type":     "CREATE",
+							"previous": nil,
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(data: "{\"name\": \"John\",\"age\": 27,\"points\": 42.1,\"verified\": true}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_User(id: "bae-0a24cf29-b2c2-5861-9d00-abd6250c475d", data: "{\"age\": 28}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithUnknownEventType(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with unknown event type",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(events: [MERGE]) {
+						name
+					}
+				}`,
+				ExpectedError: "unknown subscription event type. Event: MERGE",
+			},
+		},
+	}
+
+	execute(t, test)
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package subscription
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSubscriptionWithResumeToken_ReplaysChangesAfterToken(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with resume token replays the changes after the token",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Addo",
+					"age": 31
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"age": 28
+				}`,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(after: "1") {
+						name
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Addo",
+						"age":  int64(31),
+					},
+					{
+						"name": "John",
+						"age":  int64(28),
+					},
+					{
+						"name": "Shahzad",
+						"age":  int64(20),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `mutation {
+					create_User(data: "{\"name\": \"Shahzad\",\"age\": 20}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Shahzad",
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithResumeToken_ReplaysDeletedDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with resume token replays the deletion of documents",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.DeleteDoc{
+				DocID: 0,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(after: "0") {
+						_key
+						name
+						_event {
+							type
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-88b63198-7d38-5714-a9ff-21ba46374fd1",
+						"name": "John",
+						"_event": map[string]any{
+							"type": "CREATE",
+						},
+					},
+					{
+						"_key": "bae-88b63198-7d38-5714-a9ff-21ba46374fd1",
+						"name": "John",
+						"_event": map[string]any{
+							"type": "DELETE",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithResumeToken_WithFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with resume token and filter replays the matching changes",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 27
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Addo",
+					"age": 31
+				}`,
+			},
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(after: "0", filter: {age: {_gt: 30}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Addo",
+					},
+				},
+			},
+		},
+	}
+
+	execute(t, test)
+}
+
+func TestSubscriptionWithResumeToken_InvalidToken(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Subscription with invalid resume token",
+		Actions: []any{
+			testUtils.SubscriptionRequest{
+				Request: `subscription {
+					User(after: "abc") {
+						name
+					}
+				}`,
+				ExpectedError: "invalid subscription resume token",
+			},
+		},
+	}
+
+	execute(t, test)
+}
@@ -1247,7 +1247,8 @@ func createIndex(
 	actionNodes := getNodes(action.NodeID, s.nodes)
 	for nodeID, collections := range getNodeCollections(action.NodeID, s.collections) {
 		indexDesc := client.IndexDescription{
-			Name: action.IndexName,
+			Name:   action.IndexName,
+			Unique: action.Unique,
 		}
 		if action.FieldName != "" {
 			indexDesc.Fields = []client.IndexedFieldDescription{